@@ -17,6 +17,9 @@ type mockMetrics struct {
 func (m *mockMetrics) IncrementCounter(ctx context.Context, name string, labels ...string) {
 }
 
+func (m *mockMetrics) SetGauge(name string, value float64, labels ...string) {
+}
+
 func initializeTest(t *testing.T) {
 	c := kafka.New(kafka.Config{
 		Broker:       "localhost:9092",
@@ -6,7 +6,7 @@ management of these concerns across different parts of an application.
 Supported data sources:
   - Databases (Cassandra, ClickHouse, MongoDB, DGraph, MySQL, PostgreSQL, SQLite)
   - Key-value storages (Redis, BadgerDB)
-  - Pub/Sub systems (Azure Event Hub, Google as backend, Kafka, MQTT)
+  - Pub/Sub systems (Azure Event Hub, Google as backend, Kafka, MQTT, NATS JetStream, Redis Streams)
   - Search engines (Solr)
   - File systems (FTP, SFTP, S3)
 */
@@ -14,20 +14,26 @@ package container
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"gofr.dev/pkg/gofr/ai"
 	"strconv"
 	"strings"
 	"time"
 
 	_ "github.com/go-sql-driver/mysql" // This is required to be blank import
+	"google.golang.org/grpc"
 
 	"gofr.dev/pkg/gofr/config"
+	"gofr.dev/pkg/gofr/datasource"
 	"gofr.dev/pkg/gofr/datasource/file"
 	"gofr.dev/pkg/gofr/datasource/pubsub"
 	"gofr.dev/pkg/gofr/datasource/pubsub/google"
 	"gofr.dev/pkg/gofr/datasource/pubsub/kafka"
+	"gofr.dev/pkg/gofr/datasource/pubsub/memory"
 	"gofr.dev/pkg/gofr/datasource/pubsub/mqtt"
+	redispubsub "gofr.dev/pkg/gofr/datasource/pubsub/redis"
 	"gofr.dev/pkg/gofr/datasource/redis"
 	"gofr.dev/pkg/gofr/datasource/sql"
 	"gofr.dev/pkg/gofr/logging"
@@ -48,8 +54,13 @@ type Container struct {
 	appVersion string
 
 	Services       map[string]service.HTTP
+	GRPCServices   map[string]GRPCService
 	metricsManager metrics.Manager
 	PubSub         pubsub.Client
+	// Codecs are the codecs of the topics, registered with App.UseCodec.
+	Codecs map[string]pubsub.Codec
+	// Subscriptions reports the health of the subscriptions of the app, along with the health of the pub/sub.
+	Subscriptions SubscriptionHealthChecker
 
 	Redis Redis
 	SQL   DB
@@ -121,24 +132,10 @@ func (c *Container) Create(conf config.Config) {
 
 	c.SQL = sql.NewSQL(conf, c.Logger, c.metricsManager)
 
-	switch strings.ToUpper(conf.Get("PUBSUB_BACKEND")) {
+	switch backend := strings.ToUpper(conf.Get("PUBSUB_BACKEND")); backend {
 	case "KAFKA":
 		if conf.Get("PUBSUB_BROKER") != "" {
-			partition, _ := strconv.Atoi(conf.GetOrDefault("PARTITION_SIZE", "0"))
-			offSet, _ := strconv.Atoi(conf.GetOrDefault("PUBSUB_OFFSET", "-1"))
-			batchSize, _ := strconv.Atoi(conf.GetOrDefault("KAFKA_BATCH_SIZE", strconv.Itoa(kafka.DefaultBatchSize)))
-			batchBytes, _ := strconv.Atoi(conf.GetOrDefault("KAFKA_BATCH_BYTES", strconv.Itoa(kafka.DefaultBatchBytes)))
-			batchTimeout, _ := strconv.Atoi(conf.GetOrDefault("KAFKA_BATCH_TIMEOUT", strconv.Itoa(kafka.DefaultBatchTimeout)))
-
-			c.PubSub = kafka.New(kafka.Config{
-				Broker:          conf.Get("PUBSUB_BROKER"),
-				Partition:       partition,
-				ConsumerGroupID: conf.Get("CONSUMER_ID"),
-				OffSet:          offSet,
-				BatchSize:       batchSize,
-				BatchBytes:      batchBytes,
-				BatchTimeout:    batchTimeout,
-			}, c.Logger, c.metricsManager)
+			c.PubSub = c.createKafkaPubSub(conf)
 		}
 	case "GOOGLE":
 		c.PubSub = google.New(google.Config{
@@ -147,6 +144,16 @@ func (c *Container) Create(conf config.Config) {
 		}, c.Logger, c.metricsManager)
 	case "MQTT":
 		c.PubSub = c.createMqttPubSub(conf)
+	case "MEMORY":
+		c.PubSub = memory.New(memory.Config{ConsumerGroup: conf.Get("CONSUMER_ID")}, c.Logger, c.metricsManager)
+	case "REDIS":
+		if conf.Get("REDIS_HOST") != "" {
+			c.PubSub = c.createRedisPubSub(conf)
+		}
+	default:
+		if backend != "" {
+			c.PubSub = c.createRegisteredPubSub(backend, conf)
+		}
 	}
 
 	c.File = file.New(c.Logger)
@@ -167,9 +174,68 @@ func (c *Container) Close() error {
 		err = errors.Join(err, c.PubSub.Close())
 	}
 
+	for _, svc := range c.GRPCServices {
+		err = errors.Join(err, svc.Close())
+	}
+
 	return err
 }
 
+func (c *Container) createKafkaPubSub(conf config.Config) pubsub.Client {
+	partition, _ := strconv.Atoi(conf.GetOrDefault("PARTITION_SIZE", "0"))
+	batchSize, _ := strconv.Atoi(conf.GetOrDefault("KAFKA_BATCH_SIZE", strconv.Itoa(kafka.DefaultBatchSize)))
+	batchBytes, _ := strconv.Atoi(conf.GetOrDefault("KAFKA_BATCH_BYTES", strconv.Itoa(kafka.DefaultBatchBytes)))
+	batchTimeout, _ := strconv.Atoi(conf.GetOrDefault("KAFKA_BATCH_TIMEOUT", strconv.Itoa(kafka.DefaultBatchTimeout)))
+	insecureSkipVerify, _ := strconv.ParseBool(conf.GetOrDefault("KAFKA_TLS_INSECURE_SKIP_VERIFY", "false"))
+
+	var offSet int
+
+	switch offset := strings.ToLower(conf.GetOrDefault("PUBSUB_OFFSET", "-1")); offset {
+	case "latest":
+		offSet = kafka.OffsetLatest
+	case "earliest":
+		offSet = kafka.OffsetEarliest
+	default:
+		offSet, _ = strconv.Atoi(offset)
+	}
+
+	sessionTimeout, err := time.ParseDuration(conf.GetOrDefault("KAFKA_SESSION_TIMEOUT", "30s"))
+	if err != nil {
+		sessionTimeout = 30 * time.Second
+
+		c.Logger.Debug("KAFKA_SESSION_TIMEOUT is invalid, setting it to 30 seconds")
+	}
+
+	// the batch subscriptions wait for 1s by default
+	subscribeBatchWait, err := time.ParseDuration(conf.GetOrDefault("KAFKA_SUBSCRIBE_BATCH_WAIT", "0s"))
+	if err != nil {
+		c.Logger.Debug("KAFKA_SUBSCRIBE_BATCH_WAIT is invalid, using the default wait of the batch subscriptions")
+	}
+
+	return kafka.New(kafka.Config{
+		Broker:             conf.Get("PUBSUB_BROKER"),
+		Partition:          partition,
+		ConsumerGroupID:    conf.Get("CONSUMER_ID"),
+		OffSet:             offSet,
+		BatchSize:          batchSize,
+		BatchBytes:         batchBytes,
+		BatchTimeout:       batchTimeout,
+		SubscribeBatchWait: subscribeBatchWait,
+		SecurityProtocol:   conf.Get("KAFKA_SECURITY_PROTOCOL"),
+		SASLMechanism:      conf.Get("KAFKA_SASL_MECHANISM"),
+		SASLUser:           conf.Get("KAFKA_SASL_USERNAME"),
+		SASLPassword:       conf.Get("KAFKA_SASL_PASSWORD"),
+		TLS: kafka.TLSConfig{
+			CertFile:           conf.Get("KAFKA_TLS_CERT_FILE"),
+			KeyFile:            conf.Get("KAFKA_TLS_KEY_FILE"),
+			CACertFile:         conf.Get("KAFKA_TLS_CA_CERT_FILE"),
+			InsecureSkipVerify: insecureSkipVerify,
+		},
+		SessionTimeout: sessionTimeout,
+		IsolationLevel: conf.Get("KAFKA_ISOLATION_LEVEL"),
+	}, c.Logger, c.metricsManager)
+}
+
 func (c *Container) createMqttPubSub(conf config.Config) pubsub.Client {
 	var qos byte
 
@@ -208,6 +274,51 @@ func (c *Container) createMqttPubSub(conf config.Config) pubsub.Client {
 	return mqtt.New(configs, c.Logger, c.metricsManager)
 }
 
+// createRedisPubSub creates the Redis Streams client, connecting to the server of the REDIS_* configs, along with the
+// database and the TLS used by the Redis datasource.
+func (c *Container) createRedisPubSub(conf config.Config) pubsub.Client {
+	port := conf.GetOrDefault("REDIS_PORT", "6379")
+	db, _ := strconv.Atoi(conf.Get("REDIS_DB"))
+	maxLen, _ := strconv.ParseInt(conf.Get("REDIS_STREAMS_MAX_LEN"), 10, 64)
+	blockTimeout, _ := time.ParseDuration(conf.Get("REDIS_STREAMS_BLOCK_TIMEOUT"))
+	claimIdleTime, _ := time.ParseDuration(conf.Get("REDIS_STREAMS_CLAIM_IDLE_TIME"))
+
+	tlsConfig, err := redis.TLSConfig(conf)
+	if err != nil {
+		c.Logger.Errorf("could not connect to redis streams, error: %v", err)
+
+		return nil
+	}
+
+	return redispubsub.New(redispubsub.Config{
+		Addr:          conf.Get("REDIS_HOST") + ":" + port,
+		Username:      conf.Get("REDIS_USER"),
+		Password:      conf.Get("REDIS_PASSWORD"),
+		DB:            db,
+		TLS:           tlsConfig,
+		ConsumerGroup: conf.Get("CONSUMER_ID"),
+		ConsumerName:  conf.Get("REDIS_STREAMS_CONSUMER_NAME"),
+		MaxLen:        maxLen,
+		BlockTimeout:  blockTimeout,
+		ClaimIdleTime: claimIdleTime,
+	}, c.Logger, c.metricsManager)
+}
+
+// GRPCService is a connection to a gRPC service, which can be used with the generated clients of the service.
+type GRPCService interface {
+	grpc.ClientConnInterface
+
+	HealthCheck(ctx context.Context) datasource.Health
+	Close() error
+}
+
+// GetGRPCService returns the connection to a registered gRPC service, to be used with the generated client of
+// the service, e.g. NewCustomerServiceClient(c.GetGRPCService("customer")).
+// gRPC services are registered from AddGRPCService method of GoFr object.
+func (c *Container) GetGRPCService(serviceName string) GRPCService {
+	return c.GRPCServices[serviceName]
+}
+
 // GetHTTPService returns registered HTTP services.
 // HTTP services are registered from AddHTTPService method of GoFr object.
 func (c *Container) GetHTTPService(serviceName string) service.HTTP {
@@ -231,6 +342,9 @@ func (c *Container) registerFrameworkMetrics() {
 		httpBuckets := []float64{.001, .003, .005, .01, .02, .03, .05, .1, .2, .3, .5, .75, 1, 2, 3, 5, 10, 30}
 		c.Metrics().NewHistogram("app_http_response", "Response time of HTTP requests in seconds.", httpBuckets...)
 		c.Metrics().NewHistogram("app_http_service_response", "Response time of HTTP service requests in seconds.", httpBuckets...)
+		c.Metrics().NewHistogram("app_grpc_response", "Response time of gRPC requests in seconds.", httpBuckets...)
+		c.Metrics().NewCounter("app_http_service_cache_hit_count", "Number of HTTP service requests served from the cache.")
+		c.Metrics().NewCounter("app_http_service_cache_miss_count", "Number of HTTP service requests not served from the cache.")
 	}
 
 	{ // Redis metrics
@@ -250,6 +364,23 @@ func (c *Container) registerFrameworkMetrics() {
 	c.Metrics().NewCounter("app_pubsub_publish_success_count", "Number of successful publish operations.")
 	c.Metrics().NewCounter("app_pubsub_subscribe_total_count", "Number of total subscribe operations.")
 	c.Metrics().NewCounter("app_pubsub_subscribe_success_count", "Number of successful subscribe operations.")
+	c.Metrics().NewGauge("app_pubsub_subscriber_workers", "Number of workers of the concurrent subscriptions.")
+	c.Metrics().NewCounter("app_pubsub_subscriber_error_count", "Number of messages the subscription handlers failed to handle.")
+	c.Metrics().NewHistogram("app_pubsub_subscriber_handler_duration", "Time taken by the subscription handlers in seconds.",
+		.001, .003, .005, .01, .02, .03, .05, .1, .2, .3, .5, .75, 1, 2, 3, 5, 10, 30)
+	c.Metrics().NewGauge("app_pubsub_consumer_lag", "Number of messages of the topic yet to be consumed.")
+	c.Metrics().NewGauge("app_pubsub_consumer_lag_seconds", "Time between publishing and consuming the last message of the topic.")
+	c.Metrics().NewUpDownCounter("app_pubsub_subscriber_busy_workers", "Number of workers handling a message.")
+	c.Metrics().NewUpDownCounter("app_pubsub_subscriber_queue_depth", "Number of messages queued for the workers.")
+
+	{ // outbox metrics
+		outboxBuckets := []float64{.5, 1, 2, 5, 10, 30, 60, 120, 300, 600}
+		c.Metrics().NewGauge("app_outbox_pending_messages", "Number of outbox messages yet to be published.")
+		c.Metrics().NewCounter("app_outbox_published_count", "Number of outbox messages published.")
+		c.Metrics().NewCounter("app_outbox_publish_failed_count", "Number of failed attempts to publish outbox messages.")
+		c.Metrics().NewHistogram("app_outbox_publish_lag", "Time from writing outbox messages to publishing them in seconds.",
+			outboxBuckets...)
+	}
 }
 
 func (c *Container) GetAppName() string {
@@ -260,8 +391,74 @@ func (c *Container) GetAppVersion() string {
 	return c.appVersion
 }
 
+// EncodeMessage encodes the value with the codec of the topic, to be published to it. The values of the topics without
+// a codec are encoded as JSON.
+func (c *Container) EncodeMessage(ctx context.Context, topic string, v any) ([]byte, error) {
+	return pubsub.Encode(ctx, c.Codecs[topic], topic, v)
+}
+
+// GetPublisher returns the publisher of the pub/sub backend, which encodes the messages published to the topics with a
+// codec, registered with App.UseCodec, with their codec.
 func (c *Container) GetPublisher() pubsub.Publisher {
-	return c.PubSub
+	if len(c.Codecs) == 0 || c.PubSub == nil {
+		return c.PubSub
+	}
+
+	return &codecPublisher{Publisher: c.PubSub, codecs: c.Codecs}
+}
+
+// codecPublisher encodes the messages published to the topics with a codec. The messages are the JSON of the values,
+// while the messages already encoded by the codec, e.g. with EncodeMessage, are published as they are when the codec
+// recognizes them.
+type codecPublisher struct {
+	pubsub.Publisher
+
+	codecs map[string]pubsub.Codec
+}
+
+// encodedChecker is implemented by the codecs which recognize the messages they encoded, e.g. by the header of their
+// wire format, as the Avro and Protobuf codecs of the codec package do.
+type encodedChecker interface {
+	IsEncoded(data []byte) bool
+}
+
+func (p *codecPublisher) Publish(ctx context.Context, topic string, message []byte) error {
+	message, err := p.encode(ctx, topic, message)
+	if err != nil {
+		return err
+	}
+
+	return p.Publisher.Publish(ctx, topic, message)
+}
+
+func (p *codecPublisher) PublishMessage(ctx context.Context, topic string, message *pubsub.PublishMessage) error {
+	value, err := p.encode(ctx, topic, message.Value)
+	if err != nil {
+		return err
+	}
+
+	encoded := *message
+	encoded.Value = value
+
+	return p.Publisher.PublishMessage(ctx, topic, &encoded)
+}
+
+func (p *codecPublisher) encode(ctx context.Context, topic string, message []byte) ([]byte, error) {
+	codec, ok := p.codecs[topic]
+	if !ok {
+		return message, nil
+	}
+
+	if checker, ok := codec.(encodedChecker); ok && checker.IsEncoded(message) {
+		return message, nil
+	}
+
+	if !json.Valid(message) {
+		return nil, fmt.Errorf("%w: message of topic %s is neither JSON nor encoded by its codec", pubsub.ErrInvalidMessage,
+			topic)
+	}
+
+	return codec.Encode(ctx, topic, json.RawMessage(message))
 }
 
 func (c *Container) GetSubscriber() pubsub.Subscriber {
@@ -1,16 +1,24 @@
 package container
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"testing"
 
+	"github.com/alicebob/miniredis/v2"
 	"github.com/gorilla/websocket"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
 	"gofr.dev/pkg/gofr/config"
+	"gofr.dev/pkg/gofr/datasource"
+	"gofr.dev/pkg/gofr/datasource/pubsub"
+	"gofr.dev/pkg/gofr/datasource/pubsub/memory"
 	"gofr.dev/pkg/gofr/datasource/pubsub/mqtt"
+	redispubsub "gofr.dev/pkg/gofr/datasource/pubsub/redis"
 	gofrRedis "gofr.dev/pkg/gofr/datasource/redis"
 	gofrSql "gofr.dev/pkg/gofr/datasource/sql"
 	"gofr.dev/pkg/gofr/logging"
@@ -77,6 +85,59 @@ func TestContainer_MQTTInitialization_Default(t *testing.T) {
 	assert.NotNil(t, m.Client)
 }
 
+func TestContainer_MemoryInitialization(t *testing.T) {
+	configs := map[string]string{
+		"PUBSUB_BACKEND": "MEMORY",
+		"CONSUMER_ID":    "orders-service",
+	}
+
+	c := NewContainer(config.NewMockConfig(configs))
+
+	m, ok := c.PubSub.(*memory.Client)
+	require.True(t, ok)
+	assert.Equal(t, "orders-service", m.Health().Details["consumer_group"])
+}
+
+func TestContainer_RedisPubSubInitialization(t *testing.T) {
+	server := miniredis.RunT(t)
+
+	configs := map[string]string{
+		"PUBSUB_BACKEND":              "REDIS",
+		"REDIS_HOST":                  server.Host(),
+		"REDIS_PORT":                  server.Port(),
+		"REDIS_DB":                    "3",
+		"CONSUMER_ID":                 "orders-service",
+		"REDIS_STREAMS_CONSUMER_NAME": "orders-1",
+	}
+
+	c := NewContainer(config.NewMockConfig(configs))
+
+	r, ok := c.PubSub.(*redispubsub.Client)
+	require.True(t, ok)
+
+	health := r.Health()
+
+	assert.Equal(t, datasource.StatusUp, health.Status)
+	assert.Equal(t, "orders-service", health.Details["consumer_group"])
+	assert.Equal(t, "orders-1", health.Details["consumer"])
+
+	require.NoError(t, r.Publish(context.Background(), "orders", []byte("order-1")))
+	assert.True(t, server.DB(3).Exists("orders"), "the stream is written to the database of REDIS_DB")
+}
+
+func TestContainer_RedisPubSubInvalidTLS(t *testing.T) {
+	configs := map[string]string{
+		"PUBSUB_BACKEND":         "REDIS",
+		"REDIS_HOST":             "localhost",
+		"REDIS_TLS_ENABLED":      "true",
+		"REDIS_TLS_CA_CERT_FILE": "missing.pem",
+	}
+
+	c := NewContainer(config.NewMockConfig(configs))
+
+	assert.Nil(t, c.PubSub)
+}
+
 func TestContainer_GetHTTPService(t *testing.T) {
 	svc := service.NewHTTPService("", nil, nil)
 
@@ -134,6 +195,64 @@ func TestContainer_GetPublisher(t *testing.T) {
 	assert.Equal(t, publisher, out)
 }
 
+var errCodec = errors.New("invalid message")
+
+// prefixCodec encodes the JSON of the values prefixed with a zero byte, by which it recognizes the messages it encoded,
+// the messages prefixed with 1 are invalid.
+type prefixCodec struct{}
+
+func (prefixCodec) Encode(_ context.Context, _ string, v any) ([]byte, error) {
+	raw, _ := v.(json.RawMessage)
+	if bytes.Equal(raw, []byte("1")) {
+		return nil, errCodec
+	}
+
+	return append([]byte{0}, raw...), nil
+}
+
+func (prefixCodec) Decode(context.Context, string, []byte, any) error {
+	return nil
+}
+
+func (prefixCodec) IsEncoded(data []byte) bool {
+	return len(data) > 0 && data[0] == 0
+}
+
+func TestContainer_GetPublisherEncodesWithCodec(t *testing.T) {
+	c, mocks := NewMockContainer(t, WithMemoryPubSub())
+	c.Codecs = map[string]pubsub.Codec{"orders": prefixCodec{}}
+
+	ctx := context.Background()
+	publisher := c.GetPublisher()
+
+	require.NoError(t, publisher.Publish(ctx, "orders", []byte(`{"id":1}`)))
+	require.NoError(t, publisher.Publish(ctx, "orders", []byte("\x00encoded")))
+	require.NoError(t, publisher.PublishMessage(ctx, "orders", &pubsub.PublishMessage{Key: "2", Value: []byte(`{"id":2}`)}))
+	require.NoError(t, publisher.Publish(ctx, "payments", []byte(`{"id":3}`)))
+
+	require.ErrorIs(t, publisher.Publish(ctx, "orders", []byte("1")), errCodec)
+	require.ErrorIs(t, publisher.PublishMessage(ctx, "orders", &pubsub.PublishMessage{Value: []byte("1")}), errCodec)
+	// the messages which are neither JSON nor encoded by the codec are not published
+	require.ErrorIs(t, publisher.Publish(ctx, "orders", []byte("garbage")), pubsub.ErrInvalidMessage)
+	require.ErrorIs(t, publisher.Publish(ctx, "orders", nil), pubsub.ErrInvalidMessage)
+
+	assert.Equal(t, [][]byte{[]byte("\x00{\"id\":1}"), []byte("\x00encoded"), []byte("\x00{\"id\":2}")},
+		mocks.PubSub.Messages("orders"))
+	assert.Equal(t, [][]byte{[]byte(`{"id":3}`)}, mocks.PubSub.Messages("payments"))
+}
+
+func TestContainer_EncodeMessage(t *testing.T) {
+	c := &Container{}
+
+	out, err := c.EncodeMessage(context.Background(), "orders", map[string]string{"orderId": "1"})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"orderId":"1"}`, string(out))
+
+	out, err = c.EncodeMessage(context.Background(), "orders", []byte("order 1"))
+	require.NoError(t, err)
+	assert.Equal(t, "order 1", string(out))
+}
+
 func TestContainer_GetSubscriber(t *testing.T) {
 	subscriber := &MockPubSub{}
 
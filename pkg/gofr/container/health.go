@@ -3,6 +3,8 @@ package container
 import (
 	"context"
 	"reflect"
+
+	"gofr.dev/pkg/gofr/datasource"
 )
 
 func (c *Container) Health(ctx context.Context) interface{} {
@@ -37,6 +39,8 @@ func (c *Container) Health(ctx context.Context) interface{} {
 			downCount++
 		}
 
+		downCount += c.subscriptionHealth(&health)
+
 		healthMap["pubsub"] = health
 	}
 
@@ -51,11 +55,52 @@ func (c *Container) Health(ctx context.Context) interface{} {
 		healthMap[name] = health
 	}
 
+	for name, svc := range c.GRPCServices {
+		health := svc.HealthCheck(ctx)
+		if health.Status == statusDown {
+			downCount++
+		}
+
+		healthMap[name] = health
+	}
+
 	c.appHealth(healthMap, downCount)
 
 	return healthMap
 }
 
+// SubscriptionHealthChecker reports the health of the subscriptions by their topics.
+type SubscriptionHealthChecker interface {
+	SubscriptionHealth() map[string]datasource.Health
+}
+
+// subscriptionHealth adds the health of the subscriptions to the details of the pub/sub, returning the number of
+// subscriptions which are down.
+func (c *Container) subscriptionHealth(health *datasource.Health) (downCount int) {
+	if c.Subscriptions == nil {
+		return 0
+	}
+
+	subscriptions := c.Subscriptions.SubscriptionHealth()
+	if len(subscriptions) == 0 {
+		return 0
+	}
+
+	for _, subscription := range subscriptions {
+		if subscription.Status == datasource.StatusDown {
+			downCount++
+		}
+	}
+
+	if health.Details == nil {
+		health.Details = make(map[string]interface{})
+	}
+
+	health.Details["subscriptions"] = subscriptions
+
+	return downCount
+}
+
 func checkExternalDBHealth(ctx context.Context, c *Container, healthMap map[string]interface{}) (downCount int) {
 	services := map[string]interface {
 		HealthCheck(context.Context) (interface{}, error)
@@ -10,6 +10,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"gofr.dev/pkg/gofr/datasource"
+	"gofr.dev/pkg/gofr/datasource/pubsub/memory"
 	"gofr.dev/pkg/gofr/datasource/sql"
 	"gofr.dev/pkg/gofr/logging"
 	"gofr.dev/pkg/gofr/service"
@@ -183,3 +184,29 @@ func registerMocks(mocks *Mocks, health string) {
 		},
 	}, nil)
 }
+
+type subscriptionHealthChecker map[string]datasource.Health
+
+func (s subscriptionHealthChecker) SubscriptionHealth() map[string]datasource.Health {
+	return s
+}
+
+func TestContainer_HealthWithSubscriptions(t *testing.T) {
+	subscriptions := subscriptionHealthChecker{
+		"orders":   {Status: datasource.StatusUp, Details: map[string]interface{}{"messages_handled": 2}},
+		"payments": {Status: datasource.StatusDown, Details: map[string]interface{}{"last_error": "read failed"}},
+	}
+
+	c := &Container{
+		PubSub:        memory.New(memory.Config{}, logging.NewMockLogger(logging.ERROR), nil),
+		Subscriptions: subscriptions,
+	}
+
+	health := c.Health(context.Background()).(map[string]interface{})
+
+	pubsubHealth := health["pubsub"].(datasource.Health)
+
+	assert.Equal(t, datasource.StatusUp, pubsubHealth.Status)
+	assert.Equal(t, map[string]datasource.Health(subscriptions), pubsubHealth.Details["subscriptions"])
+	assert.Equal(t, "DEGRADED", health["status"])
+}
@@ -10,6 +10,7 @@ import (
 	"gofr.dev/pkg/gofr/datasource"
 	"gofr.dev/pkg/gofr/datasource/file"
 	"gofr.dev/pkg/gofr/datasource/pubsub"
+	"gofr.dev/pkg/gofr/datasource/pubsub/memory"
 	"gofr.dev/pkg/gofr/datasource/sql"
 	"gofr.dev/pkg/gofr/logging"
 	"gofr.dev/pkg/gofr/service"
@@ -27,6 +28,7 @@ type Mocks struct {
 	File        *file.MockFileSystemProvider
 	HTTPService *service.MockHTTP
 	Metrics     *MockMetrics
+	PubSub      *memory.Client
 }
 
 type options func(c *Container, ctrl *gomock.Controller) any
@@ -43,6 +45,32 @@ func WithMockHTTPService(httpServiceNames ...string) options {
 	}
 }
 
+// WithRecordedHTTPService registers a service which replays the interactions recorded in a fixture file,
+// or records them from the target service when config.Mode is service.RecordMode.
+//
+//nolint:revive //Because user should not access the options, and we might change it to an interface in the future.
+func WithRecordedHTTPService(serviceName string, config service.RecorderConfig) options {
+	return func(c *Container, _ *gomock.Controller) any {
+		recorder := service.NewRecorder(config)
+		c.Services[serviceName] = recorder
+
+		return recorder
+	}
+}
+
+// WithMemoryPubSub sets an in-memory pub/sub client as the PubSub of the container, so that the handlers publishing
+// and subscribing to topics can be tested end-to-end. The client is returned in Mocks.PubSub.
+//
+//nolint:revive //Because user should not access the options, and we might change it to an interface in the future.
+func WithMemoryPubSub() options {
+	return func(c *Container, _ *gomock.Controller) any {
+		client := memory.New(memory.Config{}, c.Logger, nil)
+		c.PubSub = client
+
+		return client
+	}
+}
+
 func NewMockContainer(t *testing.T, options ...options) (*Container, *Mocks) {
 	t.Helper()
 
@@ -86,16 +114,21 @@ func NewMockContainer(t *testing.T, options ...options) (*Container, *Mocks) {
 	opentsdbMock := NewMockOpenTSDBProvider(ctrl)
 	container.OpenTSDB = opentsdbMock
 
-	var httpMock *service.MockHTTP
+	var (
+		httpMock   *service.MockHTTP
+		pubSubMock *memory.Client
+	)
 
 	container.Services = make(map[string]service.HTTP)
 
 	for _, option := range options {
 		optionsAdded := option(container, ctrl)
 
-		val, ok := optionsAdded.(*service.MockHTTP)
-		if ok {
+		switch val := optionsAdded.(type) {
+		case *service.MockHTTP:
 			httpMock = val
+		case *memory.Client:
+			pubSubMock = val
 		}
 	}
 
@@ -116,6 +149,7 @@ func NewMockContainer(t *testing.T, options ...options) (*Container, *Mocks) {
 		DGraph:      dgraphMock,
 		OpenTSDB:    opentsdbMock,
 		Metrics:     mockMetrics,
+		PubSub:      pubSubMock,
 	}
 
 	mockMetrics.EXPECT().RecordHistogram(gomock.Any(), "app_http_service_response", gomock.Any(), "path", gomock.Any(),
@@ -143,6 +177,10 @@ func (*MockPubSub) Publish(_ context.Context, _ string, _ []byte) error {
 	return nil
 }
 
+func (*MockPubSub) PublishMessage(_ context.Context, _ string, _ *pubsub.PublishMessage) error {
+	return nil
+}
+
 func (*MockPubSub) Subscribe(_ context.Context, _ string) (*pubsub.Message, error) {
 	return nil, nil
 }
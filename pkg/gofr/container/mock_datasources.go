@@ -10164,6 +10164,20 @@ func (mr *MockPubSubProviderMockRecorder) Publish(ctx, topic, message any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockPubSubProvider)(nil).Publish), ctx, topic, message)
 }
 
+// PublishMessage mocks base method.
+func (m *MockPubSubProvider) PublishMessage(ctx context.Context, topic string, message *pubsub.PublishMessage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishMessage", ctx, topic, message)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PublishMessage indicates an expected call of PublishMessage.
+func (mr *MockPubSubProviderMockRecorder) PublishMessage(ctx, topic, message any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishMessage", reflect.TypeOf((*MockPubSubProvider)(nil).PublishMessage), ctx, topic, message)
+}
+
 // Subscribe mocks base method.
 func (m *MockPubSubProvider) Subscribe(ctx context.Context, topic string) (*pubsub.Message, error) {
 	m.ctrl.T.Helper()
@@ -3,13 +3,18 @@ package container
 import (
 	"bytes"
 	"context"
+	"io"
+	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"gofr.dev/pkg/gofr/datasource"
+	"gofr.dev/pkg/gofr/service"
 )
 
 func Test_HttpServiceMock(t *testing.T) {
@@ -103,3 +108,38 @@ func TestMockSQL_HealthCheck(t *testing.T) {
 
 	assert.Equal(t, expectedHealth, resultHealth)
 }
+
+func Test_RecordedHTTPServiceMock(t *testing.T) {
+	fixture := filepath.Join(t.TempDir(), "cat-facts.json")
+	interactions := `[{"request":{"method":"GET","path":"fact","query":{"max_length":["20"]}},
+		"response":{"statusCode":200,"body":"{\"fact\":\"Cats have 3 eyelids.\"}"}}]`
+
+	require.NoError(t, os.WriteFile(fixture, []byte(interactions), 0o600))
+
+	c, _ := NewMockContainer(t, WithRecordedHTTPService("cat-facts", service.RecorderConfig{FixturePath: fixture}))
+
+	resp, err := c.GetHTTPService("cat-facts").Get(context.Background(), "fact", map[string]interface{}{
+		"max_length": 20,
+	})
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, `{"fact":"Cats have 3 eyelids."}`, string(body))
+}
+
+func Test_MemoryPubSubMock(t *testing.T) {
+	c, mocks := NewMockContainer(t, WithMemoryPubSub())
+
+	require.NoError(t, c.GetPublisher().Publish(context.Background(), "orders", []byte(`{"orderId":"1"}`)))
+
+	msg, err := c.GetSubscriber().Subscribe(context.Background(), "orders")
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{"orderId":"1"}`, string(msg.Value))
+	assert.Equal(t, [][]byte{[]byte(`{"orderId":"1"}`)}, mocks.PubSub.Messages("orders"))
+}
@@ -0,0 +1,61 @@
+package container
+
+import (
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+
+	"gofr.dev/pkg/gofr/config"
+	"gofr.dev/pkg/gofr/datasource/pubsub"
+)
+
+// PubSubBackend creates the client of a PUBSUB_BACKEND from the configs of the application.
+type PubSubBackend func(conf config.Config) (PubSubProvider, error)
+
+var (
+	pubsubBackendsMu sync.RWMutex
+	pubsubBackends   = make(map[string]PubSubBackend)
+)
+
+// RegisterPubSubBackend makes a backend available as PUBSUB_BACKEND, for the backends which are separate modules,
+// so that they are only added to the binary of the applications using them. It is called from the init function of
+// the backend package, which is then added to an application with a blank import:
+//
+//	import _ "gofr.dev/pkg/gofr/datasource/pubsub/nats"
+func RegisterPubSubBackend(name string, backend PubSubBackend) {
+	pubsubBackendsMu.Lock()
+	defer pubsubBackendsMu.Unlock()
+
+	pubsubBackends[strings.ToUpper(name)] = backend
+}
+
+// createRegisteredPubSub creates the client of a registered backend, setting up its logger, metrics and tracer
+// before connecting, just like App.AddPubSub does.
+func (c *Container) createRegisteredPubSub(name string, conf config.Config) pubsub.Client {
+	pubsubBackendsMu.RLock()
+	backend, ok := pubsubBackends[name]
+	pubsubBackendsMu.RUnlock()
+
+	if !ok {
+		c.Errorf("PUBSUB_BACKEND %v is not registered, import its package to use it, "+
+			"e.g. _ \"gofr.dev/pkg/gofr/datasource/pubsub/%v\"", name, strings.ToLower(name))
+
+		return nil
+	}
+
+	client, err := backend(conf)
+	if err != nil {
+		c.Errorf("could not create PUBSUB_BACKEND %v: %v", name, err)
+
+		return nil
+	}
+
+	client.UseLogger(c.Logger)
+	client.UseMetrics(c.Metrics())
+	client.UseTracer(otel.GetTracerProvider().Tracer("gofr-" + strings.ToLower(name)))
+
+	client.Connect()
+
+	return client
+}
@@ -0,0 +1,57 @@
+package container
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"gofr.dev/pkg/gofr/config"
+)
+
+var errInvalidBackendConfig = errors.New("stream not provided")
+
+func TestContainer_RegisteredPubSubBackend(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	provider := NewMockPubSubProvider(ctrl)
+
+	provider.EXPECT().UseLogger(gomock.Any())
+	provider.EXPECT().UseMetrics(gomock.Any())
+	provider.EXPECT().UseTracer(gomock.Any())
+	provider.EXPECT().Connect()
+
+	var stream string
+
+	RegisterPubSubBackend("test-backend", func(conf config.Config) (PubSubProvider, error) {
+		stream = conf.Get("TEST_STREAM")
+
+		return provider, nil
+	})
+
+	c := NewContainer(config.NewMockConfig(map[string]string{"PUBSUB_BACKEND": "TEST-BACKEND", "TEST_STREAM": "orders"}))
+
+	assert.Equal(t, provider, c.PubSub)
+	assert.Equal(t, "orders", stream)
+}
+
+func TestContainer_RegisteredPubSubBackend_Fail(t *testing.T) {
+	RegisterPubSubBackend("invalid-backend", func(config.Config) (PubSubProvider, error) {
+		return nil, errInvalidBackendConfig
+	})
+
+	testCases := []struct {
+		desc    string
+		backend string
+	}{
+		{"backend not registered", "NOT-REGISTERED"},
+		{"invalid backend configs", "INVALID-BACKEND"},
+	}
+
+	for i, tc := range testCases {
+		c := NewContainer(config.NewMockConfig(map[string]string{"PUBSUB_BACKEND": tc.backend}))
+
+		assert.Nil(t, c.PubSub, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
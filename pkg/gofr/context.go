@@ -12,6 +12,7 @@ import (
 	"gofr.dev/pkg/gofr/cmd/terminal"
 	"gofr.dev/pkg/gofr/container"
 	"gofr.dev/pkg/gofr/http/middleware"
+	"gofr.dev/pkg/gofr/logging"
 )
 
 type Context struct {
@@ -32,6 +33,9 @@ type Context struct {
 
 	// Terminal needs to be public as CMD applications need to access various terminal user interface(TUI) features.
 	Out terminal.Output
+
+	// logger replaces the logger of the container for the logs of the request, e.g. to add the trace ID of an RPC.
+	logger logging.Logger
 }
 
 type AuthInfo interface {
@@ -99,11 +103,17 @@ type authInfo struct {
 // GetAuthInfo().GetUsername() : retrieves the username while basic authentication.
 // GetAuthInfo().GetAPIKey() : retrieves the APIKey being used for authentication.
 func (c *Context) GetAuthInfo() AuthInfo {
-	claims, _ := c.Request.Context().Value(middleware.JWTClaim).(jwt.MapClaims)
+	return AuthInfoFromContext(c.Request.Context())
+}
+
+// AuthInfoFromContext returns the authentication info stored in the context by the auth middlewares, it is used
+// to access the authentication info in the gRPC services, e.g. AuthInfoFromContext(ctx).GetUsername().
+func AuthInfoFromContext(ctx context.Context) AuthInfo {
+	claims, _ := ctx.Value(middleware.JWTClaim).(jwt.MapClaims)
 
-	APIKey, _ := c.Request.Context().Value(middleware.APIKey).(string)
+	APIKey, _ := ctx.Value(middleware.APIKey).(string)
 
-	username, _ := c.Request.Context().Value(middleware.Username).(string)
+	username, _ := ctx.Value(middleware.Username).(string)
 
 	return &authInfo{
 		claims:   claims,
@@ -155,3 +165,68 @@ func newCMDContext(w Responder, r Request, c *container.Container, out terminal.
 		Out:       out,
 	}
 }
+
+// requestLogger returns the logger of the request, which is the logger of the container unless replaced for it.
+func (c *Context) requestLogger() logging.Logger {
+	if c.logger != nil {
+		return c.logger
+	}
+
+	return c.Container.Logger
+}
+
+func (c *Context) Debug(args ...interface{}) {
+	c.requestLogger().Debug(args...)
+}
+
+func (c *Context) Debugf(format string, args ...interface{}) {
+	c.requestLogger().Debugf(format, args...)
+}
+
+func (c *Context) Log(args ...interface{}) {
+	c.requestLogger().Log(args...)
+}
+
+func (c *Context) Logf(format string, args ...interface{}) {
+	c.requestLogger().Logf(format, args...)
+}
+
+func (c *Context) Info(args ...interface{}) {
+	c.requestLogger().Info(args...)
+}
+
+func (c *Context) Infof(format string, args ...interface{}) {
+	c.requestLogger().Infof(format, args...)
+}
+
+func (c *Context) Notice(args ...interface{}) {
+	c.requestLogger().Notice(args...)
+}
+
+func (c *Context) Noticef(format string, args ...interface{}) {
+	c.requestLogger().Noticef(format, args...)
+}
+
+func (c *Context) Warn(args ...interface{}) {
+	c.requestLogger().Warn(args...)
+}
+
+func (c *Context) Warnf(format string, args ...interface{}) {
+	c.requestLogger().Warnf(format, args...)
+}
+
+func (c *Context) Error(args ...interface{}) {
+	c.requestLogger().Error(args...)
+}
+
+func (c *Context) Errorf(format string, args ...interface{}) {
+	c.requestLogger().Errorf(format, args...)
+}
+
+func (c *Context) Fatal(args ...interface{}) {
+	c.requestLogger().Fatal(args...)
+}
+
+func (c *Context) Fatalf(format string, args ...interface{}) {
+	c.requestLogger().Fatalf(format, args...)
+}
@@ -179,3 +179,14 @@ func TestGetAuthInfo_JWTClaims(t *testing.T) {
 
 	assert.Equal(t, claims, res)
 }
+
+func TestAuthInfoFromContext(t *testing.T) {
+	ctx := context.WithValue(context.Background(), middleware.Username, "validUser")
+	ctx = context.WithValue(ctx, middleware.APIKey, "valid-key")
+
+	authInfo := AuthInfoFromContext(ctx)
+
+	assert.Equal(t, "validUser", authInfo.GetUsername())
+	assert.Equal(t, "valid-key", authInfo.GetAPIKey())
+	assert.Nil(t, authInfo.GetClaims())
+}
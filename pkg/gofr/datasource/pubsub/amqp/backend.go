@@ -0,0 +1,70 @@
+package amqp
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"gofr.dev/pkg/gofr/config"
+	"gofr.dev/pkg/gofr/container"
+)
+
+var errBrokerNotProvided = errors.New("amqp broker url not provided")
+
+// init makes AMQP available as PUBSUB_BACKEND=AMQP for the applications importing this package.
+func init() {
+	container.RegisterPubSubBackend("AMQP", NewFromConfig)
+}
+
+// NewFromConfig creates the client for PUBSUB_BACKEND=AMQP from the configs of the application: PUBSUB_BROKER,
+// CONSUMER_ID, AMQP_EXCHANGE_TYPE, AMQP_BINDING_KEY, AMQP_PREFETCH_COUNT, AMQP_CONFIRM_TIMEOUT and
+// AMQP_RECONNECT_INTERVAL.
+func NewFromConfig(conf config.Config) (container.PubSubProvider, error) {
+	cfg := Config{
+		URL:           conf.Get("PUBSUB_BROKER"),
+		ConsumerGroup: conf.Get("CONSUMER_ID"),
+		ExchangeType:  conf.Get("AMQP_EXCHANGE_TYPE"),
+		BindingKey:    conf.Get("AMQP_BINDING_KEY"),
+	}
+
+	if cfg.URL == "" {
+		return nil, errBrokerNotProvided
+	}
+
+	if value := conf.Get("AMQP_PREFETCH_COUNT"); value != "" {
+		count, err := strconv.Atoi(value)
+		if err != nil || count <= 0 {
+			return nil, fmt.Errorf("invalid value %q for AMQP_PREFETCH_COUNT, expected a positive number", value)
+		}
+
+		cfg.PrefetchCount = count
+	}
+
+	var err error
+
+	if cfg.ConfirmTimeout, err = duration(conf, "AMQP_CONFIRM_TIMEOUT"); err != nil {
+		return nil, err
+	}
+
+	if cfg.ReconnectInterval, err = duration(conf, "AMQP_RECONNECT_INTERVAL"); err != nil {
+		return nil, err
+	}
+
+	return New(cfg), nil
+}
+
+// duration returns the positive duration of the config, zero when it is not set.
+func duration(conf config.Config, key string) (time.Duration, error) {
+	value := conf.Get(key)
+	if value == "" {
+		return 0, nil
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid value %q for %s, expected a duration like 5s", value, key)
+	}
+
+	return d, nil
+}
@@ -0,0 +1,142 @@
+package amqp
+
+import (
+	"context"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// Connect connects to the broker of the config, and reconnects to it whenever the connection is lost until the client
+// is closed. When the broker cannot be reached, the client keeps trying to connect every ReconnectInterval.
+func (c *Client) Connect() {
+	if c.config.URL == "" {
+		c.logger.Error("amqp url cannot be empty")
+
+		return
+	}
+
+	c.logger.Debugf("connecting to amqp broker at %s", c.host)
+
+	if err := c.connect(); err != nil {
+		c.logger.Errorf("could not connect to amqp broker at %s, retrying every %v: %v", c.host, c.config.ReconnectInterval, err)
+	}
+
+	go c.reconnect()
+}
+
+// connect dials the broker, declaring the exchanges and queues declared so far by the client.
+func (c *Client) connect() error {
+	conn, err := c.dial(c.config.URL, amqp.Config{TLSClientConfig: c.config.TLSConfig})
+	if err != nil {
+		return err
+	}
+
+	closes := conn.NotifyClose(make(chan *amqp.Error, 1))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	select {
+	case <-c.closed:
+		// the client was closed while connecting
+		return conn.Close()
+	default:
+	}
+
+	for topic, queue := range c.topics {
+		if err = c.declare(conn, topic, queue); err != nil {
+			c.logger.Errorf("failed to declare amqp exchange %s again: %v", topic, err)
+		}
+	}
+
+	c.conn = conn
+	c.closes = closes
+	close(c.ready)
+
+	c.logger.Logf("connected to amqp broker at %s", c.host)
+
+	return nil
+}
+
+// reconnect waits for the connection to be lost, and connects again every ReconnectInterval until it succeeds, until
+// the client is closed.
+func (c *Client) reconnect() {
+	for {
+		c.mu.RLock()
+		closes := c.closes
+		c.mu.RUnlock()
+
+		if closes != nil {
+			select {
+			case <-c.closed:
+				return
+			case err := <-closes:
+				if !c.disconnected(err) {
+					return
+				}
+			}
+		}
+
+		select {
+		case <-c.closed:
+			return
+		case <-time.After(c.config.ReconnectInterval):
+		}
+
+		if err := c.connect(); err != nil {
+			c.logger.Errorf("could not reconnect to amqp broker at %s: %v", c.host, err)
+		}
+	}
+}
+
+// disconnected resets the lost connection, so that the subscriptions wait for the client to reconnect. It returns
+// false when the connection was closed by closing the client.
+func (c *Client) disconnected(err *amqp.Error) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	select {
+	case <-c.closed:
+		return false
+	default:
+	}
+
+	c.conn = nil
+	c.closes = nil
+	c.ready = make(chan struct{})
+
+	c.logger.Errorf("lost connection to amqp broker at %s: %v", c.host, err)
+
+	return true
+}
+
+// connection returns the connection to the broker, nil when the client is not connected.
+func (c *Client) connection() Connection {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.conn
+}
+
+// waitConnected returns the connection to the broker, waiting for the client to connect. It returns nil once ctx is
+// done or the client is closed.
+func (c *Client) waitConnected(ctx context.Context) Connection {
+	for {
+		c.mu.RLock()
+		conn, ready := c.conn, c.ready
+		c.mu.RUnlock()
+
+		if conn != nil {
+			return conn
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-c.closed:
+			return nil
+		case <-ready:
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package amqp
+
+import (
+	"gofr.dev/pkg/gofr/datasource"
+)
+
+// Health reports the connection to the broker, along with the messages ready in, and the consumers of, the queues
+// declared by the client.
+func (c *Client) Health() datasource.Health {
+	health := datasource.Health{
+		Status: datasource.StatusDown,
+		Details: map[string]any{
+			"backend":        backend,
+			"host":           c.host,
+			"consumer_group": c.config.ConsumerGroup,
+		},
+	}
+
+	conn := c.connection()
+	if conn == nil || conn.IsClosed() {
+		health.Details["error"] = errNotConnected.Error()
+
+		return health
+	}
+
+	health.Status = datasource.StatusUp
+	health.Details["queues"] = c.queueStats(conn)
+
+	return health
+}
+
+func (c *Client) queueStats(conn Connection) map[string]any {
+	c.mu.RLock()
+	queues := make([]string, 0, len(c.topics))
+
+	for topic, queue := range c.topics {
+		if queue {
+			queues = append(queues, c.config.queueName(topic))
+		}
+	}
+
+	c.mu.RUnlock()
+
+	stats := make(map[string]any, len(queues))
+
+	for _, name := range queues {
+		// a channel per queue, as the broker closes the channel inspecting a queue which does not exist
+		ch, err := conn.Channel()
+		if err != nil {
+			break
+		}
+
+		queue, err := ch.QueueDeclarePassive(name, true, false, false, false, nil)
+
+		_ = ch.Close()
+
+		if err != nil {
+			stats[name] = map[string]any{"error": err.Error()}
+
+			continue
+		}
+
+		stats[name] = map[string]any{"messages": queue.Messages, "consumers": queue.Consumers}
+	}
+
+	return stats
+}
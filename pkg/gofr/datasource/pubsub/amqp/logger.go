@@ -0,0 +1,10 @@
+package amqp
+
+// Logger is the subset of the logger of the container used by the client.
+type Logger interface {
+	Debug(args ...any)
+	Debugf(pattern string, args ...any)
+	Logf(pattern string, args ...any)
+	Error(args ...any)
+	Errorf(pattern string, args ...any)
+}
@@ -0,0 +1,79 @@
+package amqp
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+
+	"gofr.dev/pkg/gofr/datasource/pubsub"
+)
+
+// encodeMessage creates the persistent publishing of the message, whose headers carry the trace context of ctx.
+func encodeMessage(ctx context.Context, message *pubsub.PublishMessage) amqp.Publishing {
+	headers := pubsub.InjectTraceContext(ctx, message.Headers)
+
+	table := make(amqp.Table, len(headers))
+
+	for name, value := range headers {
+		table[name] = value
+	}
+
+	timestamp := message.Timestamp
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+
+	return amqp.Publishing{
+		Headers:      table,
+		DeliveryMode: amqp.Persistent,
+		Timestamp:    timestamp,
+		Body:         message.Value,
+	}
+}
+
+// decodeMessage creates the message of the delivery, whose key is the routing key it was published with.
+func decodeMessage(ctx context.Context, topic string, delivery *amqp.Delivery) *pubsub.Message {
+	msg := pubsub.NewMessage(ctx)
+	msg.Topic = topic
+	msg.Value = delivery.Body
+	msg.Key = delivery.RoutingKey
+	msg.Timestamp = delivery.Timestamp
+	msg.MetaData = delivery
+
+	if len(delivery.Headers) > 0 {
+		msg.Headers = make(map[string]string, len(delivery.Headers))
+
+		for name, value := range delivery.Headers {
+			msg.Headers[name] = fmt.Sprint(value)
+		}
+	}
+
+	return msg
+}
+
+// committer acknowledges the delivery to the broker, which removes the message from the queue. A message which is
+// not acknowledged is redelivered once the channel it was delivered on is closed.
+type committer struct {
+	delivery *amqp.Delivery
+	queue    string
+	// requeue is unset for the queues with a dead-letter exchange, so that the nacked messages are dead-lettered.
+	requeue bool
+	logger  Logger
+}
+
+func (c *committer) Commit() {
+	if err := c.delivery.Ack(false); err != nil {
+		c.logger.Errorf("failed to acknowledge message %d of amqp queue %s: %v", c.delivery.DeliveryTag, c.queue, err)
+	}
+}
+
+// Nack hands the message back to the queue, for it to be redelivered, or to the dead-letter exchange of the queue when
+// it has one. The message is nacked once the retries of the subscription are exhausted, hence the broker does not
+// redeliver it to the queue with a dead-letter exchange.
+func (c *committer) Nack() {
+	if err := c.delivery.Nack(false, c.requeue); err != nil {
+		c.logger.Errorf("failed to nack message %d of amqp queue %s: %v", c.delivery.DeliveryTag, c.queue, err)
+	}
+}
@@ -0,0 +1,10 @@
+package amqp
+
+import "context"
+
+//go:generate mockgen -destination=mock_metrics.go -package=amqp -source=./metrics.go
+
+// Metrics is the subset of the metrics of the container used by the client.
+type Metrics interface {
+	IncrementCounter(ctx context.Context, name string, labels ...string)
+}
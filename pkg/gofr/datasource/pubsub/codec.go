@@ -0,0 +1,36 @@
+package pubsub
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+)
+
+// ErrInvalidMessage is wrapped by the errors of the codecs for the values and messages which do not conform to the
+// schema of their topic. The subscriptions do not retry such messages, as they would fail again, and publish them to
+// the dead-letter topic right away when one is configured.
+var ErrInvalidMessage = errors.New("invalid message")
+
+// Codec serializes the values published to a topic and deserializes the messages received from it, e.g. with Avro or
+// Protobuf. The topic is given to the codecs which look up the schema of the topic, e.g. in a schema registry.
+type Codec interface {
+	Encode(ctx context.Context, topic string, v any) ([]byte, error)
+	Decode(ctx context.Context, topic string, data []byte, v any) error
+}
+
+// Encode encodes the value to be published to the topic with the codec, the values are encoded as JSON when the codec
+// is nil, except for []byte and string which are published as they are.
+func Encode(ctx context.Context, codec Codec, topic string, v any) ([]byte, error) {
+	if codec != nil {
+		return codec.Encode(ctx, topic, v)
+	}
+
+	switch value := v.(type) {
+	case []byte:
+		return value, nil
+	case string:
+		return []byte(value), nil
+	default:
+		return json.Marshal(v)
+	}
+}
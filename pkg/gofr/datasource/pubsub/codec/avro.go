@@ -0,0 +1,428 @@
+package codec
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/hamba/avro/v2"
+
+	"gofr.dev/pkg/gofr/datasource/pubsub"
+)
+
+var errAvroSchema = errors.New("invalid avro schema")
+
+// Avro encodes the values of a topic with the latest schema of its subject in the schema registry, and decodes the
+// messages with the schemas they are written with. The values are mapped to the schemas by their JSON encoding, so
+// the json tags of the struct fields are the names of the fields of the records, and the JSON of a value can be
+// encoded as a json.RawMessage. []byte fields are encoded as bytes, and time.Time fields as longs with the
+// timestamp-millis or timestamp-micros logical types.
+type Avro struct {
+	registry *SchemaRegistry
+
+	mu     sync.RWMutex
+	parsed map[int]avro.Schema
+}
+
+// NewAvro creates an Avro codec using the schemas of the schema registry.
+func NewAvro(registry *SchemaRegistry) *Avro {
+	return &Avro{registry: registry, parsed: make(map[int]avro.Schema)}
+}
+
+// Encode encodes the value in the wire format of the schema registry, with the latest schema of the topic.
+func (a *Avro) Encode(ctx context.Context, topic string, v any) ([]byte, error) {
+	schema, err := a.registry.LatestSchema(ctx, topicSubject(topic))
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := a.schema(schema)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := toGeneric(v)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", pubsub.ErrInvalidMessage, err)
+	}
+
+	data, err := avro.Marshal(parsed, toAvro(parsed, value))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", pubsub.ErrInvalidMessage, err)
+	}
+
+	return append(appendWireHeader(nil, schema.ID), data...), nil
+}
+
+// Decode decodes the data in the wire format of the schema registry, with the schema it is written with.
+func (a *Avro) Decode(ctx context.Context, _ string, data []byte, v any) error {
+	id, payload, err := readWireHeader(data)
+	if err != nil {
+		return err
+	}
+
+	schema, err := a.registry.SchemaByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	parsed, err := a.schema(schema)
+	if err != nil {
+		return err
+	}
+
+	var value any
+
+	// the reader is used instead of avro.Unmarshal, which does not report the messages ending early
+	reader := avro.NewReader(nil, 0).Reset(payload)
+	reader.ReadVal(parsed, &value)
+
+	if reader.Error != nil {
+		return fmt.Errorf("%w: %w", pubsub.ErrInvalidMessage, reader.Error)
+	}
+
+	return fromGeneric(fromAvro(parsed, value), v)
+}
+
+// IsEncoded reports whether the data is in the wire format of the schema registry, i.e. encoded by the codec, so that
+// the messages encoded with EncodeMessage of the container are published as they are.
+func (*Avro) IsEncoded(data []byte) bool {
+	_, _, err := readWireHeader(data)
+
+	return err == nil
+}
+
+// schema returns the parsed Avro schema, parsing it once for its ID.
+func (a *Avro) schema(schema *Schema) (avro.Schema, error) {
+	if schema.Type != schemaTypeAvro {
+		return nil, fmt.Errorf("%w: schema %d is of type %s", errAvroSchema, schema.ID, schema.Type)
+	}
+
+	a.mu.RLock()
+	parsed, ok := a.parsed[schema.ID]
+	a.mu.RUnlock()
+
+	if ok {
+		return parsed, nil
+	}
+
+	// the named types are resolved within the schema, as the versions of a schema define the same names
+	parsed, err := avro.ParseWithCache(schema.Schema, "", &avro.SchemaCache{})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errAvroSchema, err)
+	}
+
+	a.mu.Lock()
+	a.parsed[schema.ID] = parsed
+	a.mu.Unlock()
+
+	return parsed, nil
+}
+
+// toAvro converts the JSON value to the Go types of the schema, i.e. the numbers to the types of their size, the
+// timestamps to time.Time and the base64 of bytes to []byte. The values which do not match the schema are left as they
+// are, to fail when they are encoded.
+func toAvro(schema avro.Schema, value any) any {
+	schema = deref(schema)
+
+	switch s := schema.(type) {
+	case *avro.RecordSchema:
+		record, ok := value.(map[string]any)
+		if !ok {
+			return value
+		}
+
+		for _, field := range s.Fields() {
+			if v, ok := record[field.Name()]; ok {
+				record[field.Name()] = toAvro(field.Type(), v)
+			}
+		}
+	case *avro.ArraySchema:
+		if items, ok := value.([]any); ok {
+			for i := range items {
+				items[i] = toAvro(s.Items(), items[i])
+			}
+		}
+	case *avro.MapSchema:
+		if values, ok := value.(map[string]any); ok {
+			for k, v := range values {
+				values[k] = toAvro(s.Values(), v)
+			}
+		}
+	case *avro.UnionSchema:
+		return unionToAvro(s, value)
+	case *avro.FixedSchema:
+		return fixedToAvro(s, value)
+	case *avro.PrimitiveSchema:
+		return primitiveToAvro(s, value)
+	}
+
+	return value
+}
+
+// unionToAvro converts the value to the first type of the union it matches, given along with the name of the type.
+// The values matching none of the types are given with the first type which is not null, to fail when encoded.
+func unionToAvro(s *avro.UnionSchema, value any) any {
+	if value == nil {
+		return nil
+	}
+
+	var first avro.Schema
+
+	for _, branch := range s.Types() {
+		if branch.Type() == avro.Null {
+			continue
+		}
+
+		if matches(deref(branch), value) {
+			return map[string]any{typeName(branch): toAvro(branch, value)}
+		}
+
+		if first == nil {
+			first = branch
+		}
+	}
+
+	if first == nil {
+		return value
+	}
+
+	return map[string]any{typeName(first): toAvro(first, value)}
+}
+
+func fixedToAvro(s *avro.FixedSchema, value any) any {
+	str, ok := value.(string)
+	if !ok {
+		return value
+	}
+
+	data, err := base64.StdEncoding.DecodeString(str)
+	if err != nil || len(data) != s.Size() {
+		return value
+	}
+
+	fixed := reflect.New(reflect.ArrayOf(s.Size(), reflect.TypeOf(byte(0)))).Elem()
+	reflect.Copy(fixed, reflect.ValueOf(data))
+
+	return fixed.Interface()
+}
+
+func primitiveToAvro(s *avro.PrimitiveSchema, value any) any {
+	switch v := value.(type) {
+	case json.Number:
+		return numberToAvro(s, v)
+	case string:
+		switch s.Type() {
+		case avro.Bytes:
+			if data, err := base64.StdEncoding.DecodeString(v); err == nil {
+				return data
+			}
+		case avro.Long:
+			if isTimestamp(s) {
+				if t, err := time.Parse(time.RFC3339Nano, v); err == nil {
+					return t
+				}
+			}
+		default:
+		}
+	}
+
+	return value
+}
+
+func numberToAvro(s *avro.PrimitiveSchema, n json.Number) any {
+	switch s.Type() {
+	case avro.Int:
+		if i, err := n.Int64(); err == nil && i >= math.MinInt32 && i <= math.MaxInt32 {
+			return int(i)
+		}
+	case avro.Long:
+		if i, err := n.Int64(); err == nil {
+			return i
+		}
+	case avro.Float:
+		if f, err := n.Float64(); err == nil {
+			return float32(f)
+		}
+	case avro.Double:
+		if f, err := n.Float64(); err == nil {
+			return f
+		}
+	default:
+	}
+
+	// json.Number is a string, hence the numbers are given as float64 to the other types, to fail when encoded
+	f, _ := n.Float64()
+
+	return f
+}
+
+// matches reports whether the JSON value is of the kind of the schema, to pick the type of a union.
+func matches(schema avro.Schema, value any) bool {
+	switch value.(type) {
+	case bool:
+		return schema.Type() == avro.Boolean
+	case json.Number:
+		switch schema.Type() {
+		case avro.Int, avro.Long, avro.Float, avro.Double:
+			return true
+		default:
+			return false
+		}
+	case string:
+		switch schema.Type() {
+		case avro.String, avro.Bytes, avro.Enum, avro.Fixed:
+			return true
+		default:
+			return schema.Type() == avro.Long && isTimestamp(schema)
+		}
+	case []any:
+		return schema.Type() == avro.Array
+	case map[string]any:
+		return schema.Type() == avro.Record || schema.Type() == avro.Map
+	default:
+		return false
+	}
+}
+
+// fromAvro converts the decoded value to its JSON representation, i.e. the values of the unions without the names of
+// their types and the fixed as []byte.
+func fromAvro(schema avro.Schema, value any) any {
+	schema = deref(schema)
+
+	switch s := schema.(type) {
+	case *avro.RecordSchema:
+		if record, ok := value.(map[string]any); ok {
+			for _, field := range s.Fields() {
+				if v, ok := record[field.Name()]; ok {
+					record[field.Name()] = fromAvro(field.Type(), v)
+				}
+			}
+		}
+	case *avro.ArraySchema:
+		items, _ := value.([]any)
+		if items == nil {
+			return []any{}
+		}
+
+		for i := range items {
+			items[i] = fromAvro(s.Items(), items[i])
+		}
+	case *avro.MapSchema:
+		if values, ok := value.(map[string]any); ok {
+			for k, v := range values {
+				values[k] = fromAvro(s.Values(), v)
+			}
+		}
+	case *avro.UnionSchema:
+		return unionFromAvro(s, value)
+	case *avro.FixedSchema:
+		if v := reflect.ValueOf(value); v.Kind() == reflect.Array {
+			data := make([]byte, v.Len())
+			reflect.Copy(reflect.ValueOf(data), v)
+
+			return data
+		}
+	}
+
+	return value
+}
+
+func unionFromAvro(s *avro.UnionSchema, value any) any {
+	if value == nil {
+		return nil
+	}
+
+	if named, ok := value.(map[string]any); ok && len(named) == 1 {
+		for _, branch := range s.Types() {
+			if v, ok := named[typeName(branch)]; ok {
+				return fromAvro(branch, v)
+			}
+		}
+	}
+
+	for _, branch := range s.Types() {
+		if branch.Type() != avro.Null {
+			return fromAvro(branch, value)
+		}
+	}
+
+	return value
+}
+
+// deref returns the schema of the named type a reference points to.
+func deref(schema avro.Schema) avro.Schema {
+	if ref, ok := schema.(*avro.RefSchema); ok {
+		return ref.Schema()
+	}
+
+	return schema
+}
+
+// typeName returns the name of the type in a union, i.e. the full name of the named types and the type, along with
+// its logical type, of the others.
+func typeName(schema avro.Schema) string {
+	schema = deref(schema)
+
+	if named, ok := schema.(avro.NamedSchema); ok {
+		return named.FullName()
+	}
+
+	name := string(schema.Type())
+
+	if logical, ok := schema.(avro.LogicalTypeSchema); ok && logical.Logical() != nil {
+		name += "." + string(logical.Logical().Type())
+	}
+
+	return name
+}
+
+func isTimestamp(schema avro.Schema) bool {
+	logical, ok := schema.(avro.LogicalTypeSchema)
+	if !ok || logical.Logical() == nil {
+		return false
+	}
+
+	t := logical.Logical().Type()
+
+	return t == avro.TimestampMillis || t == avro.TimestampMicros
+}
+
+// toGeneric converts the value to the maps, slices and primitives of its JSON encoding, with json.Number for numbers.
+// A json.RawMessage is taken as the JSON of the value.
+func toGeneric(v any) (any, error) {
+	data, ok := v.(json.RawMessage)
+	if !ok {
+		var err error
+
+		if data, err = json.Marshal(v); err != nil {
+			return nil, err
+		}
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value any
+
+	err := decoder.Decode(&value)
+
+	return value, err
+}
+
+// fromGeneric binds the maps, slices and primitives of a decoded value to v, through their JSON encoding.
+func fromGeneric(value, v any) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, v)
+}
@@ -0,0 +1,195 @@
+package codec
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gofr.dev/pkg/gofr/datasource/pubsub"
+)
+
+const orderSchema = `{
+	"type": "record",
+	"name": "Order",
+	"namespace": "com.example",
+	"fields": [
+		{"name": "id", "type": "long"},
+		{"name": "customer", "type": {
+			"type": "record",
+			"name": "Customer",
+			"fields": [
+				{"name": "name", "type": "string"},
+				{"name": "vip", "type": "boolean", "default": false}
+			]
+		}},
+		{"name": "status", "type": {"type": "enum", "name": "Status", "symbols": ["NEW", "SHIPPED"]}},
+		{"name": "items", "type": {"type": "array", "items": "string"}},
+		{"name": "prices", "type": {"type": "map", "values": "double"}},
+		{"name": "note", "type": ["null", "string"], "default": null},
+		{"name": "referrer", "type": ["null", "Customer"], "default": null},
+		{"name": "payload", "type": "bytes"},
+		{"name": "createdAt", "type": {"type": "long", "logicalType": "timestamp-millis"}},
+		{"name": "quantity", "type": "int"},
+		{"name": "weight", "type": "float"}
+	]
+}`
+
+type customer struct {
+	Name string `json:"name"`
+	VIP  bool   `json:"vip"`
+}
+
+type order struct {
+	ID        int64              `json:"id"`
+	Customer  customer           `json:"customer"`
+	Status    string             `json:"status"`
+	Items     []string           `json:"items"`
+	Prices    map[string]float64 `json:"prices"`
+	Note      *string            `json:"note,omitempty"`
+	Referrer  *customer          `json:"referrer,omitempty"`
+	Payload   []byte             `json:"payload"`
+	CreatedAt time.Time          `json:"createdAt"`
+	Quantity  int32              `json:"quantity"`
+	Weight    float32            `json:"weight"`
+}
+
+func TestAvro_RoundTrip(t *testing.T) {
+	server := newTestRegistry(t)
+	id := server.register(t, "orders-value", "", orderSchema)
+
+	note := "leave at the door"
+
+	testCases := []order{
+		{
+			ID: 1, Customer: customer{Name: "Alice", VIP: true}, Status: "NEW", Items: []string{"book", "pen"},
+			Prices: map[string]float64{"book": 12.5, "pen": 1.25}, Note: &note, Referrer: &customer{Name: "Bob"},
+			Payload: []byte{0, 1, 2}, CreatedAt: time.Date(2024, 5, 1, 10, 30, 0, 123000000, time.UTC), Quantity: -3,
+			Weight: 1.5,
+		},
+		{
+			ID: -42, Customer: customer{Name: "Carol"}, Status: "SHIPPED", Items: []string{}, Prices: map[string]float64{},
+			Payload: []byte{}, CreatedAt: time.Date(1999, 12, 31, 23, 59, 59, 0, time.UTC),
+		},
+	}
+
+	codec := NewAvro(NewSchemaRegistry(RegistryConfig{URL: server.URL}))
+	ctx := context.Background()
+
+	for i, tc := range testCases {
+		data, err := codec.Encode(ctx, "orders", tc)
+		require.NoError(t, err, "TEST[%d], Failed.\n", i)
+
+		schemaID, _, err := readWireHeader(data)
+		require.NoError(t, err, "TEST[%d], Failed.\n", i)
+		assert.Equal(t, id, schemaID, "TEST[%d], Failed.\n", i)
+
+		var decoded order
+
+		require.NoError(t, codec.Decode(ctx, "orders", data, &decoded), "TEST[%d], Failed.\n", i)
+		assert.Equal(t, tc, decoded, "TEST[%d], Failed.\n", i)
+	}
+}
+
+func TestAvro_EncodesJSON(t *testing.T) {
+	server := newTestRegistry(t)
+	server.register(t, "orders-value", "", orderSchema)
+
+	codec := NewAvro(NewSchemaRegistry(RegistryConfig{URL: server.URL}))
+	ctx := context.Background()
+
+	message := `{"id":7,"customer":{"name":"Dave"},"status":"NEW","items":["cup"],"prices":{"cup":3},
+		"note":"fragile","payload":"AAE=","createdAt":"2024-05-01T10:30:00Z","quantity":1,"weight":0.5}`
+
+	data, err := codec.Encode(ctx, "orders", json.RawMessage(message))
+	require.NoError(t, err)
+
+	assert.True(t, codec.IsEncoded(data))
+	assert.False(t, codec.IsEncoded([]byte(message)))
+
+	var decoded order
+
+	require.NoError(t, codec.Decode(ctx, "orders", data, &decoded))
+
+	note := "fragile"
+
+	assert.Equal(t, order{ID: 7, Customer: customer{Name: "Dave"}, Status: "NEW", Items: []string{"cup"},
+		Prices: map[string]float64{"cup": 3}, Note: &note, Payload: []byte{0, 1},
+		CreatedAt: time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC), Quantity: 1, Weight: 0.5}, decoded)
+}
+
+func TestAvro_DecodesWithWriterSchema(t *testing.T) {
+	server := newTestRegistry(t)
+	server.register(t, "users-value", "", `{"type":"record","name":"User","fields":[{"name":"name","type":"string"}]}`)
+
+	codec := NewAvro(NewSchemaRegistry(RegistryConfig{URL: server.URL}))
+	ctx := context.Background()
+
+	data, err := codec.Encode(ctx, "users", map[string]string{"name": "Alice"})
+	require.NoError(t, err)
+
+	// a new version of the schema does not change how the messages written with the previous version are decoded
+	server.register(t, "users-value", "",
+		`{"type":"record","name":"User","fields":[{"name":"age","type":"int"},{"name":"name","type":"string"}]}`)
+
+	var decoded map[string]any
+
+	require.NoError(t, codec.Decode(ctx, "users", data, &decoded))
+	assert.Equal(t, map[string]any{"name": "Alice"}, decoded)
+}
+
+func TestAvro_InvalidMessages(t *testing.T) {
+	server := newTestRegistry(t)
+	server.register(t, "users-value", "",
+		`{"type":"record","name":"User","fields":[{"name":"name","type":"string"},{"name":"age","type":"int"}]}`)
+
+	codec := NewAvro(NewSchemaRegistry(RegistryConfig{URL: server.URL}))
+	ctx := context.Background()
+
+	values := []any{
+		map[string]any{"name": "Alice"},
+		map[string]any{"name": 1, "age": 2},
+		map[string]any{"name": "Alice", "age": 1.5},
+		map[string]any{"name": "Alice", "age": int64(1) << 40},
+		"Alice",
+	}
+
+	for i, v := range values {
+		_, err := codec.Encode(ctx, "users", v)
+		require.ErrorIs(t, err, pubsub.ErrInvalidMessage, "TEST[%d], Failed.\n", i)
+	}
+
+	messages := [][]byte{
+		[]byte(`{"name":"Alice","age":1}`),
+		{0, 0, 0, 0, 1},
+		{0, 0, 0, 0, 1, 10, 'A'},
+	}
+
+	for i, data := range messages {
+		var decoded map[string]any
+
+		err := codec.Decode(ctx, "users", data, &decoded)
+		require.ErrorIs(t, err, pubsub.ErrInvalidMessage, "TEST[%d], Failed.\n", i)
+	}
+}
+
+func TestAvro_SchemaErrors(t *testing.T) {
+	server := newTestRegistry(t)
+	server.register(t, "invalid-value", "", `{"type":"record","name":"User","fields":[{"name":"a","type":"unknown"}]}`)
+	server.register(t, "proto-value", schemaTypeProtobuf, `syntax = "proto3"; message User { string name = 1; }`)
+
+	codec := NewAvro(NewSchemaRegistry(RegistryConfig{URL: server.URL}))
+	ctx := context.Background()
+
+	_, err := codec.Encode(ctx, "invalid", map[string]any{"a": 1})
+	require.ErrorIs(t, err, errAvroSchema)
+
+	_, err = codec.Encode(ctx, "proto", map[string]any{"name": "Alice"})
+	require.ErrorIs(t, err, errAvroSchema)
+
+	_, err = codec.Encode(ctx, "unknown", map[string]any{"name": "Alice"})
+	require.ErrorIs(t, err, errRegistryResponse)
+}
@@ -0,0 +1,82 @@
+package codec
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+
+	"gofr.dev/pkg/gofr/datasource/pubsub"
+)
+
+// jsonSchemaURL is the location the schema of a JSONSchema codec is compiled at, the errors are reported against it.
+const jsonSchemaURL = "urn:gofr:schema"
+
+var errJSONSchema = errors.New("invalid json schema")
+
+// JSONSchema encodes the values of a topic as JSON, validating the messages against a JSON Schema when they are
+// published and when they are bound. The schemas are of the 2020-12 draft, unless they state another one with $schema.
+type JSONSchema struct {
+	schema *jsonschema.Schema
+}
+
+// NewJSONSchema creates a JSONSchema codec validating the messages against the schema.
+func NewJSONSchema(schema string) (*JSONSchema, error) {
+	doc, err := jsonschema.UnmarshalJSON(strings.NewReader(schema))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errJSONSchema, err)
+	}
+
+	compiler := jsonschema.NewCompiler()
+
+	if err = compiler.AddResource(jsonSchemaURL, doc); err != nil {
+		return nil, fmt.Errorf("%w: %w", errJSONSchema, err)
+	}
+
+	compiled, err := compiler.Compile(jsonSchemaURL)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errJSONSchema, err)
+	}
+
+	return &JSONSchema{schema: compiled}, nil
+}
+
+// Encode encodes the value as JSON, after validating it. A json.RawMessage is taken as the JSON of the value.
+func (j *JSONSchema) Encode(_ context.Context, _ string, v any) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = j.validate(data); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// Decode validates the JSON and binds it to v.
+func (j *JSONSchema) Decode(_ context.Context, _ string, data []byte, v any) error {
+	if err := j.validate(data); err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+func (j *JSONSchema) validate(data []byte) error {
+	value, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("%w: %w", pubsub.ErrInvalidMessage, err)
+	}
+
+	if err = j.schema.Validate(value); err != nil {
+		return fmt.Errorf("%w: %w", pubsub.ErrInvalidMessage, err)
+	}
+
+	return nil
+}
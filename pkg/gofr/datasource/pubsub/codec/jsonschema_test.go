@@ -0,0 +1,111 @@
+package codec
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gofr.dev/pkg/gofr/datasource/pubsub"
+)
+
+const userSchema = `{
+	"type": "object",
+	"required": ["name", "age"],
+	"properties": {
+		"name": {"type": "string", "minLength": 1, "maxLength": 10, "pattern": "^[A-Z]"},
+		"age": {"type": "integer", "minimum": 0, "exclusiveMaximum": 150},
+		"email": {"type": ["string", "null"]},
+		"role": {"enum": ["admin", "member"]},
+		"version": {"const": 1},
+		"tags": {"type": "array", "items": {"type": "string"}, "minItems": 1, "maxItems": 2},
+		"score": {"anyOf": [{"type": "number", "maximum": 10}, {"type": "string"}]},
+		"id": {"oneOf": [{"type": "integer"}, {"type": "number"}]}
+	},
+	"additionalProperties": false
+}`
+
+func TestJSONSchema_Validation(t *testing.T) {
+	codec, err := NewJSONSchema(userSchema)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		message string
+		err     string
+	}{
+		{`{"name":"Alice","age":30}`, ""},
+		{`{"name":"Alice","age":30,"email":null,"role":"admin","version":1.0,"tags":["a"],"score":9.5}`, ""},
+		{`{"name":"Alice","age":30,"score":"high","id":1.5}`, ""},
+		{`{"age":30}`, "missing property 'name'"},
+		{`{"name":"Alice","age":30.5}`, "at '/age': got number, want integer"},
+		{`{"name":"Alice","age":-1}`, "at '/age': minimum: got -1, want 0"},
+		{`{"name":"Alice","age":150}`, "at '/age': exclusiveMaximum: got 150, want 150"},
+		{`{"name":"","age":30}`, "at '/name': minLength: got 0, want 1"},
+		{`{"name":"Alexandra Smith","age":30}`, "at '/name': maxLength: got 15, want 10"},
+		{`{"name":"alice","age":30}`, "at '/name': 'alice' does not match pattern '^[A-Z]'"},
+		{`{"name":"Alice","age":30,"email":1}`, "at '/email': got number, want null or string"},
+		{`{"name":"Alice","age":30,"role":"owner"}`, "at '/role': value must be one of 'admin', 'member'"},
+		{`{"name":"Alice","age":30,"version":2}`, "at '/version': value must be 1"},
+		{`{"name":"Alice","age":30,"tags":[]}`, "at '/tags': minItems: got 0, want 1"},
+		{`{"name":"Alice","age":30,"tags":["a","b","c"]}`, "at '/tags': maxItems: got 3, want 2"},
+		{`{"name":"Alice","age":30,"tags":["a",1]}`, "at '/tags/1': got number, want string"},
+		{`{"name":"Alice","age":30,"score":11}`, "at '/score': anyOf failed"},
+		{`{"name":"Alice","age":30,"id":1}`, "at '/id': oneOf failed"},
+		{`{"name":"Alice","age":30,"extra":true}`, "additional properties 'extra' not allowed"},
+		{`[]`, "got array, want object"},
+	}
+
+	ctx := context.Background()
+
+	for i, tc := range testCases {
+		var decoded map[string]any
+
+		err := codec.Decode(ctx, "users", []byte(tc.message), &decoded)
+
+		if tc.err == "" {
+			require.NoError(t, err, "TEST[%d], Failed.\n%s", i, tc.message)
+
+			continue
+		}
+
+		require.ErrorIs(t, err, pubsub.ErrInvalidMessage, "TEST[%d], Failed.\n%s", i, tc.message)
+		assert.Contains(t, err.Error(), tc.err, "TEST[%d], Failed.\n%s", i, tc.message)
+	}
+}
+
+func TestJSONSchema_Encode(t *testing.T) {
+	codec, err := NewJSONSchema(userSchema)
+	require.NoError(t, err)
+
+	type user struct {
+		Name string `json:"name"`
+		Age  int    `json:"age"`
+	}
+
+	ctx := context.Background()
+
+	data, err := codec.Encode(ctx, "users", user{Name: "Alice", Age: 30})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name":"Alice","age":30}`, string(data))
+
+	_, err = codec.Encode(ctx, "users", user{Name: "alice", Age: 30})
+	require.ErrorIs(t, err, pubsub.ErrInvalidMessage)
+
+	var decoded user
+
+	require.NoError(t, codec.Decode(ctx, "users", data, &decoded))
+	assert.Equal(t, user{Name: "Alice", Age: 30}, decoded)
+
+	err = codec.Decode(ctx, "users", []byte("not json"), &decoded)
+	require.ErrorIs(t, err, pubsub.ErrInvalidMessage)
+}
+
+func TestNewJSONSchema_Error(t *testing.T) {
+	schemas := []string{`{"type":`, `{"pattern":"("}`, `{"type":1}`}
+
+	for i, schema := range schemas {
+		_, err := NewJSONSchema(schema)
+		require.ErrorIs(t, err, errJSONSchema, "TEST[%d], Failed.\n", i)
+	}
+}
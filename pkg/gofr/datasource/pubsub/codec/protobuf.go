@@ -0,0 +1,158 @@
+package codec
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"gofr.dev/pkg/gofr/datasource/pubsub"
+)
+
+var (
+	errNotProtoMessage = errors.New("value is not a proto.Message")
+	errProtobufSchema  = errors.New("invalid protobuf schema")
+)
+
+// Protobuf encodes and decodes the values of a topic, which are the generated proto.Message types. With a schema
+// registry, the messages are written in its wire format with the ID of the latest schema of the topic, which has to
+// be registered with the schema registry beforehand. Without a schema registry, the messages are written as they are.
+type Protobuf struct {
+	registry *SchemaRegistry
+}
+
+// NewProtobuf creates a Protobuf codec, registry can be nil.
+func NewProtobuf(registry *SchemaRegistry) *Protobuf {
+	return &Protobuf{registry: registry}
+}
+
+// Encode encodes the message, in the wire format of the schema registry when the codec has one.
+func (p *Protobuf) Encode(ctx context.Context, topic string, v any) ([]byte, error) {
+	msg, ok := v.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T", errNotProtoMessage, v)
+	}
+
+	var data []byte
+
+	if p.registry != nil {
+		schema, err := p.registry.LatestSchema(ctx, topicSubject(topic))
+		if err != nil {
+			return nil, err
+		}
+
+		if schema.Type != schemaTypeProtobuf {
+			return nil, fmt.Errorf("%w: schema %d is of type %s", errProtobufSchema, schema.ID, schema.Type)
+		}
+
+		data = appendMessageIndexes(appendWireHeader(nil, schema.ID), msg.ProtoReflect().Descriptor())
+	}
+
+	return proto.MarshalOptions{}.MarshalAppend(data, msg)
+}
+
+// Decode decodes the data into the message, which has to be of the type the data is written with.
+func (p *Protobuf) Decode(_ context.Context, _ string, data []byte, v any) error {
+	msg, ok := v.(proto.Message)
+	if !ok {
+		return fmt.Errorf("%w: %T", errNotProtoMessage, v)
+	}
+
+	if p.registry != nil {
+		_, payload, err := readWireHeader(data)
+		if err != nil {
+			return err
+		}
+
+		if data, err = skipMessageIndexes(payload); err != nil {
+			return err
+		}
+	}
+
+	if err := proto.Unmarshal(data, msg); err != nil {
+		return fmt.Errorf("%w: %w", pubsub.ErrInvalidMessage, err)
+	}
+
+	return nil
+}
+
+// IsEncoded reports whether the data is a message in the protobuf wire format, prefixed with the wire format of the
+// schema registry when the codec has one, so that the messages encoded with EncodeMessage of the container are
+// published as they are.
+func (p *Protobuf) IsEncoded(data []byte) bool {
+	if p.registry != nil {
+		_, payload, err := readWireHeader(data)
+		if err != nil {
+			return false
+		}
+
+		if data, err = skipMessageIndexes(payload); err != nil {
+			return false
+		}
+	}
+
+	for len(data) > 0 {
+		num, typ, tagLength := protowire.ConsumeTag(data)
+		if tagLength < 0 {
+			return false
+		}
+
+		valueLength := protowire.ConsumeFieldValue(num, typ, data[tagLength:])
+		if valueLength < 0 {
+			return false
+		}
+
+		data = data[tagLength+valueLength:]
+	}
+
+	return true
+}
+
+// appendMessageIndexes appends the path of the message in the schema, i.e. the indexes of the message and of the
+// messages it is nested in, as per the wire format of the schema registry. The path of the first message of the
+// schema is written as a single zero.
+func appendMessageIndexes(data []byte, desc protoreflect.MessageDescriptor) []byte {
+	var indexes []int64
+
+	for d := protoreflect.Descriptor(desc); d != nil; d = d.Parent() {
+		if _, ok := d.(protoreflect.MessageDescriptor); ok {
+			indexes = append([]int64{int64(d.Index())}, indexes...)
+		}
+	}
+
+	if len(indexes) == 1 && indexes[0] == 0 {
+		return append(data, 0)
+	}
+
+	data = binary.AppendVarint(data, int64(len(indexes)))
+
+	for _, index := range indexes {
+		data = binary.AppendVarint(data, index)
+	}
+
+	return data
+}
+
+// skipMessageIndexes returns the payload after the path of the message in the schema.
+func skipMessageIndexes(data []byte) ([]byte, error) {
+	count, size := binary.Varint(data)
+	if size <= 0 || count < 0 {
+		return nil, fmt.Errorf("%w: invalid message indexes", pubsub.ErrInvalidMessage)
+	}
+
+	data = data[size:]
+
+	for i := int64(0); i < count; i++ {
+		if _, size = binary.Varint(data); size <= 0 {
+			return nil, fmt.Errorf("%w: invalid message indexes", pubsub.ErrInvalidMessage)
+		}
+
+		data = data[size:]
+	}
+
+	return data, nil
+}
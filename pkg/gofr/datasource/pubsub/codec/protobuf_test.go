@@ -0,0 +1,106 @@
+package codec
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"gofr.dev/pkg/gofr/datasource/pubsub"
+)
+
+func TestProtobuf_RoundTrip(t *testing.T) {
+	server := newTestRegistry(t)
+	id := server.register(t, "values-value", schemaTypeProtobuf, `syntax = "proto3"; message Value {}`)
+
+	testCases := []struct {
+		desc    string
+		message proto.Message
+		decoded proto.Message
+		indexes []byte
+	}{
+		{"first message of the schema", wrapperspb.Double(1.5), &wrapperspb.DoubleValue{}, []byte{0}},
+		// StringValue is the eighth message of wrappers.proto, written as the path [7]
+		{"other message of the schema", wrapperspb.String("hello"), &wrapperspb.StringValue{}, []byte{2, 14}},
+	}
+
+	codec := NewProtobuf(NewSchemaRegistry(RegistryConfig{URL: server.URL}))
+	ctx := context.Background()
+
+	for i, tc := range testCases {
+		data, err := codec.Encode(ctx, "values", tc.message)
+		require.NoError(t, err, "TEST[%d], Failed.\n%s", i, tc.desc)
+
+		schemaID, payload, err := readWireHeader(data)
+		require.NoError(t, err, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, id, schemaID, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.indexes, payload[:len(tc.indexes)], "TEST[%d], Failed.\n%s", i, tc.desc)
+
+		require.NoError(t, codec.Decode(ctx, "values", data, tc.decoded), "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.True(t, proto.Equal(tc.message, tc.decoded), "TEST[%d], Failed.\n%s", i, tc.desc)
+
+		assert.True(t, codec.IsEncoded(data), "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.False(t, codec.IsEncoded(data[wireHeaderLength:]), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestProtobuf_WithoutRegistry(t *testing.T) {
+	codec := NewProtobuf(nil)
+	ctx := context.Background()
+
+	message, err := structpb.NewStruct(map[string]any{"name": "Alice", "tags": []any{"a", "b"}})
+	require.NoError(t, err)
+
+	data, err := codec.Encode(ctx, "users", message)
+	require.NoError(t, err)
+
+	// the messages are written without the wire format of the schema registry
+	unmarshalled := &structpb.Struct{}
+
+	require.NoError(t, proto.Unmarshal(data, unmarshalled))
+	assert.True(t, proto.Equal(message, unmarshalled))
+
+	decoded := &structpb.Struct{}
+
+	require.NoError(t, codec.Decode(ctx, "users", data, decoded))
+	assert.True(t, proto.Equal(message, decoded))
+
+	assert.True(t, codec.IsEncoded(data))
+	assert.False(t, codec.IsEncoded(data[:len(data)-1]))
+	assert.False(t, codec.IsEncoded([]byte("garbage")))
+}
+
+func TestProtobuf_Errors(t *testing.T) {
+	server := newTestRegistry(t)
+	server.register(t, "avro-value", "", `"string"`)
+
+	codec := NewProtobuf(NewSchemaRegistry(RegistryConfig{URL: server.URL}))
+	ctx := context.Background()
+
+	_, err := codec.Encode(ctx, "avro", wrapperspb.String("hello"))
+	require.ErrorIs(t, err, errProtobufSchema)
+
+	_, err = codec.Encode(ctx, "avro", "hello")
+	require.ErrorIs(t, err, errNotProtoMessage)
+
+	var value string
+
+	err = codec.Decode(ctx, "avro", []byte{0, 0, 0, 0, 1, 0}, &value)
+	require.ErrorIs(t, err, errNotProtoMessage)
+
+	messages := [][]byte{
+		[]byte("hello"),
+		{0, 0, 0, 0, 1},
+		{0, 0, 0, 0, 1, 2},
+		{0, 0, 0, 0, 1, 0, 0xff},
+	}
+
+	for i, data := range messages {
+		err = codec.Decode(ctx, "avro", data, &wrapperspb.StringValue{})
+		require.ErrorIs(t, err, pubsub.ErrInvalidMessage, "TEST[%d], Failed.\n", i)
+	}
+}
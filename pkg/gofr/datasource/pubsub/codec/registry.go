@@ -0,0 +1,221 @@
+// Package codec provides the codecs serializing the messages of pub/sub topics with Avro, Protobuf and JSON Schema.
+// The Avro and Protobuf codecs look up the schemas of the topics in a Confluent compatible schema registry, and write
+// the messages in its wire format, so that they are interoperable with the other clients of the registry.
+package codec
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultRegistryTimeout  = 10 * time.Second
+	defaultRegistryCacheTTL = 5 * time.Minute
+
+	schemaTypeAvro     = "AVRO"
+	schemaTypeProtobuf = "PROTOBUF"
+)
+
+var errRegistryResponse = errors.New("unexpected response from schema registry")
+
+// RegistryConfig configures the client of a schema registry.
+type RegistryConfig struct {
+	// URL of the schema registry, e.g. http://localhost:8081.
+	URL string
+	// Username and Password are used for the basic authentication, when set.
+	Username string
+	Password string
+	// Timeout of the requests to the schema registry, 10s by default.
+	Timeout time.Duration
+	// CacheTTL is the duration for which the latest schemas of the subjects are cached, 5m by default, so that the
+	// new versions of the schemas are used once it expires.
+	CacheTTL time.Duration
+}
+
+// Schema is a schema registered in the schema registry.
+type Schema struct {
+	ID int `json:"id"`
+	// Type is AVRO, PROTOBUF or JSON, it is AVRO when it is not returned by the schema registry.
+	Type   string `json:"schemaType"`
+	Schema string `json:"schema"`
+}
+
+// SchemaRegistry is a client of a Confluent compatible schema registry, caching the schemas it has fetched. The schemas
+// are cached by their IDs for the lifetime of the client, as they do not change, while the latest schemas of the
+// subjects are cached for the CacheTTL.
+type SchemaRegistry struct {
+	config RegistryConfig
+	client *http.Client
+
+	mu        sync.RWMutex
+	byID      map[int]*Schema
+	bySubject map[string]latestSchema
+}
+
+// latestSchema is the latest schema of a subject, cached until it expires.
+type latestSchema struct {
+	schema    *Schema
+	expiresAt time.Time
+}
+
+// NewSchemaRegistry creates a client of the schema registry.
+func NewSchemaRegistry(config RegistryConfig) *SchemaRegistry {
+	if config.Timeout <= 0 {
+		config.Timeout = defaultRegistryTimeout
+	}
+
+	if config.CacheTTL <= 0 {
+		config.CacheTTL = defaultRegistryCacheTTL
+	}
+
+	config.URL = strings.TrimSuffix(config.URL, "/")
+
+	return &SchemaRegistry{
+		config:    config,
+		client:    &http.Client{Timeout: config.Timeout},
+		byID:      make(map[int]*Schema),
+		bySubject: make(map[string]latestSchema),
+	}
+}
+
+// SchemaByID returns the schema with the ID, which is written along with the messages in the wire format.
+func (r *SchemaRegistry) SchemaByID(ctx context.Context, id int) (*Schema, error) {
+	r.mu.RLock()
+	schema, ok := r.byID[id]
+	r.mu.RUnlock()
+
+	if ok {
+		return schema, nil
+	}
+
+	schema = &Schema{}
+
+	if err := r.do(ctx, http.MethodGet, "/schemas/ids/"+strconv.Itoa(id), nil, schema); err != nil {
+		return nil, err
+	}
+
+	schema.ID = id
+
+	r.cache(schema, "")
+
+	return schema, nil
+}
+
+// LatestSchema returns the latest version of the schema of the subject, the subject of the values of a topic is
+// <topic>-value as per the topic name strategy of the schema registry.
+func (r *SchemaRegistry) LatestSchema(ctx context.Context, subject string) (*Schema, error) {
+	r.mu.RLock()
+	latest, ok := r.bySubject[subject]
+	r.mu.RUnlock()
+
+	if ok && time.Now().Before(latest.expiresAt) {
+		return latest.schema, nil
+	}
+
+	schema := &Schema{}
+
+	if err := r.do(ctx, http.MethodGet, "/subjects/"+url.PathEscape(subject)+"/versions/latest", nil, schema); err != nil {
+		return nil, err
+	}
+
+	r.cache(schema, subject)
+
+	return schema, nil
+}
+
+// Register registers the schema for the subject, returning its ID. The ID of the existing schema is returned when it
+// is already registered.
+func (r *SchemaRegistry) Register(ctx context.Context, subject string, schema Schema) (int, error) {
+	body, err := json.Marshal(struct {
+		Schema string `json:"schema"`
+		Type   string `json:"schemaType,omitempty"`
+	}{schema.Schema, schema.Type})
+	if err != nil {
+		return 0, err
+	}
+
+	var registered struct {
+		ID int `json:"id"`
+	}
+
+	if err = r.do(ctx, http.MethodPost, "/subjects/"+url.PathEscape(subject)+"/versions", body, &registered); err != nil {
+		return 0, err
+	}
+
+	schema.ID = registered.ID
+
+	r.cache(&schema, "")
+
+	return registered.ID, nil
+}
+
+func (r *SchemaRegistry) cache(schema *Schema, subject string) {
+	if schema.Type == "" {
+		schema.Type = schemaTypeAvro
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.byID[schema.ID] = schema
+
+	if subject != "" {
+		r.bySubject[subject] = latestSchema{schema: schema, expiresAt: time.Now().Add(r.config.CacheTTL)}
+	}
+}
+
+func (r *SchemaRegistry) do(ctx context.Context, method, path string, body []byte, result any) error {
+	var reqBody io.Reader
+	if body != nil {
+		reqBody = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, r.config.URL+path, reqBody)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/vnd.schemaregistry.v1+json")
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/vnd.schemaregistry.v1+json")
+	}
+
+	if r.config.Username != "" {
+		req.SetBasicAuth(r.config.Username, r.config.Password)
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: %s %s returned %d: %s", errRegistryResponse, method, path, resp.StatusCode,
+			strings.TrimSpace(string(respBody)))
+	}
+
+	return json.Unmarshal(respBody, result)
+}
+
+// topicSubject returns the subject of the values of the topic, as per the topic name strategy.
+func topicSubject(topic string) string {
+	return topic + "-value"
+}
@@ -0,0 +1,175 @@
+package codec
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testRegistry is a local stand-in for a schema registry, serving the endpoints used by SchemaRegistry.
+type testRegistry struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	schemas  []Schema
+	subjects map[string][]int
+	requests atomic.Int32
+}
+
+func newTestRegistry(t *testing.T) *testRegistry {
+	t.Helper()
+
+	r := &testRegistry{subjects: make(map[string][]int)}
+	r.Server = httptest.NewServer(http.HandlerFunc(r.serve))
+
+	t.Cleanup(r.Close)
+
+	return r
+}
+
+func (r *testRegistry) serve(w http.ResponseWriter, req *http.Request) {
+	r.requests.Add(1)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	parts := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+
+	switch {
+	case req.Method == http.MethodPost && len(parts) == 3 && parts[0] == "subjects":
+		var schema Schema
+
+		_ = json.NewDecoder(req.Body).Decode(&schema)
+
+		schema.ID = len(r.schemas) + 1
+		r.schemas = append(r.schemas, schema)
+		r.subjects[parts[1]] = append(r.subjects[parts[1]], schema.ID)
+
+		_ = json.NewEncoder(w).Encode(map[string]int{"id": schema.ID})
+	case len(parts) == 4 && parts[0] == "subjects" && parts[3] == "latest":
+		ids := r.subjects[parts[1]]
+		if len(ids) == 0 {
+			http.Error(w, `{"error_code":40401,"message":"Subject not found."}`, http.StatusNotFound)
+
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(r.schemas[ids[len(ids)-1]-1])
+	case len(parts) == 3 && parts[0] == "schemas":
+		id, _ := strconv.Atoi(parts[2])
+		if id < 1 || id > len(r.schemas) {
+			http.Error(w, `{"error_code":40403,"message":"Schema not found"}`, http.StatusNotFound)
+
+			return
+		}
+
+		schema := r.schemas[id-1]
+		schema.ID = 0
+
+		_ = json.NewEncoder(w).Encode(schema)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (r *testRegistry) register(t *testing.T, subject, schemaType, schema string) int {
+	t.Helper()
+
+	id, err := NewSchemaRegistry(RegistryConfig{URL: r.URL}).Register(context.Background(), subject,
+		Schema{Type: schemaType, Schema: schema})
+	require.NoError(t, err)
+
+	return id
+}
+
+func TestSchemaRegistry_Cache(t *testing.T) {
+	server := newTestRegistry(t)
+	id := server.register(t, "orders-value", "", `"string"`)
+
+	registry := NewSchemaRegistry(RegistryConfig{URL: server.URL + "/"})
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		latest, err := registry.LatestSchema(ctx, "orders-value")
+		require.NoError(t, err)
+
+		byID, err := registry.SchemaByID(ctx, id)
+		require.NoError(t, err)
+
+		assert.Equal(t, &Schema{ID: id, Type: schemaTypeAvro, Schema: `"string"`}, latest)
+		assert.Equal(t, latest, byID)
+	}
+
+	// one request registering the schema and one fetching the latest schema, the schema by ID is cached along with it
+	assert.Equal(t, int32(2), server.requests.Load())
+}
+
+func TestSchemaRegistry_LatestSchemaExpires(t *testing.T) {
+	server := newTestRegistry(t)
+	server.register(t, "orders-value", "", `"string"`)
+
+	registry := NewSchemaRegistry(RegistryConfig{URL: server.URL})
+	ctx := context.Background()
+
+	first, err := registry.LatestSchema(ctx, "orders-value")
+	require.NoError(t, err)
+
+	id := server.register(t, "orders-value", "", `"bytes"`)
+
+	latest, err := registry.LatestSchema(ctx, "orders-value")
+	require.NoError(t, err)
+	assert.Equal(t, first, latest, "the latest schema is cached until it expires")
+
+	registry.mu.Lock()
+	registry.bySubject["orders-value"] = latestSchema{schema: first, expiresAt: time.Now().Add(-time.Second)}
+	registry.mu.Unlock()
+
+	latest, err = registry.LatestSchema(ctx, "orders-value")
+	require.NoError(t, err)
+	assert.Equal(t, id, latest.ID, "the new version of the schema is fetched once the cache expires")
+}
+
+func TestSchemaRegistry_Errors(t *testing.T) {
+	server := newTestRegistry(t)
+	registry := NewSchemaRegistry(RegistryConfig{URL: server.URL})
+	ctx := context.Background()
+
+	_, err := registry.LatestSchema(ctx, "unknown-value")
+	require.ErrorIs(t, err, errRegistryResponse)
+	assert.Contains(t, err.Error(), "Subject not found")
+
+	_, err = registry.SchemaByID(ctx, 42)
+	require.ErrorIs(t, err, errRegistryResponse)
+	assert.Contains(t, err.Error(), "404")
+}
+
+func TestSchemaRegistry_BasicAuth(t *testing.T) {
+	var username, password string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, password, _ = r.BasicAuth()
+
+		assert.Equal(t, "application/vnd.schemaregistry.v1+json", r.Header.Get("Accept"))
+
+		_, _ = w.Write([]byte(`{"schema":"\"string\""}`))
+	}))
+	defer server.Close()
+
+	registry := NewSchemaRegistry(RegistryConfig{URL: server.URL, Username: "user", Password: "secret"})
+
+	_, err := registry.SchemaByID(context.Background(), 1)
+	require.NoError(t, err)
+
+	assert.Equal(t, "user", username)
+	assert.Equal(t, "secret", password)
+}
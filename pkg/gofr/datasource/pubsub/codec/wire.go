@@ -0,0 +1,30 @@
+package codec
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"gofr.dev/pkg/gofr/datasource/pubsub"
+)
+
+const (
+	magicByte        = 0
+	wireHeaderLength = 5
+)
+
+// appendWireHeader appends the header of the wire format of the schema registry, a zero magic byte followed by the
+// ID of the schema as a big-endian 32-bit integer.
+func appendWireHeader(data []byte, schemaID int) []byte {
+	data = append(data, magicByte)
+
+	return binary.BigEndian.AppendUint32(data, uint32(schemaID)) //nolint:gosec // schema IDs are 32-bit integers
+}
+
+// readWireHeader returns the ID of the schema of the data in the wire format, along with the payload.
+func readWireHeader(data []byte) (schemaID int, payload []byte, err error) {
+	if len(data) < wireHeaderLength || data[0] != magicByte {
+		return 0, nil, fmt.Errorf("%w: not in the wire format of the schema registry", pubsub.ErrInvalidMessage)
+	}
+
+	return int(binary.BigEndian.Uint32(data[1:wireHeaderLength])), data[wireHeaderLength:], nil
+}
@@ -0,0 +1,74 @@
+package pubsub
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errTestCodec = errors.New("test codec error")
+
+// prefixCodec encodes the values as JSON prefixed with the topic, failing for the messages of other topics.
+type prefixCodec struct{}
+
+func (prefixCodec) Encode(_ context.Context, topic string, v any) ([]byte, error) {
+	data, err := json.Marshal(v)
+
+	return append([]byte(topic+":"), data...), err
+}
+
+func (prefixCodec) Decode(_ context.Context, topic string, data []byte, v any) error {
+	prefix := []byte(topic + ":")
+	if len(data) < len(prefix) || string(data[:len(prefix)]) != string(prefix) {
+		return errTestCodec
+	}
+
+	return json.Unmarshal(data[len(prefix):], v)
+}
+
+func TestEncode(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		codec    Codec
+		value    any
+		expected string
+	}{
+		{"bytes without codec", nil, []byte("raw"), "raw"},
+		{"string without codec", nil, "text", "text"},
+		{"struct without codec", nil, struct {
+			ID int `json:"id"`
+		}{1}, `{"id":1}`},
+		{"value with codec", prefixCodec{}, map[string]int{"id": 1}, `orders:{"id":1}`},
+		{"string with codec", prefixCodec{}, "text", `orders:"text"`},
+	}
+
+	for i, tc := range testCases {
+		data, err := Encode(context.Background(), tc.codec, "orders", tc.value)
+
+		require.NoError(t, err, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.expected, string(data), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestMessage_BindWithCodec(t *testing.T) {
+	m := NewMessage(context.Background())
+	m.Topic = "orders"
+	m.Value = []byte(`orders:{"id":1}`)
+	m.Codec = prefixCodec{}
+
+	var order struct {
+		ID int `json:"id"`
+	}
+
+	require.NoError(t, m.Bind(&order))
+	assert.Equal(t, 1, order.ID)
+
+	m.Value = []byte(`{"id":1}`)
+
+	require.ErrorIs(t, m.Bind(&order), errTestCodec)
+	require.ErrorIs(t, m.Bind(order), errNotPointer)
+}
@@ -276,8 +276,25 @@ func (c *Client) processEvents(ctx context.Context, partitionClient *azeventhubs
 	}
 
 	msg.Topic = partitionClient.PartitionID()
+	msg.Partition = partitionClient.PartitionID()
 	msg.MetaData = events[0].EventData
 
+	if events[0].PartitionKey != nil {
+		msg.Key = *events[0].PartitionKey
+	}
+
+	if events[0].EnqueuedTime != nil {
+		msg.Timestamp = *events[0].EnqueuedTime
+	}
+
+	if len(events[0].Properties) > 0 {
+		msg.Headers = make(map[string]string, len(events[0].Properties))
+
+		for key, value := range events[0].Properties {
+			msg.Headers[key] = fmt.Sprint(value)
+		}
+	}
+
 	return msg, nil
 }
 
@@ -286,6 +303,13 @@ func closePartitionResources(ctx context.Context, partitionClient *azeventhubs.P
 }
 
 func (c *Client) Publish(ctx context.Context, topic string, message []byte) error {
+	return c.PublishMessage(ctx, topic, &pubsub.PublishMessage{Value: message})
+}
+
+// PublishMessage publishes the message as an event, the key is used as the partition key of the event so that the
+// events with the same key are sent to the same partition, and the headers along with the trace context of ctx are
+// set as its application properties.
+func (c *Client) PublishMessage(ctx context.Context, topic string, message *pubsub.PublishMessage) error {
 	if topic != c.cfg.EventhubName {
 		return errors.New("topic should be same as eventhub name")
 	}
@@ -293,6 +317,9 @@ func (c *Client) Publish(ctx context.Context, topic string, message []byte) erro
 	c.metrics.IncrementCounter(ctx, "app_pubsub_publish_total_count", "topic", topic)
 
 	newBatchOptions := &azeventhubs.EventDataBatchOptions{}
+	if message.Key != "" {
+		newBatchOptions.PartitionKey = &message.Key
+	}
 
 	batch, err := c.producer.NewEventDataBatch(ctx, newBatchOptions)
 	if err != nil {
@@ -301,12 +328,22 @@ func (c *Client) Publish(ctx context.Context, topic string, message []byte) erro
 		return err
 	}
 
-	data := []*azeventhubs.EventData{{
-		Body: message,
-	}}
+	event := &azeventhubs.EventData{
+		Body: message.Value,
+	}
 
-	for i := 0; i < len(data); i++ {
-		err = batch.AddEventData(data[i], nil)
+	if headers := pubsub.InjectTraceContext(ctx, message.Headers); len(headers) > 0 {
+		event.Properties = make(map[string]any, len(headers))
+
+		for key, value := range headers {
+			event.Properties[key] = value
+		}
+	}
+
+	if err = batch.AddEventData(event, nil); err != nil {
+		c.logger.Errorf("failed to add event to the batch %v", err)
+
+		return err
 	}
 
 	start := time.Now()
@@ -320,7 +357,7 @@ func (c *Client) Publish(ctx context.Context, topic string, message []byte) erro
 
 	c.logger.Debug(&Log{
 		Mode:          "PUB",
-		MessageValue:  strings.Join(strings.Fields(string(message)), " "),
+		MessageValue:  strings.Join(strings.Fields(string(message.Value)), " "),
 		Topic:         topic,
 		Host:          fmt.Sprint(c.cfg.EventhubName),
 		PubSubBackend: "EVHUB",
@@ -80,6 +80,12 @@ func validateConfigs(conf *Config) error {
 }
 
 func (g *googleClient) Publish(ctx context.Context, topic string, message []byte) error {
+	return g.PublishMessage(ctx, topic, &pubsub.PublishMessage{Value: message})
+}
+
+// PublishMessage publishes the message with its headers and the trace context of ctx as attributes, and its key as
+// the ordering key.
+func (g *googleClient) PublishMessage(ctx context.Context, topic string, message *pubsub.PublishMessage) error {
 	ctx, span := otel.GetTracerProvider().Tracer("gofr").Start(ctx, "publish-gcp")
 	defer span.End()
 
@@ -92,10 +98,22 @@ func (g *googleClient) Publish(ctx context.Context, topic string, message []byte
 		return err
 	}
 
+	publishTime := message.Timestamp
+	if publishTime.IsZero() {
+		publishTime = time.Now()
+	}
+
+	// messages with an ordering key are delivered in order, which has to be enabled on the topic before publishing
+	if message.Key != "" {
+		t.EnableMessageOrdering = true
+	}
+
 	start := time.Now()
 	result := t.Publish(ctx, &gcPubSub.Message{
-		Data:        message,
-		PublishTime: time.Now(),
+		Data:        message.Value,
+		Attributes:  pubsub.InjectTraceContext(ctx, message.Headers),
+		OrderingKey: message.Key,
+		PublishTime: publishTime,
 	})
 	end := time.Since(start)
 
@@ -103,13 +121,18 @@ func (g *googleClient) Publish(ctx context.Context, topic string, message []byte
 	if err != nil {
 		g.logger.Errorf("error publishing to google topic '%s', error: %v", topic, err)
 
+		// publishing of an ordering key is paused after a failure, until it is resumed
+		if message.Key != "" {
+			t.ResumePublish(message.Key)
+		}
+
 		return err
 	}
 
 	g.logger.Debug(&pubsub.Log{
 		Mode:          "PUB",
 		CorrelationID: span.SpanContext().TraceID().String(),
-		MessageValue:  string(message),
+		MessageValue:  string(message.Value),
 		Topic:         topic,
 		Host:          g.ProjectID,
 		PubSubBackend: "GCP",
@@ -149,6 +172,9 @@ func (g *googleClient) Subscribe(ctx context.Context, topic string) (*pubsub.Mes
 			m.Topic = topic
 			m.Value = msg.Data
 			m.MetaData = msg.Attributes
+			m.Key = msg.OrderingKey
+			m.Headers = msg.Attributes
+			m.Timestamp = msg.PublishTime
 			m.Committer = newGoogleMessage(msg)
 
 			g.mu.Lock()
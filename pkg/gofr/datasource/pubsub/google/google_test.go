@@ -90,6 +90,48 @@ func TestGoogleClient_Publish_Success(t *testing.T) {
 	assert.Contains(t, out, "GCP")
 }
 
+func TestGoogleClient_PublishMessage(t *testing.T) {
+	srv := pstest.NewServer()
+	defer srv.Close()
+
+	conn, err := grpc.NewClient(srv.Addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+
+	client, err := gcPubSub.NewClient(context.Background(), "project", option.WithGRPCConn(conn))
+	require.NoError(t, err)
+
+	defer client.Close()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMetrics := NewMockMetrics(ctrl)
+
+	g := &googleClient{
+		logger:  logging.NewMockLogger(logging.DEBUG),
+		client:  client,
+		Config:  Config{ProjectID: "test", SubscriptionName: "sub"},
+		metrics: mockMetrics,
+	}
+
+	mockMetrics.EXPECT().IncrementCounter(gomock.Any(), "app_pubsub_publish_total_count", "topic", "test-topic")
+	mockMetrics.EXPECT().IncrementCounter(gomock.Any(), "app_pubsub_publish_success_count", "topic", "test-topic")
+
+	err = g.PublishMessage(context.Background(), "test-topic", &pubsub.PublishMessage{
+		Key:     "order-123",
+		Value:   []byte("test message"),
+		Headers: map[string]string{"Order-Id": "123"},
+	})
+	require.NoError(t, err)
+
+	messages := srv.Messages()
+	require.Len(t, messages, 1)
+
+	assert.Equal(t, []byte("test message"), messages[0].Data)
+	assert.Equal(t, "order-123", messages[0].OrderingKey)
+	assert.Equal(t, map[string]string{"Order-Id": "123"}, messages[0].Attributes)
+}
+
 func TestGoogleClient_PublishTopic_Error(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -15,3 +15,7 @@ func newGoogleMessage(msg *gcPubSub.Message) *googleMessage {
 func (gm *googleMessage) Commit() {
 	gm.msg.Ack()
 }
+
+func (gm *googleMessage) Nack() {
+	gm.msg.Nack()
+}
@@ -20,3 +20,9 @@ func TestGoogleMessage_Commit(_ *testing.T) {
 
 	msg.Commit()
 }
+
+func TestGoogleMessage_Nack(_ *testing.T) {
+	msg := newGoogleMessage(&gcPubSub.Message{})
+
+	msg.Nack()
+}
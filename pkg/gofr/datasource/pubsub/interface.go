@@ -4,12 +4,15 @@ package pubsub
 
 import (
 	"context"
+	"time"
 
 	"gofr.dev/pkg/gofr/datasource"
 )
 
 type Publisher interface {
 	Publish(ctx context.Context, topic string, message []byte) error
+	// PublishMessage publishes the message along with its key, headers and timestamp, as supported by the backend.
+	PublishMessage(ctx context.Context, topic string, message *PublishMessage) error
 }
 
 type Subscriber interface {
@@ -27,10 +30,39 @@ type Client interface {
 	Close() error
 }
 
+// BatchConfigurer is implemented by the backends which are configured to consume the messages in batches, e.g. with
+// KAFKA_BATCH_SIZE or NATS_BATCH_SIZE, whose batch size and wait are used by the batch subscriptions not setting them.
+type BatchConfigurer interface {
+	BatchConfig() (size int, wait time.Duration)
+}
+
+// TopicConfig configures the topics created with CreateTopicWithConfig.
+type TopicConfig struct {
+	// Partitions of the topic, 1 by default.
+	Partitions int
+	// ReplicationFactor of the partitions of the topic, 1 by default.
+	ReplicationFactor int
+	// Configs of the topic as per the backend, e.g. retention.ms or cleanup.policy for Kafka.
+	Configs map[string]string
+}
+
+// TopicConfigurer is implemented by the backends which create the topics with partitions, replication and configs,
+// e.g. Kafka.
+type TopicConfigurer interface {
+	CreateTopicWithConfig(ctx context.Context, name string, config TopicConfig) error
+}
+
 type Committer interface {
 	Commit()
 }
 
+// Nacker is implemented by the committers of the backends which can hand a message back to the broker for an
+// immediate redelivery, e.g. Google and NATS. For the other backends, a message which is not committed is
+// redelivered as per the semantics of the broker.
+type Nacker interface {
+	Nack()
+}
+
 type Logger interface {
 	Debugf(format string, args ...interface{})
 	Debug(args ...interface{})
@@ -5,6 +5,9 @@ package kafka
 import (
 	"context"
 	"errors"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -21,22 +24,53 @@ var (
 	errBatchSize                = errors.New("KAFKA_BATCH_SIZE must be greater than 0")
 	errBatchBytes               = errors.New("KAFKA_BATCH_BYTES must be greater than 0")
 	errBatchTimeout             = errors.New("KAFKA_BATCH_TIMEOUT must be greater than 0")
+	errOffset                   = errors.New("PUBSUB_OFFSET must be -1 (latest) or -2 (earliest)")
+	errIsolationLevel           = errors.New("KAFKA_ISOLATION_LEVEL must be read_uncommitted or read_committed")
 )
 
 const (
 	DefaultBatchSize    = 100
 	DefaultBatchBytes   = 1048576
 	DefaultBatchTimeout = 1000
+
+	// OffsetLatest and OffsetEarliest are the values of OffSet, as per kafka.LastOffset and kafka.FirstOffset.
+	OffsetLatest   = -1
+	OffsetEarliest = -2
+
+	IsolationReadUncommitted = "read_uncommitted"
+	IsolationReadCommitted   = "read_committed"
 )
 
 type Config struct {
+	// Broker is the address of the broker, or the comma-separated addresses of the brokers of the cluster.
 	Broker          string
 	Partition       int
 	ConsumerGroupID string
-	OffSet          int
-	BatchSize       int
-	BatchBytes      int
-	BatchTimeout    int
+	// OffSet is where the consumer group starts consuming the partitions without a committed offset, OffsetLatest
+	// or OffsetEarliest. The partitions with a committed offset are consumed from it. It is OffsetEarliest when 0.
+	OffSet       int
+	BatchSize    int
+	BatchBytes   int
+	BatchTimeout int
+	// SubscribeBatchWait is the time a batch subscription waits for its batch to fill after the first message, when
+	// it is not given to SubscribeBatch.
+	SubscribeBatchWait time.Duration
+
+	// SecurityProtocol is PLAINTEXT by default, SSL, SASL_PLAINTEXT or SASL_SSL.
+	SecurityProtocol string
+	// SASLMechanism is PLAIN, SCRAM-SHA-256 or SCRAM-SHA-512, with the SASL security protocols.
+	SASLMechanism string
+	SASLUser      string
+	SASLPassword  string
+	// TLS is used with the SSL and SASL_SSL security protocols.
+	TLS TLSConfig
+
+	// SessionTimeout of the consumer group members, after which a member not sending heartbeats is removed from the
+	// group and its partitions are reassigned. It is 30s by default.
+	SessionTimeout time.Duration
+	// IsolationLevel of the readers, read_uncommitted by default or read_committed which reads only the committed
+	// messages of the transactional producers.
+	IsolationLevel string
 }
 
 type kafkaClient struct {
@@ -62,9 +96,16 @@ func New(conf Config, logger pubsub.Logger, metrics Metrics) *kafkaClient {
 		return nil
 	}
 
+	dialer, err := newDialer(&conf)
+	if err != nil {
+		logger.Errorf("could not initialize kafka, error: %v", err)
+
+		return nil
+	}
+
 	logger.Debugf("connecting to kafka broker '%s'", conf.Broker)
 
-	conn, err := kafka.Dial("tcp", conf.Broker)
+	conn, err := dial(dialer, conf.brokers())
 	if err != nil {
 		logger.Errorf("failed to connect to kafka at %v, error: %v", conf.Broker, err)
 
@@ -75,17 +116,14 @@ func New(conf Config, logger pubsub.Logger, metrics Metrics) *kafkaClient {
 		}
 	}
 
-	dialer := &kafka.Dialer{
-		Timeout:   10 * time.Second,
-		DualStack: true,
-	}
-
 	writer := kafka.NewWriter(kafka.WriterConfig{
-		Brokers:      []string{conf.Broker},
+		Brokers:      conf.brokers(),
 		Dialer:       dialer,
 		BatchSize:    conf.BatchSize,
 		BatchBytes:   conf.BatchBytes,
 		BatchTimeout: time.Duration(conf.BatchTimeout),
+		// messages with a key are published to the partition of the key, the others are spread across partitions
+		Balancer: &kafka.Hash{},
 	})
 
 	reader := make(map[string]Reader)
@@ -121,10 +159,58 @@ func validateConfigs(conf Config) error {
 		return errBatchTimeout
 	}
 
-	return nil
+	if conf.OffSet != 0 && conf.OffSet != OffsetLatest && conf.OffSet != OffsetEarliest {
+		return errOffset
+	}
+
+	if _, err := conf.isolationLevel(); err != nil {
+		return err
+	}
+
+	return validateSecurity(&conf)
+}
+
+// brokers returns the addresses of the brokers, which are comma-separated in Broker.
+func (c *Config) brokers() []string {
+	brokers := strings.Split(c.Broker, ",")
+
+	for i := range brokers {
+		brokers[i] = strings.TrimSpace(brokers[i])
+	}
+
+	return brokers
+}
+
+func (c *Config) isolationLevel() (kafka.IsolationLevel, error) {
+	switch strings.ToLower(c.IsolationLevel) {
+	case "", IsolationReadUncommitted:
+		return kafka.ReadUncommitted, nil
+	case IsolationReadCommitted:
+		return kafka.ReadCommitted, nil
+	default:
+		return 0, errIsolationLevel
+	}
+}
+
+// dial connects to the first available broker, the connection is used to manage the topics.
+func dial(dialer *kafka.Dialer, brokers []string) (conn *kafka.Conn, err error) {
+	for _, broker := range brokers {
+		conn, err = dialer.Dial("tcp", broker)
+		if err == nil {
+			return conn, nil
+		}
+	}
+
+	return nil, err
 }
 
 func (k *kafkaClient) Publish(ctx context.Context, topic string, message []byte) error {
+	return k.PublishMessage(ctx, topic, &pubsub.PublishMessage{Value: message})
+}
+
+// PublishMessage publishes the message with its key, headers and timestamp, the messages with the same key are
+// published to the same partition. The trace context of ctx is published along with the headers.
+func (k *kafkaClient) PublishMessage(ctx context.Context, topic string, message *pubsub.PublishMessage) error {
 	ctx, span := otel.GetTracerProvider().Tracer("gofr").Start(ctx, "kafka-publish")
 	defer span.End()
 
@@ -134,14 +220,26 @@ func (k *kafkaClient) Publish(ctx context.Context, topic string, message []byte)
 		return errPublisherNotConfigured
 	}
 
+	msg := kafka.Message{
+		Topic: topic,
+		Value: message.Value,
+		Time:  message.Timestamp,
+	}
+
+	if msg.Time.IsZero() {
+		msg.Time = time.Now()
+	}
+
+	if message.Key != "" {
+		msg.Key = []byte(message.Key)
+	}
+
+	for key, value := range pubsub.InjectTraceContext(ctx, message.Headers) {
+		msg.Headers = append(msg.Headers, kafka.Header{Key: key, Value: []byte(value)})
+	}
+
 	start := time.Now()
-	err := k.writer.WriteMessages(ctx,
-		kafka.Message{
-			Topic: topic,
-			Value: message,
-			Time:  time.Now(),
-		},
-	)
+	err := k.writer.WriteMessages(ctx, msg)
 	end := time.Since(start)
 
 	if err != nil {
@@ -152,7 +250,7 @@ func (k *kafkaClient) Publish(ctx context.Context, topic string, message []byte)
 	k.logger.Debug(&pubsub.Log{
 		Mode:          "PUB",
 		CorrelationID: span.SpanContext().TraceID().String(),
-		MessageValue:  string(message),
+		MessageValue:  string(message.Value),
 		Topic:         topic,
 		Host:          k.config.Broker,
 		PubSubBackend: "KAFKA",
@@ -164,6 +262,11 @@ func (k *kafkaClient) Publish(ctx context.Context, topic string, message []byte)
 	return nil
 }
 
+// BatchConfig returns KAFKA_BATCH_SIZE and KAFKA_SUBSCRIBE_BATCH_WAIT as the size and wait of the batch subscriptions.
+func (k *kafkaClient) BatchConfig() (size int, wait time.Duration) {
+	return k.config.BatchSize, k.config.SubscribeBatchWait
+}
+
 func (k *kafkaClient) Subscribe(ctx context.Context, topic string) (*pubsub.Message, error) {
 	if k.config.ConsumerGroupID == "" {
 		k.logger.Error("cannot subscribe as consumer_id is not provided in configs")
@@ -202,6 +305,18 @@ func (k *kafkaClient) Subscribe(ctx context.Context, topic string) (*pubsub.Mess
 	m := pubsub.NewMessage(ctx)
 	m.Value = msg.Value
 	m.Topic = topic
+	m.Key = string(msg.Key)
+	m.Timestamp = msg.Time
+	m.Partition = strconv.Itoa(msg.Partition)
+
+	if len(msg.Headers) > 0 {
+		m.Headers = make(map[string]string, len(msg.Headers))
+
+		for _, h := range msg.Headers {
+			m.Headers[h.Key] = string(h.Value)
+		}
+	}
+
 	m.Committer = newKafkaMessage(&msg, k.reader[topic], k.logger)
 
 	end := time.Since(start)
@@ -217,10 +332,25 @@ func (k *kafkaClient) Subscribe(ctx context.Context, topic string) (*pubsub.Mess
 	})
 
 	k.metrics.IncrementCounter(ctx, "app_pubsub_subscribe_success_count", "topic", topic, "consumer_group", k.config.ConsumerGroupID)
+	k.recordLag(topic, &msg)
 
 	return m, err
 }
 
+// recordLag records the messages of the partition yet to be read by the consumer group, from the high watermark
+// of the partition fetched along with the message.
+func (k *kafkaClient) recordLag(topic string, msg *kafka.Message) {
+	metrics, ok := k.metrics.(gauge)
+	if !ok || msg.HighWaterMark == 0 {
+		return
+	}
+
+	lag := max(msg.HighWaterMark-msg.Offset-1, 0)
+
+	metrics.SetGauge("app_pubsub_consumer_lag", float64(lag), "topic", topic,
+		"partition", strconv.Itoa(msg.Partition), "consumer_group", k.config.ConsumerGroupID)
+}
+
 func (k *kafkaClient) Close() (err error) {
 	for _, r := range k.reader {
 		err = errors.Join(err, r.Close())
@@ -238,14 +368,19 @@ func (k *kafkaClient) Close() (err error) {
 }
 
 func (k *kafkaClient) getNewReader(topic string) Reader {
+	// the isolation level is validated along with the configs
+	isolationLevel, _ := k.config.isolationLevel()
+
 	reader := kafka.NewReader(kafka.ReaderConfig{
-		GroupID:     k.config.ConsumerGroupID,
-		Brokers:     []string{k.config.Broker},
-		Topic:       topic,
-		MinBytes:    10e3,
-		MaxBytes:    10e6,
-		Dialer:      k.dialer,
-		StartOffset: int64(k.config.OffSet),
+		GroupID:        k.config.ConsumerGroupID,
+		Brokers:        k.config.brokers(),
+		Topic:          topic,
+		MinBytes:       10e3,
+		MaxBytes:       10e6,
+		Dialer:         k.dialer,
+		StartOffset:    int64(k.config.OffSet),
+		SessionTimeout: k.config.SessionTimeout,
+		IsolationLevel: isolationLevel,
 	})
 
 	return reader
@@ -259,13 +394,29 @@ func (k *kafkaClient) Controller() (broker kafka.Broker, err error) {
 	return k.conn.Controller()
 }
 
-func (k *kafkaClient) CreateTopic(_ context.Context, name string) error {
-	topics := kafka.TopicConfig{Topic: name, NumPartitions: 1, ReplicationFactor: 1}
+func (k *kafkaClient) CreateTopic(ctx context.Context, name string) error {
+	return k.CreateTopicWithConfig(ctx, name, pubsub.TopicConfig{})
+}
 
-	err := k.conn.CreateTopics(topics)
-	if err != nil {
-		return err
+// CreateTopicWithConfig creates the topic with the partitions, replication factor and configs, e.g. retention.ms.
+func (k *kafkaClient) CreateTopicWithConfig(_ context.Context, name string, config pubsub.TopicConfig) error {
+	topic := kafka.TopicConfig{Topic: name, NumPartitions: config.Partitions, ReplicationFactor: config.ReplicationFactor}
+
+	if topic.NumPartitions <= 0 {
+		topic.NumPartitions = 1
 	}
 
-	return nil
+	if topic.ReplicationFactor <= 0 {
+		topic.ReplicationFactor = 1
+	}
+
+	for key, value := range config.Configs {
+		topic.ConfigEntries = append(topic.ConfigEntries, kafka.ConfigEntry{ConfigName: key, ConfigValue: value})
+	}
+
+	sort.Slice(topic.ConfigEntries, func(i, j int) bool {
+		return topic.ConfigEntries[i].ConfigName < topic.ConfigEntries[j].ConfigName
+	})
+
+	return k.conn.CreateTopics(topic)
 }
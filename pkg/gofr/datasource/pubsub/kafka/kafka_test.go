@@ -5,10 +5,14 @@ import (
 	"errors"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/segmentio/kafka-go"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.uber.org/mock/gomock"
 
 	"gofr.dev/pkg/gofr/datasource/pubsub"
@@ -47,6 +51,50 @@ func TestValidateConfigs(t *testing.T) {
 			config:   Config{Broker: "kafkabroker", BatchSize: 1, BatchBytes: 1, BatchTimeout: 0},
 			expected: errBatchTimeout,
 		},
+		{
+			name:     "Earliest OffSet",
+			config:   Config{Broker: "kafkabroker", BatchSize: 1, BatchBytes: 1, BatchTimeout: 1, OffSet: OffsetEarliest},
+			expected: nil,
+		},
+		{
+			name:     "Invalid OffSet",
+			config:   Config{Broker: "kafkabroker", BatchSize: 1, BatchBytes: 1, BatchTimeout: 1, OffSet: 10},
+			expected: errOffset,
+		},
+		{
+			name: "Read Committed IsolationLevel",
+			config: Config{Broker: "kafkabroker", BatchSize: 1, BatchBytes: 1, BatchTimeout: 1,
+				IsolationLevel: IsolationReadCommitted},
+			expected: nil,
+		},
+		{
+			name:     "Invalid IsolationLevel",
+			config:   Config{Broker: "kafkabroker", BatchSize: 1, BatchBytes: 1, BatchTimeout: 1, IsolationLevel: "serializable"},
+			expected: errIsolationLevel,
+		},
+		{
+			name: "SASL Config",
+			config: Config{Broker: "kafkabroker", BatchSize: 1, BatchBytes: 1, BatchTimeout: 1,
+				SecurityProtocol: "sasl_ssl", SASLMechanism: "scram-sha-512", SASLUser: "user", SASLPassword: "secret"},
+			expected: nil,
+		},
+		{
+			name:     "Invalid SecurityProtocol",
+			config:   Config{Broker: "kafkabroker", BatchSize: 1, BatchBytes: 1, BatchTimeout: 1, SecurityProtocol: "SSH"},
+			expected: errSecurityProtocol,
+		},
+		{
+			name: "Invalid SASLMechanism",
+			config: Config{Broker: "kafkabroker", BatchSize: 1, BatchBytes: 1, BatchTimeout: 1,
+				SecurityProtocol: SecurityProtocolSASLPlaintext, SASLMechanism: "GSSAPI"},
+			expected: errSASLMechanism,
+		},
+		{
+			name: "Missing SASL Credentials",
+			config: Config{Broker: "kafkabroker", BatchSize: 1, BatchBytes: 1, BatchTimeout: 1,
+				SecurityProtocol: SecurityProtocolSASLPlaintext, SASLMechanism: SASLMechanismPlain, SASLUser: "user"},
+			expected: errSASLCredentials,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -149,6 +197,69 @@ func TestKafkaClient_Publish(t *testing.T) {
 	assert.Contains(t, logs, "test")
 }
 
+func TestKafkaClient_PublishMessage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWriter := NewMockWriter(ctrl)
+	mockMetrics := NewMockMetrics(ctrl)
+
+	ctx := context.TODO()
+	sentAt := time.Date(2024, 9, 1, 10, 0, 0, 0, time.UTC)
+	k := &kafkaClient{writer: mockWriter, logger: logging.NewMockLogger(logging.DEBUG), metrics: mockMetrics}
+
+	mockMetrics.EXPECT().IncrementCounter(gomock.Any(), "app_pubsub_publish_total_count", "topic", "test")
+	mockWriter.EXPECT().WriteMessages(gomock.Any(), kafka.Message{
+		Topic:   "test",
+		Key:     []byte("order-123"),
+		Value:   []byte(`hello`),
+		Headers: []kafka.Header{{Key: "Order-Id", Value: []byte("123")}},
+		Time:    sentAt,
+	}).Return(nil)
+	mockMetrics.EXPECT().IncrementCounter(gomock.Any(), "app_pubsub_publish_success_count", "topic", "test")
+
+	err := k.PublishMessage(ctx, "test", &pubsub.PublishMessage{
+		Key:       "order-123",
+		Value:     []byte(`hello`),
+		Headers:   map[string]string{"Order-Id": "123"},
+		Timestamp: sentAt,
+	})
+
+	require.NoError(t, err)
+}
+
+func TestKafkaClient_PublishTraceContext(t *testing.T) {
+	propagator := otel.GetTextMapPropagator()
+	defer otel.SetTextMapPropagator(propagator)
+
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWriter := NewMockWriter(ctrl)
+	mockMetrics := NewMockMetrics(ctrl)
+
+	ctx, span := sdktrace.NewTracerProvider().Tracer("test").Start(context.Background(), "handler")
+	defer span.End()
+
+	k := &kafkaClient{writer: mockWriter, logger: logging.NewMockLogger(logging.DEBUG), metrics: mockMetrics}
+
+	mockMetrics.EXPECT().IncrementCounter(gomock.Any(), "app_pubsub_publish_total_count", "topic", "test")
+	mockWriter.EXPECT().WriteMessages(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, msgs ...kafka.Message) error {
+		require.Len(t, msgs[0].Headers, 1)
+		assert.Equal(t, "traceparent", msgs[0].Headers[0].Key)
+		assert.Contains(t, string(msgs[0].Headers[0].Value), span.SpanContext().TraceID().String())
+
+		return nil
+	})
+	mockMetrics.EXPECT().IncrementCounter(gomock.Any(), "app_pubsub_publish_success_count", "topic", "test")
+
+	err := k.Publish(ctx, "test", []byte(`hello`))
+
+	require.NoError(t, err)
+}
+
 func TestKafkaClient_SubscribeSuccess(t *testing.T) {
 	var (
 		msg *pubsub.Message
@@ -178,12 +289,16 @@ func TestKafkaClient_SubscribeSuccess(t *testing.T) {
 	}
 
 	expMessage := pubsub.Message{
-		Value: []byte(`hello`),
-		Topic: "test",
+		Value:     []byte(`hello`),
+		Topic:     "test",
+		Key:       "order-123",
+		Headers:   map[string]string{"Order-Id": "123"},
+		Partition: "3",
 	}
 
 	mockReader.EXPECT().ReadMessage(gomock.Any()).
-		Return(kafka.Message{Value: []byte(`hello`), Topic: "test"}, nil)
+		Return(kafka.Message{Value: []byte(`hello`), Topic: "test", Key: []byte("order-123"), Partition: 3,
+			Headers: []kafka.Header{{Key: "Order-Id", Value: []byte("123")}}}, nil)
 	mockMetrics.EXPECT().IncrementCounter(gomock.Any(), "app_pubsub_subscribe_total_count", "topic", "test",
 		"consumer_group", gomock.Any())
 	mockMetrics.EXPECT().IncrementCounter(gomock.Any(), "app_pubsub_subscribe_success_count", "topic", "test",
@@ -200,12 +315,48 @@ func TestKafkaClient_SubscribeSuccess(t *testing.T) {
 	assert.NotNil(t, msg.Context())
 	assert.Equal(t, expMessage.Value, msg.Value)
 	assert.Equal(t, expMessage.Topic, msg.Topic)
+	assert.Equal(t, expMessage.Key, msg.Key)
+	assert.Equal(t, expMessage.Headers, msg.Headers)
+	assert.Equal(t, expMessage.Partition, msg.Partition)
 	assert.Contains(t, logs, "KAFKA")
 	assert.Contains(t, logs, "hello")
 	assert.Contains(t, logs, "kafkabroker")
 	assert.Contains(t, logs, "test")
 }
 
+// gaugeMetrics are metrics which set gauges as well, as the metrics of the container do.
+type gaugeMetrics struct {
+	*MockMetrics
+	*Mockgauge
+}
+
+func TestKafkaClient_SubscribeRecordsLag(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	mockReader := NewMockReader(ctrl)
+	mockMetrics, mockGauge := NewMockMetrics(ctrl), NewMockgauge(ctrl)
+	k := &kafkaClient{
+		reader:  map[string]Reader{"test": mockReader},
+		logger:  logging.NewMockLogger(logging.INFO),
+		config:  Config{ConsumerGroupID: "consumer", Broker: "kafkabroker"},
+		mu:      &sync.RWMutex{},
+		metrics: gaugeMetrics{MockMetrics: mockMetrics, Mockgauge: mockGauge},
+	}
+
+	mockReader.EXPECT().ReadMessage(gomock.Any()).
+		Return(kafka.Message{Value: []byte(`hello`), Topic: "test", Partition: 2, Offset: 40, HighWaterMark: 50}, nil)
+	mockMetrics.EXPECT().IncrementCounter(gomock.Any(), "app_pubsub_subscribe_total_count", "topic", "test",
+		"consumer_group", "consumer")
+	mockMetrics.EXPECT().IncrementCounter(gomock.Any(), "app_pubsub_subscribe_success_count", "topic", "test",
+		"consumer_group", "consumer")
+	mockGauge.EXPECT().SetGauge("app_pubsub_consumer_lag", float64(9), "topic", "test", "partition", "2",
+		"consumer_group", "consumer")
+
+	_, err := k.Subscribe(context.Background(), "test")
+
+	require.NoError(t, err)
+}
+
 func TestKafkaClient_Subscribe_ErrConsumerGroupID(t *testing.T) {
 	k := &kafkaClient{
 		dialer: &kafka.Dialer{},
@@ -451,3 +602,50 @@ func TestKafkaClient_CreateTopic(t *testing.T) {
 		assert.Equal(t, tc.err, err)
 	}
 }
+
+func TestKafkaClient_CreateTopicWithConfig(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	mockClient := NewMockConnection(ctrl)
+
+	client := kafkaClient{
+		conn: mockClient,
+	}
+
+	mockClient.EXPECT().CreateTopics(kafka.TopicConfig{
+		Topic:             "orders",
+		NumPartitions:     6,
+		ReplicationFactor: 3,
+		ConfigEntries: []kafka.ConfigEntry{
+			{ConfigName: "cleanup.policy", ConfigValue: "compact"},
+			{ConfigName: "retention.ms", ConfigValue: "86400000"},
+		},
+	}).Return(nil)
+
+	err := client.CreateTopicWithConfig(context.Background(), "orders", pubsub.TopicConfig{
+		Partitions:        6,
+		ReplicationFactor: 3,
+		Configs:           map[string]string{"retention.ms": "86400000", "cleanup.policy": "compact"},
+	})
+
+	require.NoError(t, err)
+
+	mockClient.EXPECT().CreateTopics(kafka.TopicConfig{Topic: "test", NumPartitions: 1, ReplicationFactor: 1}).Return(nil)
+
+	require.NoError(t, client.CreateTopic(context.Background(), "test"))
+}
+
+func TestConfig_Brokers(t *testing.T) {
+	conf := Config{Broker: "kafka-1:9092, kafka-2:9092,kafka-3:9092"}
+
+	assert.Equal(t, []string{"kafka-1:9092", "kafka-2:9092", "kafka-3:9092"}, conf.brokers())
+}
+
+func TestKafkaClient_BatchConfig(t *testing.T) {
+	k := &kafkaClient{config: Config{BatchSize: 10, BatchTimeout: 300, SubscribeBatchWait: 2 * time.Second}}
+
+	size, wait := k.BatchConfig()
+
+	assert.Equal(t, 10, size)
+	assert.Equal(t, 2*time.Second, wait)
+}
@@ -5,3 +5,9 @@ import "context"
 type Metrics interface {
 	IncrementCounter(ctx context.Context, name string, labels ...string)
 }
+
+// gauge is implemented by the metrics which can set gauges, as the metrics of the container do. The consumer lag is
+// only recorded with such metrics, so that the Metrics passed to New are not required to set gauges.
+type gauge interface {
+	SetGauge(name string, value float64, labels ...string)
+}
@@ -55,3 +55,43 @@ func (mr *MockMetricsMockRecorder) IncrementCounter(ctx, name any, labels ...any
 	varargs := append([]any{ctx, name}, labels...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementCounter", reflect.TypeOf((*MockMetrics)(nil).IncrementCounter), varargs...)
 }
+
+// Mockgauge is a mock of gauge interface.
+type Mockgauge struct {
+	ctrl     *gomock.Controller
+	recorder *MockgaugeMockRecorder
+}
+
+// MockgaugeMockRecorder is the mock recorder for Mockgauge.
+type MockgaugeMockRecorder struct {
+	mock *Mockgauge
+}
+
+// NewMockgauge creates a new mock instance.
+func NewMockgauge(ctrl *gomock.Controller) *Mockgauge {
+	mock := &Mockgauge{ctrl: ctrl}
+	mock.recorder = &MockgaugeMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockgauge) EXPECT() *MockgaugeMockRecorder {
+	return m.recorder
+}
+
+// SetGauge mocks base method.
+func (m *Mockgauge) SetGauge(name string, value float64, labels ...string) {
+	m.ctrl.T.Helper()
+	varargs := []any{name, value}
+	for _, a := range labels {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "SetGauge", varargs...)
+}
+
+// SetGauge indicates an expected call of SetGauge.
+func (mr *MockgaugeMockRecorder) SetGauge(name, value any, labels ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{name, value}, labels...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetGauge", reflect.TypeOf((*Mockgauge)(nil).SetGauge), varargs...)
+}
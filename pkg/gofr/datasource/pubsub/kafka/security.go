@@ -0,0 +1,149 @@
+package kafka
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl"
+	"github.com/segmentio/kafka-go/sasl/plain"
+	"github.com/segmentio/kafka-go/sasl/scram"
+)
+
+const (
+	SecurityProtocolPlaintext     = "PLAINTEXT"
+	SecurityProtocolSSL           = "SSL"
+	SecurityProtocolSASLPlaintext = "SASL_PLAINTEXT"
+	SecurityProtocolSASLSSL       = "SASL_SSL"
+
+	SASLMechanismPlain       = "PLAIN"
+	SASLMechanismSCRAMSHA256 = "SCRAM-SHA-256"
+	SASLMechanismSCRAMSHA512 = "SCRAM-SHA-512"
+
+	defaultDialTimeout = 10 * time.Second
+)
+
+var (
+	errSecurityProtocol = errors.New("KAFKA_SECURITY_PROTOCOL must be PLAINTEXT, SSL, SASL_PLAINTEXT or SASL_SSL")
+	errSASLMechanism    = errors.New("KAFKA_SASL_MECHANISM must be PLAIN, SCRAM-SHA-256 or SCRAM-SHA-512")
+	errSASLCredentials  = errors.New("KAFKA_SASL_USERNAME and KAFKA_SASL_PASSWORD must be provided for SASL")
+	errTLSKeyPair       = errors.New("KAFKA_TLS_CERT_FILE and KAFKA_TLS_KEY_FILE must be provided together")
+	errCACert           = errors.New("no certificates found in KAFKA_TLS_CA_CERT_FILE")
+)
+
+// TLSConfig configures the TLS connections to the brokers, with the SSL and SASL_SSL security protocols.
+type TLSConfig struct {
+	// CertFile and KeyFile are the client certificate and its key, for the brokers authenticating the clients.
+	CertFile string
+	KeyFile  string
+	// CACertFile is the certificate of the CA of the brokers, the CAs of the system are used when it is not set.
+	CACertFile         string
+	InsecureSkipVerify bool
+}
+
+func validateSecurity(conf *Config) error {
+	switch strings.ToUpper(conf.SecurityProtocol) {
+	case "", SecurityProtocolPlaintext, SecurityProtocolSSL:
+		return nil
+	case SecurityProtocolSASLPlaintext, SecurityProtocolSASLSSL:
+	default:
+		return errSecurityProtocol
+	}
+
+	switch strings.ToUpper(conf.SASLMechanism) {
+	case SASLMechanismPlain, SASLMechanismSCRAMSHA256, SASLMechanismSCRAMSHA512:
+	default:
+		return errSASLMechanism
+	}
+
+	if conf.SASLUser == "" || conf.SASLPassword == "" {
+		return errSASLCredentials
+	}
+
+	return nil
+}
+
+// newDialer creates the dialer of the connections to the brokers, along with the TLS and SASL of the security protocol.
+// The writer and the readers use the TLS and SASL of the dialer as well.
+func newDialer(conf *Config) (*kafka.Dialer, error) {
+	dialer := &kafka.Dialer{
+		Timeout:   defaultDialTimeout,
+		DualStack: true,
+	}
+
+	protocol := strings.ToUpper(conf.SecurityProtocol)
+
+	if protocol == SecurityProtocolSSL || protocol == SecurityProtocolSASLSSL {
+		tlsConfig, err := newTLSConfig(conf.TLS)
+		if err != nil {
+			return nil, err
+		}
+
+		dialer.TLS = tlsConfig
+	}
+
+	if protocol == SecurityProtocolSASLPlaintext || protocol == SecurityProtocolSASLSSL {
+		mechanism, err := newSASLMechanism(conf)
+		if err != nil {
+			return nil, err
+		}
+
+		dialer.SASLMechanism = mechanism
+	}
+
+	return dialer, nil
+}
+
+func newSASLMechanism(conf *Config) (sasl.Mechanism, error) {
+	switch strings.ToUpper(conf.SASLMechanism) {
+	case SASLMechanismPlain:
+		return plain.Mechanism{Username: conf.SASLUser, Password: conf.SASLPassword}, nil
+	case SASLMechanismSCRAMSHA256:
+		return scram.Mechanism(scram.SHA256, conf.SASLUser, conf.SASLPassword)
+	case SASLMechanismSCRAMSHA512:
+		return scram.Mechanism(scram.SHA512, conf.SASLUser, conf.SASLPassword)
+	default:
+		return nil, errSASLMechanism
+	}
+}
+
+func newTLSConfig(conf TLSConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: conf.InsecureSkipVerify, //nolint:gosec // skipping the verification is opted in by the config
+	}
+
+	if (conf.CertFile == "") != (conf.KeyFile == "") {
+		return nil, errTLSKeyPair
+	}
+
+	if conf.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(conf.CertFile, conf.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the client certificate: %w", err)
+		}
+
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	if conf.CACertFile != "" {
+		caCert, err := os.ReadFile(conf.CACertFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the CA certificate: %w", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caCert) {
+			return nil, errCACert
+		}
+
+		tlsConfig.RootCAs = pool
+	}
+
+	return tlsConfig, nil
+}
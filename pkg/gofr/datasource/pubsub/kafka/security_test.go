@@ -0,0 +1,131 @@
+package kafka
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go/sasl/plain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeTestCertificate writes a self-signed certificate and its key to the directory.
+func writeTestCertificate(t *testing.T, dir string) (certFile, keyFile string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "kafka"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+		IsCA:         true,
+	}
+
+	cert, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyBytes, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile, keyFile = filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert}), 0600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyBytes}), 0600))
+
+	return certFile, keyFile
+}
+
+func TestNewDialer(t *testing.T) {
+	certFile, keyFile := writeTestCertificate(t, t.TempDir())
+
+	testCases := []struct {
+		desc      string
+		config    Config
+		expTLS    bool
+		expSASL   string
+		expCerts  int
+		expRootCA bool
+	}{
+		{"plaintext by default", Config{}, false, "", 0, false},
+		{"ssl with the system CAs", Config{SecurityProtocol: "SSL"}, true, "", 0, false},
+		{"ssl with client certificate and CA", Config{SecurityProtocol: SecurityProtocolSSL,
+			TLS: TLSConfig{CertFile: certFile, KeyFile: keyFile, CACertFile: certFile}}, true, "", 1, true},
+		{"sasl plain", Config{SecurityProtocol: SecurityProtocolSASLPlaintext, SASLMechanism: SASLMechanismPlain,
+			SASLUser: "user", SASLPassword: "secret"}, false, "PLAIN", 0, false},
+		{"sasl scram-sha-256 over ssl", Config{SecurityProtocol: SecurityProtocolSASLSSL,
+			SASLMechanism: SASLMechanismSCRAMSHA256, SASLUser: "user", SASLPassword: "secret"}, true, "SCRAM-SHA-256", 0, false},
+		{"sasl scram-sha-512", Config{SecurityProtocol: SecurityProtocolSASLPlaintext, SASLMechanism: "scram-sha-512",
+			SASLUser: "user", SASLPassword: "secret"}, false, "SCRAM-SHA-512", 0, false},
+	}
+
+	for i, tc := range testCases {
+		dialer, err := newDialer(&tc.config)
+		require.NoError(t, err, "TEST[%d], Failed.\n%s", i, tc.desc)
+
+		assert.Equal(t, tc.expTLS, dialer.TLS != nil, "TEST[%d], Failed.\n%s", i, tc.desc)
+
+		if dialer.TLS != nil {
+			assert.Len(t, dialer.TLS.Certificates, tc.expCerts, "TEST[%d], Failed.\n%s", i, tc.desc)
+			assert.Equal(t, tc.expRootCA, dialer.TLS.RootCAs != nil, "TEST[%d], Failed.\n%s", i, tc.desc)
+		}
+
+		if tc.expSASL == "" {
+			assert.Nil(t, dialer.SASLMechanism, "TEST[%d], Failed.\n%s", i, tc.desc)
+
+			continue
+		}
+
+		require.NotNil(t, dialer.SASLMechanism, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.expSASL, dialer.SASLMechanism.Name(), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestNewDialer_PlainCredentials(t *testing.T) {
+	dialer, err := newDialer(&Config{SecurityProtocol: SecurityProtocolSASLPlaintext, SASLMechanism: SASLMechanismPlain,
+		SASLUser: "user", SASLPassword: "secret"})
+	require.NoError(t, err)
+
+	assert.Equal(t, plain.Mechanism{Username: "user", Password: "secret"}, dialer.SASLMechanism)
+}
+
+func TestNewTLSConfig_Errors(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeTestCertificate(t, dir)
+
+	invalidFile := filepath.Join(dir, "invalid.pem")
+	require.NoError(t, os.WriteFile(invalidFile, []byte("not a certificate"), 0600))
+
+	testCases := []struct {
+		desc   string
+		config TLSConfig
+		expErr error
+	}{
+		{"certificate without key", TLSConfig{CertFile: certFile}, errTLSKeyPair},
+		{"key without certificate", TLSConfig{KeyFile: keyFile}, errTLSKeyPair},
+		{"invalid CA certificate", TLSConfig{CACertFile: invalidFile}, errCACert},
+	}
+
+	for i, tc := range testCases {
+		_, err := newTLSConfig(tc.config)
+
+		require.ErrorIs(t, err, tc.expErr, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+
+	_, err := newTLSConfig(TLSConfig{CertFile: certFile, KeyFile: invalidFile})
+	require.ErrorContains(t, err, "failed to load the client certificate")
+
+	_, err = newTLSConfig(TLSConfig{CACertFile: filepath.Join(dir, "missing.pem")})
+	require.ErrorContains(t, err, "failed to read the CA certificate")
+}
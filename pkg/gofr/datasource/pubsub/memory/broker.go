@@ -0,0 +1,206 @@
+package memory
+
+import (
+	"sync"
+	"time"
+)
+
+// Broker holds the topics and the consumer groups of the clients created with it, so that the clients of different
+// consumer groups receive every message of a topic, while the clients of the same group share them.
+type Broker struct {
+	mu     sync.Mutex
+	topics map[string]*topic
+}
+
+// NewBroker creates an empty broker.
+func NewBroker() *Broker {
+	return &Broker{topics: make(map[string]*topic)}
+}
+
+type record struct {
+	key       string
+	value     []byte
+	headers   map[string]string
+	timestamp time.Time
+}
+
+type topic struct {
+	// records are the messages from offset base, the earlier ones are dropped once every consumer group has
+	// committed them.
+	records []record
+	base    int
+	groups  map[string]*group
+	// notify is closed, and replaced, whenever a message is available for the consumer groups of the topic.
+	notify chan struct{}
+	// deleted is set once the topic is deleted, the messages delivered before are then neither committed nor nacked.
+	deleted bool
+}
+
+// group is the progress of a consumer group on a topic, the offsets count the messages published to the topic.
+type group struct {
+	next        int
+	redelivered []int
+	unacked     map[int]struct{}
+}
+
+// getTopic returns the topic, creating it if it does not exist, b.mu has to be held by the caller.
+func (b *Broker) getTopic(name string) *topic {
+	t, ok := b.topics[name]
+	if !ok {
+		t = &topic{groups: make(map[string]*group), notify: make(chan struct{})}
+		b.topics[name] = t
+	}
+
+	return t
+}
+
+// deleteTopic deletes the topic along with its messages, waking up the subscribers waiting on it.
+func (b *Broker) deleteTopic(name string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if t, ok := b.topics[name]; ok {
+		t.deleted = true
+		close(t.notify)
+		delete(b.topics, name)
+	}
+}
+
+// publish appends the message to the topic, waking up the subscribers waiting on it.
+func (b *Broker) publish(name string, r record) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	t := b.getTopic(name)
+	t.records = append(t.records, r)
+	t.wake()
+}
+
+// next returns the next message of the topic for the consumer group, along with its committer. When there is none, it
+// returns a channel which is closed once a message is available.
+func (b *Broker) next(name, groupName string) (record, *committer, <-chan struct{}) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	t := b.getTopic(name)
+
+	g, ok := t.groups[groupName]
+	if !ok {
+		// a new consumer group starts from the earliest message retained by the topic
+		g = &group{next: t.base, unacked: make(map[int]struct{})}
+		t.groups[groupName] = g
+	}
+
+	offset := -1
+
+	switch {
+	case len(g.redelivered) > 0:
+		offset, g.redelivered = g.redelivered[0], g.redelivered[1:]
+	case g.next < t.end():
+		offset = g.next
+		g.next++
+	default:
+		return record{}, nil, t.notify
+	}
+
+	g.unacked[offset] = struct{}{}
+
+	return t.records[offset-t.base], &committer{broker: b, topic: t, group: g, offset: offset}, nil
+}
+
+// commit acknowledges the message of the consumer group, dropping the messages committed by every consumer group.
+func (b *Broker) commit(t *topic, g *group, offset int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if t.deleted {
+		return
+	}
+
+	delete(g.unacked, offset)
+
+	t.trim()
+}
+
+// end is the offset of the next message published to the topic.
+func (t *topic) end() int {
+	return t.base + len(t.records)
+}
+
+// trim drops the messages before the earliest message which is not committed by a consumer group, b.mu has to be
+// held by the caller. The messages are retained until a consumer group subscribes to the topic.
+func (t *topic) trim() {
+	if len(t.groups) == 0 {
+		return
+	}
+
+	low := t.end()
+
+	for _, g := range t.groups {
+		low = min(low, g.next)
+
+		for _, offset := range g.redelivered {
+			low = min(low, offset)
+		}
+
+		for offset := range g.unacked {
+			low = min(low, offset)
+		}
+	}
+
+	if dropped := low - t.base; dropped > 0 {
+		// the dropped messages are cleared, so that their values are released before the records are reallocated
+		clear(t.records[:dropped])
+
+		t.records = t.records[dropped:]
+		t.base = low
+	}
+}
+
+// nack hands the message back to the consumer group for an immediate redelivery.
+func (b *Broker) nack(t *topic, g *group, offset int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := g.unacked[offset]; !ok || t.deleted {
+		return
+	}
+
+	delete(g.unacked, offset)
+
+	g.redelivered = append(g.redelivered, offset)
+	t.wake()
+}
+
+// wake wakes up the subscribers waiting on the topic, b.mu has to be held by the caller.
+func (t *topic) wake() {
+	close(t.notify)
+	t.notify = make(chan struct{})
+}
+
+// stats returns the number of messages retained by the topics, and the messages pending and unacknowledged for each
+// of their consumer groups.
+func (b *Broker) stats() map[string]any {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	topics := make(map[string]any, len(b.topics))
+
+	for name, t := range b.topics {
+		groups := make(map[string]any, len(t.groups))
+
+		for groupName, g := range t.groups {
+			groups[groupName] = map[string]any{
+				"pending":        t.end() - g.next + len(g.redelivered),
+				"unacknowledged": len(g.unacked),
+			}
+		}
+
+		topics[name] = map[string]any{
+			"messages":        len(t.records),
+			"consumer_groups": groups,
+		}
+	}
+
+	return topics
+}
@@ -0,0 +1,247 @@
+// Package memory provides an in-process pub/sub client, which runs the publishers and subscribers of an application
+// without a message broker, e.g. locally or in tests. The messages of a topic are delivered to every consumer group,
+// and the messages which are nacked are redelivered to their group. A topic retains its messages until they are
+// committed by every consumer group subscribing to it.
+package memory
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel"
+
+	"gofr.dev/pkg/gofr/datasource"
+	"gofr.dev/pkg/gofr/datasource/pubsub"
+)
+
+const defaultConsumerGroup = "default"
+
+var (
+	errClientClosed = errors.New("memory pubsub client is closed")
+	errEmptyTopic   = errors.New("topic name cannot be empty")
+)
+
+type Config struct {
+	// ConsumerGroup is the consumer group of the subscriptions, "default" if not set.
+	ConsumerGroup string
+	// Broker is shared by the clients publishing and subscribing to the same topics, a new one is created if not set.
+	Broker *Broker
+}
+
+// Client is a pub/sub client whose topics are held in the memory of the application.
+type Client struct {
+	broker  *Broker
+	group   string
+	closed  *atomic.Bool
+	logger  pubsub.Logger
+	metrics Metrics
+
+	// done is closed by Close, waking up the subscriptions waiting for a message.
+	done      chan struct{}
+	closeOnce *sync.Once
+}
+
+// New creates a client for the consumer group of the config, metrics can be nil.
+func New(conf Config, logger pubsub.Logger, metrics Metrics) *Client {
+	if conf.ConsumerGroup == "" {
+		conf.ConsumerGroup = defaultConsumerGroup
+	}
+
+	if conf.Broker == nil {
+		conf.Broker = NewBroker()
+	}
+
+	logger.Logf("using in-memory pubsub with consumer group '%s'", conf.ConsumerGroup)
+
+	return &Client{
+		broker:    conf.Broker,
+		group:     conf.ConsumerGroup,
+		closed:    &atomic.Bool{},
+		logger:    logger,
+		metrics:   metrics,
+		done:      make(chan struct{}),
+		closeOnce: &sync.Once{},
+	}
+}
+
+// WithConsumerGroup returns a client subscribing to the topics of the same broker with another consumer group.
+func (c *Client) WithConsumerGroup(group string) *Client {
+	client := *c
+	client.group = group
+	client.closed = &atomic.Bool{}
+	client.done = make(chan struct{})
+	client.closeOnce = &sync.Once{}
+
+	return &client
+}
+
+func (c *Client) Publish(ctx context.Context, topic string, message []byte) error {
+	return c.PublishMessage(ctx, topic, &pubsub.PublishMessage{Value: message})
+}
+
+// PublishMessage publishes the message with its key, headers and timestamp. The trace context of ctx is published
+// along with the headers.
+func (c *Client) PublishMessage(ctx context.Context, topic string, message *pubsub.PublishMessage) error {
+	ctx, span := otel.GetTracerProvider().Tracer("gofr").Start(ctx, "memory-publish")
+	defer span.End()
+
+	c.incrementCounter(ctx, "app_pubsub_publish_total_count", "topic", topic)
+
+	switch {
+	case c.closed.Load():
+		return errClientClosed
+	case topic == "":
+		return errEmptyTopic
+	}
+
+	start := time.Now()
+
+	r := record{
+		key:       message.Key,
+		value:     message.Value,
+		headers:   pubsub.InjectTraceContext(ctx, message.Headers),
+		timestamp: message.Timestamp,
+	}
+
+	if r.timestamp.IsZero() {
+		r.timestamp = start
+	}
+
+	c.broker.publish(topic, r)
+
+	c.logger.Debug(&pubsub.Log{
+		Mode:          "PUB",
+		CorrelationID: span.SpanContext().TraceID().String(),
+		MessageValue:  string(message.Value),
+		Topic:         topic,
+		PubSubBackend: "MEMORY",
+		Time:          time.Since(start).Microseconds(),
+	})
+
+	c.incrementCounter(ctx, "app_pubsub_publish_success_count", "topic", topic)
+
+	return nil
+}
+
+// Subscribe returns the next message of the topic for the consumer group of the client, waiting for one to be
+// published if there is none. It returns a nil message once ctx is done or the client is closed.
+func (c *Client) Subscribe(ctx context.Context, topic string) (*pubsub.Message, error) {
+	if c.closed.Load() {
+		return nil, errClientClosed
+	}
+
+	ctx, span := otel.GetTracerProvider().Tracer("gofr").Start(ctx, "memory-subscribe")
+	defer span.End()
+
+	c.incrementCounter(ctx, "app_pubsub_subscribe_total_count", "topic", topic, "consumer_group", c.group)
+
+	start := time.Now()
+
+	for {
+		r, msgCommitter, wait := c.broker.next(topic, c.group)
+		if wait == nil {
+			msg := pubsub.NewMessage(ctx)
+			msg.Topic = topic
+			msg.Key = r.key
+			msg.Value = r.value
+			msg.Headers = r.headers
+			msg.Timestamp = r.timestamp
+			msg.Committer = msgCommitter
+
+			c.logger.Debug(&pubsub.Log{
+				Mode:          "SUB",
+				CorrelationID: span.SpanContext().TraceID().String(),
+				MessageValue:  string(r.value),
+				Topic:         topic,
+				PubSubBackend: "MEMORY",
+				Time:          time.Since(start).Microseconds(),
+			})
+
+			c.incrementCounter(ctx, "app_pubsub_subscribe_success_count", "topic", topic, "consumer_group", c.group)
+
+			return msg, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, nil
+		case <-c.done:
+			return nil, nil
+		case <-wait:
+		}
+	}
+}
+
+// Messages returns the values of the messages retained by the topic, in the order they are published. These are all
+// the messages published to it, except the ones committed by every consumer group subscribing to it.
+func (c *Client) Messages(topic string) [][]byte {
+	c.broker.mu.Lock()
+	defer c.broker.mu.Unlock()
+
+	t, ok := c.broker.topics[topic]
+	if !ok {
+		return nil
+	}
+
+	values := make([][]byte, 0, len(t.records))
+	for _, r := range t.records {
+		values = append(values, r.value)
+	}
+
+	return values
+}
+
+func (c *Client) Health() datasource.Health {
+	health := datasource.Health{
+		Status: datasource.StatusUp,
+		Details: map[string]any{
+			"backend":        "MEMORY",
+			"consumer_group": c.group,
+			"topics":         c.broker.stats(),
+		},
+	}
+
+	if c.closed.Load() {
+		health.Status = datasource.StatusDown
+	}
+
+	return health
+}
+
+// CreateTopic creates the topic if it does not exist, the topics are also created when they are first used.
+func (c *Client) CreateTopic(_ context.Context, name string) error {
+	if name == "" {
+		return errEmptyTopic
+	}
+
+	c.broker.mu.Lock()
+	defer c.broker.mu.Unlock()
+
+	c.broker.getTopic(name)
+
+	return nil
+}
+
+// DeleteTopic deletes the topic along with its messages and the progress of its consumer groups.
+func (c *Client) DeleteTopic(_ context.Context, name string) error {
+	c.broker.deleteTopic(name)
+
+	return nil
+}
+
+// Close closes the client, waking up its subscriptions. The topics of its broker are retained for the other clients.
+func (c *Client) Close() error {
+	c.closed.Store(true)
+	c.closeOnce.Do(func() { close(c.done) })
+
+	return nil
+}
+
+func (c *Client) incrementCounter(ctx context.Context, name string, labels ...string) {
+	if c.metrics != nil {
+		c.metrics.IncrementCounter(ctx, name, labels...)
+	}
+}
@@ -0,0 +1,245 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gofr.dev/pkg/gofr/datasource"
+	"gofr.dev/pkg/gofr/datasource/pubsub"
+	"gofr.dev/pkg/gofr/logging"
+)
+
+func newTestClient() *Client {
+	return New(Config{}, logging.NewMockLogger(logging.ERROR), nil)
+}
+
+func subscribe(t *testing.T, c *Client, topic string) *pubsub.Message {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	msg, err := c.Subscribe(ctx, topic)
+	require.NoError(t, err)
+	require.NotNil(t, msg, "no message received on topic %s", topic)
+
+	return msg
+}
+
+func TestClient_PublishSubscribe(t *testing.T) {
+	c := newTestClient()
+	timestamp := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	err := c.PublishMessage(context.Background(), "orders", &pubsub.PublishMessage{
+		Key:       "order-1",
+		Value:     []byte(`{"orderId":"1"}`),
+		Headers:   map[string]string{"source": "checkout"},
+		Timestamp: timestamp,
+	})
+	require.NoError(t, err)
+
+	msg := subscribe(t, c, "orders")
+
+	assert.Equal(t, "orders", msg.Topic)
+	assert.Equal(t, "order-1", msg.Key)
+	assert.JSONEq(t, `{"orderId":"1"}`, string(msg.Value))
+	assert.Equal(t, "checkout", msg.Headers["source"])
+	assert.Equal(t, timestamp, msg.Timestamp)
+}
+
+func TestClient_SubscribeWaitsForMessage(t *testing.T) {
+	c := newTestClient()
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+
+		_ = c.Publish(context.Background(), "orders", []byte("1"))
+	}()
+
+	msg := subscribe(t, c, "orders")
+
+	assert.Equal(t, []byte("1"), msg.Value)
+}
+
+func TestClient_SubscribeContextDone(t *testing.T) {
+	c := newTestClient()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	msg, err := c.Subscribe(ctx, "orders")
+
+	require.NoError(t, err)
+	assert.Nil(t, msg)
+}
+
+func TestClient_ConsumerGroups(t *testing.T) {
+	orders := newTestClient()
+	billing := orders.WithConsumerGroup("billing")
+	otherOrders := orders.WithConsumerGroup(defaultConsumerGroup)
+
+	require.NoError(t, orders.Publish(context.Background(), "orders", []byte("1")))
+	require.NoError(t, orders.Publish(context.Background(), "orders", []byte("2")))
+
+	// every consumer group gets every message, while the clients of a group share them
+	assert.Equal(t, []byte("1"), subscribe(t, orders, "orders").Value)
+	assert.Equal(t, []byte("2"), subscribe(t, otherOrders, "orders").Value)
+	assert.Equal(t, []byte("1"), subscribe(t, billing, "orders").Value)
+	assert.Equal(t, []byte("2"), subscribe(t, billing, "orders").Value)
+}
+
+func TestClient_Nack(t *testing.T) {
+	c := newTestClient()
+
+	require.NoError(t, c.Publish(context.Background(), "orders", []byte("1")))
+	require.NoError(t, c.Publish(context.Background(), "orders", []byte("2")))
+
+	first := subscribe(t, c, "orders")
+	first.Committer.(pubsub.Nacker).Nack()
+
+	redelivered := subscribe(t, c, "orders")
+	assert.Equal(t, []byte("1"), redelivered.Value)
+
+	redelivered.Commit()
+	// the message is not redelivered again, as it is committed
+	first.Committer.(pubsub.Nacker).Nack()
+
+	assert.Equal(t, []byte("2"), subscribe(t, c, "orders").Value)
+}
+
+func TestClient_DropsMessagesCommittedByAllGroups(t *testing.T) {
+	orders := newTestClient()
+	billing := orders.WithConsumerGroup("billing")
+
+	for _, value := range []string{"1", "2", "3"} {
+		require.NoError(t, orders.Publish(context.Background(), "orders", []byte(value)))
+	}
+
+	// the messages are retained until a consumer group subscribes
+	subscribe(t, orders, "orders").Commit()
+	assert.Equal(t, [][]byte{[]byte("2"), []byte("3")}, orders.Messages("orders"))
+
+	// billing has subscribed after the first message was dropped, it starts from the earliest retained message
+	second := subscribe(t, billing, "orders")
+	assert.Equal(t, []byte("2"), second.Value)
+
+	subscribe(t, orders, "orders").Commit()
+	assert.Equal(t, [][]byte{[]byte("2"), []byte("3")}, orders.Messages("orders"), "message 2 is not committed by billing")
+
+	second.Commit()
+	assert.Equal(t, [][]byte{[]byte("3")}, orders.Messages("orders"))
+
+	// the offsets of the retained messages are kept across the drops
+	third := subscribe(t, billing, "orders")
+	assert.Equal(t, []byte("3"), third.Value)
+
+	third.Committer.(pubsub.Nacker).Nack()
+	assert.Equal(t, []byte("3"), subscribe(t, billing, "orders").Value)
+}
+
+func TestClient_CloseWakesSubscriptions(t *testing.T) {
+	c := newTestClient()
+	done := make(chan *pubsub.Message)
+
+	go func() {
+		msg, _ := c.Subscribe(context.Background(), "orders")
+		done <- msg
+	}()
+
+	require.NoError(t, c.Close())
+
+	select {
+	case msg := <-done:
+		assert.Nil(t, msg)
+	case <-time.After(time.Second):
+		t.Fatal("subscription is not woken up by Close")
+	}
+
+	require.NoError(t, c.Close())
+}
+
+func TestClient_Health(t *testing.T) {
+	c := newTestClient()
+
+	require.NoError(t, c.CreateTopic(context.Background(), "payments"))
+	require.NoError(t, c.Publish(context.Background(), "orders", []byte("1")))
+	require.NoError(t, c.Publish(context.Background(), "orders", []byte("2")))
+
+	subscribe(t, c, "orders").Commit()
+	subscribe(t, c, "orders")
+
+	expected := datasource.Health{
+		Status: datasource.StatusUp,
+		Details: map[string]any{
+			"backend":        "MEMORY",
+			"consumer_group": defaultConsumerGroup,
+			"topics": map[string]any{
+				"orders": map[string]any{
+					// the committed message is dropped
+					"messages": 1,
+					"consumer_groups": map[string]any{
+						defaultConsumerGroup: map[string]any{"pending": 0, "unacknowledged": 1},
+					},
+				},
+				"payments": map[string]any{"messages": 0, "consumer_groups": map[string]any{}},
+			},
+		},
+	}
+
+	assert.Equal(t, expected, c.Health())
+
+	require.NoError(t, c.Close())
+	assert.Equal(t, datasource.StatusDown, c.Health().Status)
+}
+
+func TestClient_DeleteTopic(t *testing.T) {
+	c := newTestClient()
+
+	require.NoError(t, c.Publish(context.Background(), "orders", []byte("1")))
+	require.NoError(t, c.DeleteTopic(context.Background(), "orders"))
+	require.NoError(t, c.DeleteTopic(context.Background(), "orders"))
+
+	assert.Nil(t, c.Messages("orders"))
+
+	require.NoError(t, c.Publish(context.Background(), "orders", []byte("2")))
+
+	assert.Equal(t, []byte("2"), subscribe(t, c, "orders").Value)
+}
+
+func TestClient_NackAfterDeleteTopic(t *testing.T) {
+	c := newTestClient()
+
+	require.NoError(t, c.Publish(context.Background(), "orders", []byte("1")))
+	require.NoError(t, c.Publish(context.Background(), "orders", []byte("2")))
+
+	nacked := subscribe(t, c, "orders")
+	committed := subscribe(t, c, "orders")
+
+	require.NoError(t, c.DeleteTopic(context.Background(), "orders"))
+
+	// the messages of the deleted topic are neither redelivered nor committed
+	nacked.Committer.(pubsub.Nacker).Nack()
+	committed.Commit()
+
+	require.NoError(t, c.Publish(context.Background(), "orders", []byte("3")))
+
+	assert.Equal(t, []byte("3"), subscribe(t, c, "orders").Value)
+}
+
+func TestClient_Errors(t *testing.T) {
+	c := newTestClient()
+
+	require.ErrorIs(t, c.Publish(context.Background(), "", []byte("1")), errEmptyTopic)
+	require.ErrorIs(t, c.CreateTopic(context.Background(), ""), errEmptyTopic)
+
+	require.NoError(t, c.Close())
+
+	require.ErrorIs(t, c.Publish(context.Background(), "orders", []byte("1")), errClientClosed)
+
+	_, err := c.Subscribe(context.Background(), "orders")
+	require.ErrorIs(t, err, errClientClosed)
+}
@@ -0,0 +1,18 @@
+package memory
+
+// committer commits, or nacks, a message for the consumer group it is delivered to.
+type committer struct {
+	broker *Broker
+	topic  *topic
+	group  *group
+	offset int
+}
+
+func (c *committer) Commit() {
+	c.broker.commit(c.topic, c.group, c.offset)
+}
+
+// Nack hands the message back to the consumer group for an immediate redelivery.
+func (c *committer) Nack() {
+	c.broker.nack(c.topic, c.group, c.offset)
+}
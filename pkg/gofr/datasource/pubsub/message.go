@@ -4,11 +4,35 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"reflect"
 	"strconv"
+	"time"
 )
 
-var errNotPointer = errors.New("input should be a pointer to a variable")
+var (
+	errNotPointer     = errors.New("input should be a pointer to a variable")
+	errHeaderNotFound = errors.New("header not found")
+)
+
+// PublishMessage is a message to publish along with the attributes supported by the backends:
+//   - Kafka publishes the key, headers and timestamp, the messages with the same key go to the same partition.
+//   - Google publishes the headers as attributes and the key as the ordering key.
+//   - NATS publishes the headers.
+//   - Event Hub publishes the headers as properties and the key as the partition key.
+//   - MQTT publishes with the QoS and Retain overrides, it has no keys or headers.
+//   - AMQP publishes the headers and timestamp, with the key as the routing key.
+type PublishMessage struct {
+	Key     string
+	Value   []byte
+	Headers map[string]string
+	// Timestamp is the time of publishing by default.
+	Timestamp time.Time
+
+	// QoS and Retain override the configs of MQTT for the message.
+	QoS    *byte
+	Retain *bool
+}
 
 type Message struct {
 	ctx context.Context
@@ -17,6 +41,17 @@ type Message struct {
 	Value    []byte
 	MetaData interface{}
 
+	// Key, Headers and Timestamp are set by the backends supporting them, as described in PublishMessage.
+	Key       string
+	Headers   map[string]string
+	Timestamp time.Time
+	// Partition is set by the backends whose topics are split into partitions, e.g. Kafka and Event Hub. The messages
+	// are ordered, and committed, within their partition.
+	Partition string
+
+	// Codec decodes the value of the message in Bind, it is set by the subscriptions of the topics with a codec.
+	Codec Codec
+
 	Committer
 }
 
@@ -32,24 +67,73 @@ func (m *Message) Context() context.Context {
 	return m.ctx
 }
 
+// Nack tells the broker that the message could not be processed, so that it is redelivered, when the backend
+// supports it.
+func (m *Message) Nack() {
+	if n, ok := m.Committer.(Nacker); ok {
+		n.Nack()
+	}
+}
+
+// Param returns the topic for "topic", and the header with the given key otherwise.
 func (m *Message) Param(p string) string {
 	if p == "topic" {
 		return m.Topic
 	}
 
-	return ""
+	return m.Header(p)
+}
+
+// Header returns the value of the header with the given key, or an empty string when it is not present.
+func (m *Message) Header(key string) string {
+	return m.Headers[key]
+}
+
+// HeaderInt returns the value of the header with the given key as an int.
+func (m *Message) HeaderInt(key string) (int, error) {
+	value, ok := m.Headers[key]
+	if !ok {
+		return 0, fmt.Errorf("%w: %v", errHeaderNotFound, key)
+	}
+
+	return strconv.Atoi(value)
+}
+
+// HeaderBool returns the value of the header with the given key as a bool.
+func (m *Message) HeaderBool(key string) (bool, error) {
+	value, ok := m.Headers[key]
+	if !ok {
+		return false, fmt.Errorf("%w: %v", errHeaderNotFound, key)
+	}
+
+	return strconv.ParseBool(value)
+}
+
+// HeaderTime returns the value of the header with the given key as a time, formatted as RFC3339.
+func (m *Message) HeaderTime(key string) (time.Time, error) {
+	value, ok := m.Headers[key]
+	if !ok {
+		return time.Time{}, fmt.Errorf("%w: %v", errHeaderNotFound, key)
+	}
+
+	return time.Parse(time.RFC3339Nano, value)
 }
 
 func (m *Message) PathParam(p string) string {
 	return m.Param(p)
 }
 
-// Bind binds the message value to the input variable. The input should be a pointer to a variable.
+// Bind binds the message value to the input variable. The input should be a pointer to a variable. The value is
+// decoded with the codec of the message when it has one.
 func (m *Message) Bind(i any) error {
 	if reflect.ValueOf(i).Kind() != reflect.Ptr {
 		return errNotPointer
 	}
 
+	if m.Codec != nil {
+		return m.Codec.Decode(m.ctx, m.Topic, m.Value, i)
+	}
+
 	switch v := i.(type) {
 	case *string:
 		return m.bindString(v)
@@ -3,6 +3,7 @@ package pubsub
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -189,10 +190,12 @@ func TestMessage_Param(t *testing.T) {
 	}{
 		{desc: "topic is fetched", input: "topic", expectedOut: "test-topic"},
 		{desc: "any other param is fetched", input: "path", expectedOut: ""},
+		{desc: "header is fetched", input: "Order-Id", expectedOut: "123"},
 	}
 
 	m := NewMessage(context.TODO())
 	m.Topic = "test-topic"
+	m.Headers = map[string]string{"Order-Id": "123"}
 
 	for _, tc := range testCases {
 		out := m.Param(tc.input)
@@ -234,3 +237,70 @@ func TestMessage_QueryParam(t *testing.T) {
 
 	assert.Nil(t, m.Params("test"))
 }
+
+type nackCommitter struct {
+	nacked bool
+}
+
+func (*nackCommitter) Commit() {}
+
+func (n *nackCommitter) Nack() {
+	n.nacked = true
+}
+
+func TestMessage_Nack(t *testing.T) {
+	committer := &nackCommitter{}
+
+	m := NewMessage(context.Background())
+	m.Committer = committer
+
+	m.Nack()
+
+	assert.True(t, committer.nacked)
+
+	// messages of backends which do not support nacks are left uncommitted
+	NewMessage(context.Background()).Nack()
+}
+
+func TestMessage_Headers(t *testing.T) {
+	m := NewMessage(context.Background())
+	m.Headers = map[string]string{
+		"count":   "3",
+		"retry":   "true",
+		"sent-at": "2024-09-01T10:00:00.5Z",
+		"invalid": "abc",
+	}
+
+	assert.Equal(t, "3", m.Header("count"))
+	assert.Empty(t, m.Header("missing"))
+
+	count, err := m.HeaderInt("count")
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	retry, err := m.HeaderBool("retry")
+	require.NoError(t, err)
+	assert.True(t, retry)
+
+	sentAt, err := m.HeaderTime("sent-at")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 9, 1, 10, 0, 0, 500000000, time.UTC), sentAt)
+
+	_, err = m.HeaderInt("invalid")
+	require.Error(t, err)
+
+	_, err = m.HeaderBool("invalid")
+	require.Error(t, err)
+
+	_, err = m.HeaderTime("invalid")
+	require.Error(t, err)
+
+	_, err = m.HeaderInt("missing")
+	require.ErrorIs(t, err, errHeaderNotFound)
+
+	_, err = m.HeaderBool("missing")
+	require.ErrorIs(t, err, errHeaderNotFound)
+
+	_, err = m.HeaderTime("missing")
+	require.ErrorIs(t, err, errHeaderNotFound)
+}
@@ -217,14 +217,30 @@ func (m *MQTT) createMqttHandler(_ context.Context, topic string, msgs chan *pub
 	}
 }
 func (m *MQTT) Publish(ctx context.Context, topic string, message []byte) error {
+	return m.PublishMessage(ctx, topic, &pubsub.PublishMessage{Value: message})
+}
+
+// PublishMessage publishes the message with the QoS and Retain of the message when they are set, and the ones
+// configured otherwise. MQTT messages have no keys or headers.
+func (m *MQTT) PublishMessage(ctx context.Context, topic string, message *pubsub.PublishMessage) error {
 	_, span := otel.GetTracerProvider().Tracer("gofr").Start(ctx, "mqtt-publish")
 	defer span.End()
 
 	m.metrics.IncrementCounter(ctx, "app_pubsub_publish_total_count", "topic", topic)
 
+	qos, retain := m.config.QoS, m.config.RetrieveRetained
+
+	if message.QoS != nil {
+		qos = *message.QoS
+	}
+
+	if message.Retain != nil {
+		retain = *message.Retain
+	}
+
 	s := time.Now()
 
-	token := m.Client.Publish(topic, m.config.QoS, m.config.RetrieveRetained, message)
+	token := m.Client.Publish(topic, qos, retain, message.Value)
 
 	// Check for errors during publishing (More on error reporting
 	// https://pkg.go.dev/github.com/eclipse/paho.mqtt.golang#readme-error-handling)
@@ -239,7 +255,7 @@ func (m *MQTT) Publish(ctx context.Context, topic string, message []byte) error
 	m.logger.Debug(&pubsub.Log{
 		Mode:          "PUB",
 		CorrelationID: span.SpanContext().TraceID().String(),
-		MessageValue:  string(message),
+		MessageValue:  string(message.Value),
 		Topic:         topic,
 		Host:          m.config.Hostname,
 		PubSubBackend: "MQTT",
@@ -197,6 +197,28 @@ func TestMQTT_PublishSuccess(t *testing.T) {
 	assert.Contains(t, out, "test/topic")
 }
 
+func TestMQTT_PublishMessage(t *testing.T) {
+	ctrl, client, mockClient, mockMetrics, mockToken := getMockMQTT(t, mockConfigs)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	qos, retain := byte(2), true
+
+	mockMetrics.EXPECT().
+		IncrementCounter(ctx, "app_pubsub_publish_total_count", "topic", "test/topic")
+	mockMetrics.EXPECT().
+		IncrementCounter(ctx, "app_pubsub_publish_success_count", "topic", "test/topic")
+
+	mockClient.EXPECT().Publish("test/topic", qos, retain, msg).Return(mockToken)
+
+	mockToken.EXPECT().Wait().Return(true)
+	mockToken.EXPECT().Error().Return(nil)
+
+	err := client.PublishMessage(ctx, "test/topic", &pubsub.PublishMessage{Value: msg, QoS: &qos, Retain: &retain})
+
+	require.NoError(t, err)
+}
+
 func TestMQTT_PublishFailure(t *testing.T) {
 	ctrl, client, mockClient, mockMetrics, mockToken := getMockMQTT(t, mockConfigs)
 	defer ctrl.Finish()
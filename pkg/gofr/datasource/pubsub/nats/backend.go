@@ -0,0 +1,62 @@
+package nats
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"gofr.dev/pkg/gofr/config"
+	"gofr.dev/pkg/gofr/container"
+)
+
+const defaultMaxWait = 5 * time.Second
+
+// init makes NATS JetStream available as PUBSUB_BACKEND=NATS for the applications importing this package.
+func init() {
+	container.RegisterPubSubBackend("NATS", NewFromConfig)
+}
+
+// NewFromConfig creates the client for PUBSUB_BACKEND=NATS from the configs of the application:
+// PUBSUB_BROKER, NATS_STREAM, NATS_SUBJECTS, NATS_CONSUMER, NATS_CREDS_FILE, NATS_BATCH_SIZE and NATS_MAX_WAIT.
+func NewFromConfig(conf config.Config) (container.PubSubProvider, error) {
+	cfg := &Config{
+		Server:    conf.Get("PUBSUB_BROKER"),
+		CredsFile: conf.Get("NATS_CREDS_FILE"),
+		Consumer:  conf.Get("NATS_CONSUMER"),
+		Stream: StreamConfig{
+			Stream: conf.Get("NATS_STREAM"),
+		},
+		MaxWait: defaultMaxWait,
+	}
+
+	for _, subject := range strings.Split(conf.Get("NATS_SUBJECTS"), ",") {
+		if subject = strings.TrimSpace(subject); subject != "" {
+			cfg.Stream.Subjects = append(cfg.Stream.Subjects, subject)
+		}
+	}
+
+	if value := conf.Get("NATS_BATCH_SIZE"); value != "" {
+		size, err := strconv.Atoi(value)
+		if err != nil || size <= 0 {
+			return nil, fmt.Errorf("invalid value %q for NATS_BATCH_SIZE, expected a positive number", value)
+		}
+
+		cfg.BatchSize = size
+	}
+
+	if value := conf.Get("NATS_MAX_WAIT"); value != "" {
+		maxWait, err := time.ParseDuration(value)
+		if err != nil || maxWait <= 0 {
+			return nil, fmt.Errorf("invalid value %q for NATS_MAX_WAIT, expected a duration like 5s", value)
+		}
+
+		cfg.MaxWait = maxWait
+	}
+
+	if err := validateConfigs(cfg); err != nil {
+		return nil, err
+	}
+
+	return New(cfg, nil), nil
+}
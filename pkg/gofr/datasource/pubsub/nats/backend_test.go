@@ -0,0 +1,83 @@
+package nats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gofr.dev/pkg/gofr/config"
+)
+
+func TestNewFromConfig(t *testing.T) {
+	provider, err := NewFromConfig(config.NewMockConfig(map[string]string{
+		"PUBSUB_BROKER":   NATSServer,
+		"NATS_STREAM":     "orders",
+		"NATS_SUBJECTS":   "orders.created, orders.shipped",
+		"NATS_CONSUMER":   "order-service",
+		"NATS_CREDS_FILE": "/path/to/creds.json",
+		"NATS_BATCH_SIZE": "10",
+		"NATS_MAX_WAIT":   "2s",
+	}))
+	require.NoError(t, err)
+
+	wrapper, ok := provider.(*PubSubWrapper)
+	require.True(t, ok)
+
+	assert.Equal(t, &Config{
+		Server:    NATSServer,
+		CredsFile: "/path/to/creds.json",
+		Stream:    StreamConfig{Stream: "orders", Subjects: []string{"orders.created", "orders.shipped"}},
+		Consumer:  "order-service",
+		MaxWait:   2 * time.Second,
+		BatchSize: 10,
+	}, wrapper.Client.Config)
+}
+
+func TestNewFromConfig_Defaults(t *testing.T) {
+	provider, err := NewFromConfig(config.NewMockConfig(map[string]string{
+		"PUBSUB_BROKER": NATSServer,
+		"NATS_SUBJECTS": "orders.*",
+		"NATS_CONSUMER": "order-service",
+	}))
+	require.NoError(t, err)
+
+	cfg := provider.(*PubSubWrapper).Client.Config
+
+	assert.Equal(t, defaultMaxWait, cfg.MaxWait)
+	assert.Equal(t, 1, cfg.fetchSize())
+}
+
+func TestNewFromConfig_Error(t *testing.T) {
+	valid := func(key, value string) map[string]string {
+		configs := map[string]string{
+			"PUBSUB_BROKER": NATSServer,
+			"NATS_SUBJECTS": "orders.*",
+			"NATS_CONSUMER": "order-service",
+		}
+
+		configs[key] = value
+
+		return configs
+	}
+
+	testCases := []struct {
+		desc    string
+		configs map[string]string
+		expErr  string
+	}{
+		{"server not provided", valid("PUBSUB_BROKER", ""), errServerNotProvided.Error()},
+		{"subjects not provided", valid("NATS_SUBJECTS", " , "), errSubjectsNotProvided.Error()},
+		{"consumer not provided", valid("NATS_CONSUMER", ""), errConsumerNotProvided.Error()},
+		{"invalid batch size", valid("NATS_BATCH_SIZE", "0"),
+			`invalid value "0" for NATS_BATCH_SIZE, expected a positive number`},
+		{"invalid max wait", valid("NATS_MAX_WAIT", "5"), `invalid value "5" for NATS_MAX_WAIT, expected a duration like 5s`},
+	}
+
+	for i, tc := range testCases {
+		provider, err := NewFromConfig(config.NewMockConfig(tc.configs))
+
+		require.EqualError(t, err, tc.expErr, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Nil(t, provider, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
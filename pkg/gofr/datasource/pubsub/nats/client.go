@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/nats-io/nats.go/jetstream"
 	"go.opentelemetry.io/otel/trace"
@@ -99,6 +100,16 @@ func (c *Client) Publish(ctx context.Context, subject string, message []byte) er
 	return c.connManager.Publish(ctx, subject, message, c.metrics)
 }
 
+// PublishMessage publishes a message to a topic along with its headers.
+func (c *Client) PublishMessage(ctx context.Context, subject string, message *pubsub.PublishMessage) error {
+	return c.connManager.PublishMessage(ctx, subject, message, c.metrics)
+}
+
+// BatchConfig returns the fetch size and wait of the consumer as the size and wait of the batch subscriptions.
+func (c *Client) BatchConfig() (size int, wait time.Duration) {
+	return c.Config.fetchSize(), c.Config.MaxWait
+}
+
 // Subscribe subscribes to a topic and returns a single message.
 func (c *Client) Subscribe(ctx context.Context, topic string) (*pubsub.Message, error) {
 	js, err := c.connManager.jetStream()
@@ -177,7 +188,7 @@ func (c *Client) processMessages(ctx context.Context, cons jetstream.Consumer, s
 }
 
 func (c *Client) fetchAndProcessMessages(ctx context.Context, cons jetstream.Consumer, subject string, handler messageHandler) error {
-	msgs, err := cons.Fetch(1, jetstream.FetchMaxWait(c.Config.MaxWait))
+	msgs, err := cons.Fetch(c.Config.fetchSize(), jetstream.FetchMaxWait(c.Config.MaxWait))
 	if err != nil {
 		if !errors.Is(err, context.DeadlineExceeded) {
 			c.logger.Errorf("Error fetching messages for subject %s: %v", subject, err)
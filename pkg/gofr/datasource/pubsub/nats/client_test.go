@@ -783,3 +783,34 @@ func TestClient_CreateOrUpdateStream(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, mockStream, stream)
 }
+
+func TestNATSClient_PublishMessage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMetrics := NewMockMetrics(ctrl)
+	mockConnManager := NewMockConnectionManagerInterface(ctrl)
+
+	client := &Client{
+		connManager: mockConnManager,
+		Config:      &Config{Server: NATSServer},
+		logger:      logging.NewMockLogger(logging.DEBUG),
+		metrics:     mockMetrics,
+	}
+
+	message := &pubsub.PublishMessage{Value: []byte("test-message"), Headers: map[string]string{"Order-Id": "123"}}
+
+	mockConnManager.EXPECT().PublishMessage(gomock.Any(), "test-subject", message, mockMetrics).Return(nil)
+
+	err := client.PublishMessage(context.Background(), "test-subject", message)
+	require.NoError(t, err)
+}
+
+func TestNATSClient_BatchConfig(t *testing.T) {
+	client := &Client{Config: &Config{BatchSize: 10, MaxWait: time.Second}}
+
+	size, wait := client.BatchConfig()
+
+	assert.Equal(t, 10, size)
+	assert.Equal(t, time.Second, wait)
+}
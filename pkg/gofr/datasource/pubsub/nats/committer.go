@@ -25,6 +25,13 @@ func (c *natsCommitter) Commit() {
 	}
 }
 
+// Nack naks the message, so that it is redelivered.
+func (c *natsCommitter) Nack() {
+	if err := c.msg.Nak(); err != nil {
+		log.Println("Error naking message:", err)
+	}
+}
+
 // Nak naks the message.
 func (c *natsCommitter) Nak() error {
 	return c.msg.Nak()
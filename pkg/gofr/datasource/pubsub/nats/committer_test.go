@@ -63,6 +63,20 @@ func TestNATSCommitter_Nak(t *testing.T) {
 	})
 }
 
+func TestNATSCommitter_Nack(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMsg := NewMockMsg(ctrl)
+	committer := createTestCommitter(mockMsg)
+
+	mockMsg.EXPECT().Nak().Return(nil)
+	committer.Nack()
+
+	mockMsg.EXPECT().Nak().Return(assert.AnError)
+	committer.Nack()
+}
+
 func TestNATSCommitter_Rollback(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -16,6 +16,8 @@ type Config struct {
 	Consumer    string
 	MaxWait     time.Duration
 	MaxPullWait int
+	// BatchSize is the number of messages fetched from the consumer in each pull, 1 by default.
+	BatchSize int
 }
 
 // StreamConfig holds stream settings for NATS jStream.
@@ -42,6 +44,15 @@ func New(cfg *Config, logger pubsub.Logger) *PubSubWrapper {
 	return &PubSubWrapper{Client: client}
 }
 
+// fetchSize returns the number of messages to fetch in each pull.
+func (c *Config) fetchSize() int {
+	if c.BatchSize <= 0 {
+		return 1
+	}
+
+	return c.BatchSize
+}
+
 // validateConfigs validates the configuration for NATS jStream.
 func validateConfigs(conf *Config) error {
 	if conf.Server == "" {
@@ -120,13 +120,26 @@ func (cm *ConnectionManager) Close(ctx context.Context) {
 }
 
 func (cm *ConnectionManager) Publish(ctx context.Context, subject string, message []byte, metrics Metrics) error {
+	return cm.PublishMessage(ctx, subject, &pubsub.PublishMessage{Value: message}, metrics)
+}
+
+// PublishMessage publishes the message with its headers, along with the trace context of ctx.
+func (cm *ConnectionManager) PublishMessage(ctx context.Context, subject string, message *pubsub.PublishMessage,
+	metrics Metrics) error {
 	metrics.IncrementCounter(ctx, "app_pubsub_publish_total_count", "subject", subject)
 
 	if err := cm.validateJetStream(subject); err != nil {
 		return err
 	}
 
-	_, err := cm.jStream.Publish(ctx, subject, message)
+	msg := nats.NewMsg(subject)
+	msg.Data = message.Value
+
+	for key, value := range pubsub.InjectTraceContext(ctx, message.Headers) {
+		msg.Header.Set(key, value)
+	}
+
+	_, err := cm.jStream.PublishMsg(ctx, msg)
 	if err != nil {
 		cm.logger.Errorf("failed to publish message to NATS jStream: %v", err)
 		return err
@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 	"gofr.dev/pkg/gofr/datasource"
+	"gofr.dev/pkg/gofr/datasource/pubsub"
 	"gofr.dev/pkg/gofr/logging"
 )
 
@@ -93,13 +94,49 @@ func TestConnectionManager_Publish(t *testing.T) {
 	message := []byte("test message")
 
 	mockMetrics.EXPECT().IncrementCounter(ctx, "app_pubsub_publish_total_count", "subject", subject)
-	mockJS.EXPECT().Publish(ctx, subject, message).Return(&jetstream.PubAck{}, nil)
+	mockJS.EXPECT().PublishMsg(ctx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, msg *nats.Msg, _ ...jetstream.PublishOpt) (*jetstream.PubAck, error) {
+			assert.Equal(t, message, msg.Data)
+
+			return &jetstream.PubAck{}, nil
+		})
 	mockMetrics.EXPECT().IncrementCounter(ctx, "app_pubsub_publish_success_count", "subject", subject)
 
 	err := cm.Publish(ctx, subject, message, mockMetrics)
 	require.NoError(t, err)
 }
 
+func TestConnectionManager_PublishMessage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockJS := NewMockJetStream(ctrl)
+	mockMetrics := NewMockMetrics(ctrl)
+
+	cm := &ConnectionManager{
+		jStream: mockJS,
+		logger:  logging.NewMockLogger(logging.DEBUG),
+	}
+
+	ctx := context.Background()
+	subject := "test.subject"
+	message := &pubsub.PublishMessage{Value: []byte("test message"), Headers: map[string]string{"Order-Id": "123"}}
+
+	mockMetrics.EXPECT().IncrementCounter(ctx, "app_pubsub_publish_total_count", "subject", subject)
+	mockJS.EXPECT().PublishMsg(ctx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, msg *nats.Msg, _ ...jetstream.PublishOpt) (*jetstream.PubAck, error) {
+			assert.Equal(t, subject, msg.Subject)
+			assert.Equal(t, message.Value, msg.Data)
+			assert.Equal(t, "123", msg.Header.Get("Order-Id"))
+
+			return &jetstream.PubAck{}, nil
+		})
+	mockMetrics.EXPECT().IncrementCounter(ctx, "app_pubsub_publish_success_count", "subject", subject)
+
+	err := cm.PublishMessage(ctx, subject, message, mockMetrics)
+	require.NoError(t, err)
+}
+
 func TestConnectionManager_validateJetStream(t *testing.T) {
 	cm := &ConnectionManager{
 		jStream: NewMockJetStream(gomock.NewController(t)),
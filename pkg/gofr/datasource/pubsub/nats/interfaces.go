@@ -46,6 +46,7 @@ type ConnectionManagerInterface interface {
 	Connect() error
 	Close(ctx context.Context)
 	Publish(ctx context.Context, subject string, message []byte, metrics Metrics) error
+	PublishMessage(ctx context.Context, subject string, message *pubsub.PublishMessage, metrics Metrics) error
 	Health() datasource.Health
 	jetStream() (jetstream.JetStream, error)
 }
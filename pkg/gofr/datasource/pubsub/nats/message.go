@@ -22,3 +22,9 @@ func (nmsg *natsMessage) Commit() {
 		nmsg.logger.Errorf("unable to acknowledge message on Client jStream: %v", err)
 	}
 }
+
+func (nmsg *natsMessage) Nack() {
+	if err := nmsg.msg.Nak(); err != nil {
+		nmsg.logger.Errorf("unable to negatively acknowledge message on Client jStream: %v", err)
+	}
+}
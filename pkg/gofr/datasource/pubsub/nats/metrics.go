@@ -8,3 +8,9 @@ import "context"
 type Metrics interface {
 	IncrementCounter(ctx context.Context, name string, labels ...string)
 }
+
+// gauge is implemented by the metrics which can set gauges, as the metrics of the container do. The consumer lag is
+// only recorded with such metrics, so that the metrics set with UseMetrics are not required to set gauges.
+type gauge interface {
+	SetGauge(name string, value float64, labels ...string)
+}
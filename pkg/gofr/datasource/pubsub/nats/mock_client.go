@@ -393,6 +393,20 @@ func (mr *MockConnectionManagerInterfaceMockRecorder) Publish(ctx, subject, mess
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockConnectionManagerInterface)(nil).Publish), ctx, subject, message, metrics)
 }
 
+// PublishMessage mocks base method.
+func (m *MockConnectionManagerInterface) PublishMessage(ctx context.Context, subject string, message *pubsub.PublishMessage, metrics Metrics) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishMessage", ctx, subject, message, metrics)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PublishMessage indicates an expected call of PublishMessage.
+func (mr *MockConnectionManagerInterfaceMockRecorder) PublishMessage(ctx, subject, message, metrics any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishMessage", reflect.TypeOf((*MockConnectionManagerInterface)(nil).PublishMessage), ctx, subject, message, metrics)
+}
+
 // MockSubscriptionManagerInterface is a mock of SubscriptionManagerInterface interface.
 type MockSubscriptionManagerInterface struct {
 	ctrl     *gomock.Controller
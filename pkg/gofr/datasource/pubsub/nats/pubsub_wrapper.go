@@ -2,6 +2,7 @@ package nats
 
 import (
 	"context"
+	"time"
 
 	"gofr.dev/pkg/gofr/datasource"
 	"gofr.dev/pkg/gofr/datasource/pubsub"
@@ -17,6 +18,16 @@ func (w *PubSubWrapper) Publish(ctx context.Context, topic string, message []byt
 	return w.Client.Publish(ctx, topic, message)
 }
 
+// PublishMessage publishes a message to a topic along with its headers.
+func (w *PubSubWrapper) PublishMessage(ctx context.Context, topic string, message *pubsub.PublishMessage) error {
+	return w.Client.PublishMessage(ctx, topic, message)
+}
+
+// BatchConfig returns the fetch size and wait of the consumer as the size and wait of the batch subscriptions.
+func (w *PubSubWrapper) BatchConfig() (size int, wait time.Duration) {
+	return w.Client.BatchConfig()
+}
+
 // Subscribe subscribes to a topic and returns a single message.
 func (w *PubSubWrapper) Subscribe(ctx context.Context, topic string) (*pubsub.Message, error) {
 	return w.Client.Subscribe(ctx, topic)
@@ -73,12 +73,34 @@ func (sm *SubscriptionManager) Subscribe(
 	select {
 	case msg := <-buffer:
 		metrics.IncrementCounter(ctx, "app_pubsub_subscribe_success_count", "topic", topic)
+		recordLag(topic, msg, cfg, metrics)
+
 		return msg, nil
 	case <-ctx.Done():
 		return nil, ctx.Err()
 	}
 }
 
+// recordLag records the messages of the consumer yet to be delivered, as reported by the metadata of the message.
+func recordLag(topic string, msg *pubsub.Message, cfg *Config, metrics Metrics) {
+	lagMetrics, ok := metrics.(gauge)
+	if !ok {
+		return
+	}
+
+	committer, ok := msg.Committer.(*natsCommitter)
+	if !ok {
+		return
+	}
+
+	metadata, err := committer.msg.Metadata()
+	if err != nil {
+		return
+	}
+
+	lagMetrics.SetGauge("app_pubsub_consumer_lag", float64(metadata.NumPending), "topic", topic, "consumer_group", cfg.Consumer)
+}
+
 func (*SubscriptionManager) validateSubscribePrerequisites(js jetstream.JetStream, cfg *Config) error {
 	if js == nil {
 		return errJetStreamNotConfigured
@@ -147,7 +169,7 @@ func (sm *SubscriptionManager) fetchAndProcessMessages(
 	buffer chan *pubsub.Message,
 	cfg *Config,
 	logger pubsub.Logger) error {
-	msgs, err := cons.Fetch(1, jetstream.FetchMaxWait(cfg.MaxWait))
+	msgs, err := cons.Fetch(cfg.fetchSize(), jetstream.FetchMaxWait(cfg.MaxWait))
 	if err != nil {
 		return sm.handleFetchError(err, topic, logger)
 	}
@@ -185,7 +207,21 @@ func (sm *SubscriptionManager) createPubSubMessage(msg jetstream.Msg, topic stri
 	pubsubMsg := pubsub.NewMessage(context.Background()) // Pass a context if needed
 	pubsubMsg.Topic = topic
 	pubsubMsg.Value = msg.Data()
-	pubsubMsg.MetaData = msg.Headers()
+	headers := msg.Headers()
+	pubsubMsg.MetaData = headers
+
+	if len(headers) > 0 {
+		pubsubMsg.Headers = make(map[string]string, len(headers))
+
+		for key := range headers {
+			pubsubMsg.Headers[key] = headers.Get(key)
+		}
+	}
+
+	if metadata, err := msg.Metadata(); err == nil {
+		pubsubMsg.Timestamp = metadata.Timestamp
+	}
+
 	pubsubMsg.Committer = &natsCommitter{msg: msg}
 	return pubsubMsg
 }
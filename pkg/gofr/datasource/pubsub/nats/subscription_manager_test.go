@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/nats-io/nats.go"
 	"github.com/nats-io/nats.go/jetstream"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -21,13 +22,19 @@ func TestNewSubscriptionManager(t *testing.T) {
 	assert.NotNil(t, sm.topicBuffers)
 }
 
+// gaugeMetrics are metrics which set gauges as well, as the metrics of the container do.
+type gaugeMetrics struct {
+	*MockMetrics
+	*Mockgauge
+}
+
 func TestSubscriptionManager_Subscribe(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockJS := NewMockJetStream(ctrl)
 	mockConsumer := NewMockConsumer(ctrl)
-	mockMetrics := NewMockMetrics(ctrl)
+	mockMetrics, mockGauge := NewMockMetrics(ctrl), NewMockgauge(ctrl)
 	mockLogger := logging.NewMockLogger(logging.DEBUG)
 
 	sm := newSubscriptionManager(1)
@@ -49,11 +56,14 @@ func TestSubscriptionManager_Subscribe(t *testing.T) {
 	mockMetrics.EXPECT().IncrementCounter(gomock.Any(), "app_pubsub_subscribe_total_count", "topic", topic)
 	mockConsumer.EXPECT().Fetch(gomock.Any(), gomock.Any()).Return(createMockMessageBatch(ctrl), nil).AnyTimes()
 	mockMetrics.EXPECT().IncrementCounter(gomock.Any(), "app_pubsub_subscribe_success_count", "topic", topic)
+	mockGauge.EXPECT().SetGauge("app_pubsub_consumer_lag", float64(4), "topic", topic, "consumer_group", "test-consumer")
 
-	msg, err := sm.Subscribe(ctx, topic, mockJS, cfg, mockLogger, mockMetrics)
+	msg, err := sm.Subscribe(ctx, topic, mockJS, cfg, mockLogger, gaugeMetrics{MockMetrics: mockMetrics, Mockgauge: mockGauge})
 	require.NoError(t, err)
 	assert.NotNil(t, msg)
 	assert.Equal(t, topic, msg.Topic)
+	assert.Equal(t, "123", msg.Header("Order-Id"))
+	assert.Equal(t, time.Date(2024, 9, 1, 10, 0, 0, 0, time.UTC), msg.Timestamp)
 }
 
 func TestSubscriptionManager_Subscribe_Error(t *testing.T) {
@@ -174,7 +184,9 @@ func createMockMessageBatch(ctrl *gomock.Controller) jetstream.MessageBatch {
 	mockMsg := NewMockMsg(ctrl)
 
 	mockMsg.EXPECT().Data().Return([]byte("test message")).AnyTimes()
-	mockMsg.EXPECT().Headers().Return(nil).AnyTimes()
+	mockMsg.EXPECT().Headers().Return(nats.Header{"Order-Id": []string{"123"}}).AnyTimes()
+	mockMsg.EXPECT().Metadata().Return(&jetstream.MsgMetadata{Timestamp: time.Date(2024, 9, 1, 10, 0, 0, 0, time.UTC),
+		NumPending: 4}, nil).AnyTimes()
 
 	msgChan := make(chan jetstream.Msg, 1)
 	msgChan <- mockMsg
@@ -0,0 +1,29 @@
+package pubsub
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// InjectTraceContext returns a copy of the headers along with the trace context of ctx, as the W3C traceparent,
+// tracestate and baggage headers, so that the spans of the subscribers continue the trace of the publisher.
+// It is used by the backends supporting headers while publishing the messages.
+func InjectTraceContext(ctx context.Context, headers map[string]string) map[string]string {
+	carrier := make(propagation.MapCarrier, len(headers))
+
+	for key, value := range headers {
+		carrier[key] = value
+	}
+
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+
+	return carrier
+}
+
+// ExtractTraceContext returns ctx along with the trace context propagated through the headers of a message, such
+// that the spans started from it are children of the span which published the message.
+func ExtractTraceContext(ctx context.Context, headers map[string]string) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(headers))
+}
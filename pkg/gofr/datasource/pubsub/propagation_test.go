@@ -0,0 +1,50 @@
+package pubsub
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTraceContextPropagation(t *testing.T) {
+	propagator := otel.GetTextMapPropagator()
+	defer otel.SetTextMapPropagator(propagator)
+
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	member, _ := baggage.NewMember("tenant", "gofr")
+	bag, _ := baggage.New(member)
+
+	ctx, span := sdktrace.NewTracerProvider().Tracer("test").Start(baggage.ContextWithBaggage(context.Background(), bag),
+		"publish")
+	defer span.End()
+
+	headers := map[string]string{"Order-Id": "123"}
+
+	out := InjectTraceContext(ctx, headers)
+
+	assert.Equal(t, "123", out["Order-Id"])
+	assert.Contains(t, out["traceparent"], span.SpanContext().TraceID().String())
+	assert.Equal(t, "tenant=gofr", out["baggage"])
+	assert.Len(t, headers, 1, "headers of the message should not be modified")
+
+	extracted := ExtractTraceContext(context.Background(), out)
+
+	spanContext := trace.SpanContextFromContext(extracted)
+	assert.True(t, spanContext.IsRemote())
+	assert.Equal(t, span.SpanContext().TraceID(), spanContext.TraceID())
+	assert.Equal(t, span.SpanContext().SpanID(), spanContext.SpanID())
+	assert.Equal(t, "gofr", baggage.FromContext(extracted).Member("tenant").Value())
+}
+
+func TestExtractTraceContext_NoHeaders(t *testing.T) {
+	ctx := ExtractTraceContext(context.Background(), nil)
+
+	assert.False(t, trace.SpanContextFromContext(ctx).IsValid())
+}
@@ -0,0 +1,99 @@
+package redis
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"gofr.dev/pkg/gofr/datasource/pubsub"
+)
+
+// The fields of the stream entries of the messages, the headers are added as fields prefixed with headerPrefix.
+const (
+	valueField     = "value"
+	keyField       = "key"
+	timestampField = "timestamp"
+	headerPrefix   = "header:"
+)
+
+func encodeMessage(ctx context.Context, message *pubsub.PublishMessage) map[string]any {
+	headers := pubsub.InjectTraceContext(ctx, message.Headers)
+
+	values := make(map[string]any, len(headers)+3) //nolint:mnd // the value, key and timestamp fields
+	values[valueField] = message.Value
+
+	if message.Key != "" {
+		values[keyField] = message.Key
+	}
+
+	if !message.Timestamp.IsZero() {
+		values[timestampField] = message.Timestamp.UnixMilli()
+	}
+
+	for name, value := range headers {
+		values[headerPrefix+name] = value
+	}
+
+	return values
+}
+
+// decodeMessage creates the message of the stream entry, whose timestamp is the time it was added to the stream
+// unless it was published with one.
+func decodeMessage(ctx context.Context, topic string, entry *redis.XMessage) *pubsub.Message {
+	msg := pubsub.NewMessage(ctx)
+	msg.Topic = topic
+	msg.Timestamp = entryTime(entry.ID)
+
+	for field, value := range entry.Values {
+		str, _ := value.(string)
+
+		switch {
+		case field == valueField:
+			msg.Value = []byte(str)
+		case field == keyField:
+			msg.Key = str
+		case field == timestampField:
+			if millis, err := strconv.ParseInt(str, 10, 64); err == nil {
+				msg.Timestamp = time.UnixMilli(millis)
+			}
+		case strings.HasPrefix(field, headerPrefix):
+			if msg.Headers == nil {
+				msg.Headers = make(map[string]string)
+			}
+
+			msg.Headers[strings.TrimPrefix(field, headerPrefix)] = str
+		}
+	}
+
+	return msg
+}
+
+// entryTime returns the time of the ID of a stream entry, i.e. the unix milliseconds before its sequence number.
+func entryTime(id string) time.Time {
+	millis, _, _ := strings.Cut(id, "-")
+
+	ms, err := strconv.ParseInt(millis, 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return time.UnixMilli(ms)
+}
+
+// committer acknowledges the message to the consumer group, removing it from the pending messages of the group.
+type committer struct {
+	client *redis.Client
+	stream string
+	group  string
+	id     string
+	logger pubsub.Logger
+}
+
+func (c *committer) Commit() {
+	if err := c.client.XAck(context.Background(), c.stream, c.group, c.id).Err(); err != nil {
+		c.logger.Errorf("failed to acknowledge message %s of redis stream %s: %v", c.id, c.stream, err)
+	}
+}
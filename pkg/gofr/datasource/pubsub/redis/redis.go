@@ -0,0 +1,449 @@
+// Package redis provides a pub/sub client on Redis Streams. The topics are streams, the subscriptions read them with
+// consumer groups and commit the messages by acknowledging them, while the messages left pending by crashed consumers
+// are claimed by the other consumers of their group.
+package redis
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel"
+
+	"gofr.dev/pkg/gofr/datasource"
+	"gofr.dev/pkg/gofr/datasource/pubsub"
+)
+
+const (
+	defaultBlockTimeout  = 5 * time.Second
+	defaultClaimIdleTime = time.Minute
+	defaultConsumerName  = "gofr"
+	healthTimeout        = 5 * time.Second
+
+	// claimCount is the number of pending messages claimed at once from the other consumers.
+	claimCount = 10
+	// firstStreamID reads a stream from its first message.
+	firstStreamID = "0-0"
+)
+
+var (
+	ErrConsumerGroupNotProvided = errors.New("consumer group id not provided")
+	errEmptyTopic               = errors.New("topic name cannot be empty")
+)
+
+type Config struct {
+	// Addr is the host:port of the Redis server.
+	Addr     string
+	Username string
+	Password string
+	DB       int
+	// TLS configures the TLS connections to the Redis server, the connections are not encrypted when it is nil.
+	TLS *tls.Config
+	// ConsumerGroup is the consumer group of the subscriptions, it is required to subscribe.
+	ConsumerGroup string
+	// ConsumerName identifies the client in its consumer group, the hostname by default. The name must be unique
+	// among the running consumers of the group.
+	ConsumerName string
+	// MaxLen caps the length of the streams on publishing, the oldest messages are trimmed once it is exceeded. The
+	// streams are trimmed approximately, and are not capped when MaxLen is zero.
+	MaxLen int64
+	// BlockTimeout is the time a read waits for new messages, before checking for the messages to be claimed.
+	BlockTimeout time.Duration
+	// ClaimIdleTime is the time after which the messages delivered to a consumer, but not committed, are claimed by
+	// the other consumers of the group. It must be longer than the time taken to handle a message.
+	ClaimIdleTime time.Duration
+}
+
+// Client is a pub/sub client whose topics are Redis streams.
+type Client struct {
+	client  *redis.Client
+	config  Config
+	logger  pubsub.Logger
+	metrics Metrics
+
+	mu      sync.Mutex
+	streams map[string]*stream
+}
+
+// stream is the progress of the subscription of the client to a stream.
+type stream struct {
+	mu         sync.Mutex
+	groupReady bool
+	// claimed are the messages claimed from the other consumers, which are delivered before the new messages.
+	claimed    []redis.XMessage
+	claimStart string
+	nextClaim  time.Time
+}
+
+// New creates a client connected to the Redis server of the config, metrics can be nil.
+func New(conf Config, logger pubsub.Logger, metrics Metrics) *Client {
+	if conf.ConsumerName == "" {
+		conf.ConsumerName = defaultConsumerName
+
+		if hostname, err := os.Hostname(); err == nil {
+			conf.ConsumerName = hostname
+		}
+	}
+
+	if conf.BlockTimeout <= 0 {
+		conf.BlockTimeout = defaultBlockTimeout
+	}
+
+	if conf.ClaimIdleTime <= 0 {
+		conf.ClaimIdleTime = defaultClaimIdleTime
+	}
+
+	client := redis.NewClient(&redis.Options{
+		Addr:      conf.Addr,
+		Username:  conf.Username,
+		Password:  conf.Password,
+		DB:        conf.DB,
+		TLSConfig: conf.TLS,
+		// the reads blocking for new messages return once the context of the subscription is done
+		ContextTimeoutEnabled: true,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), healthTimeout)
+	defer cancel()
+
+	if err := client.Ping(ctx).Err(); err != nil {
+		logger.Errorf("could not connect to redis streams at '%s', error: %v", conf.Addr, err)
+	} else {
+		logger.Logf("connected to redis streams at '%s' as consumer '%s'", conf.Addr, conf.ConsumerName)
+	}
+
+	return &Client{
+		client:  client,
+		config:  conf,
+		logger:  logger,
+		metrics: metrics,
+		streams: make(map[string]*stream),
+	}
+}
+
+func (c *Client) Publish(ctx context.Context, topic string, message []byte) error {
+	return c.PublishMessage(ctx, topic, &pubsub.PublishMessage{Value: message})
+}
+
+// PublishMessage adds the message to the stream of the topic, trimming the stream to MaxLen. The trace context of ctx
+// is published along with the headers.
+func (c *Client) PublishMessage(ctx context.Context, topic string, message *pubsub.PublishMessage) error {
+	ctx, span := otel.GetTracerProvider().Tracer("gofr").Start(ctx, "redis-publish")
+	defer span.End()
+
+	c.incrementCounter(ctx, "app_pubsub_publish_total_count", "topic", topic)
+
+	if topic == "" {
+		return errEmptyTopic
+	}
+
+	start := time.Now()
+
+	err := c.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: topic,
+		MaxLen: c.config.MaxLen,
+		Approx: true,
+		Values: encodeMessage(ctx, message),
+	}).Err()
+	if err != nil {
+		c.logger.Errorf("failed to publish message to redis stream %s: %v", topic, err)
+
+		return err
+	}
+
+	c.logger.Debug(&pubsub.Log{
+		Mode:          "PUB",
+		CorrelationID: span.SpanContext().TraceID().String(),
+		MessageValue:  string(message.Value),
+		Topic:         topic,
+		Host:          c.config.Addr,
+		PubSubBackend: "REDIS",
+		Time:          time.Since(start).Microseconds(),
+	})
+
+	c.incrementCounter(ctx, "app_pubsub_publish_success_count", "topic", topic)
+
+	return nil
+}
+
+// Subscribe returns the next message of the topic for the consumer group of the client, waiting for one to be
+// published if there is none. The messages left pending by the other consumers for ClaimIdleTime are claimed and
+// delivered first. It returns a nil message once ctx is done.
+func (c *Client) Subscribe(ctx context.Context, topic string) (*pubsub.Message, error) {
+	if c.config.ConsumerGroup == "" {
+		c.logger.Error("cannot subscribe as consumer_id is not provided in configs")
+
+		return nil, ErrConsumerGroupNotProvided
+	}
+
+	ctx, span := otel.GetTracerProvider().Tracer("gofr").Start(ctx, "redis-subscribe")
+	defer span.End()
+
+	c.incrementCounter(ctx, "app_pubsub_subscribe_total_count", "topic", topic, "consumer_group", c.config.ConsumerGroup)
+
+	start := time.Now()
+
+	entry, err := c.next(ctx, topic, c.getStream(topic))
+	if err != nil || entry == nil {
+		return nil, err
+	}
+
+	msg := decodeMessage(ctx, topic, entry)
+	msg.Committer = &committer{client: c.client, stream: topic, group: c.config.ConsumerGroup, id: entry.ID, logger: c.logger}
+
+	c.logger.Debug(&pubsub.Log{
+		Mode:          "SUB",
+		CorrelationID: span.SpanContext().TraceID().String(),
+		MessageValue:  string(msg.Value),
+		Topic:         topic,
+		Host:          c.config.Addr,
+		PubSubBackend: "REDIS",
+		Time:          time.Since(start).Microseconds(),
+	})
+
+	c.incrementCounter(ctx, "app_pubsub_subscribe_success_count", "topic", topic, "consumer_group", c.config.ConsumerGroup)
+
+	return msg, nil
+}
+
+// next returns the next claimed, or new, message of the stream, nil once ctx is done.
+func (c *Client) next(ctx context.Context, topic string, s *stream) (*redis.XMessage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for {
+		if ctx.Err() != nil {
+			return nil, nil
+		}
+
+		if !s.groupReady {
+			if err := c.createGroup(ctx, topic); err != nil {
+				if err = c.readError(ctx, topic, s, err); err != nil {
+					return nil, err
+				}
+
+				continue
+			}
+
+			s.groupReady = true
+		}
+
+		if err := c.claim(ctx, topic, s); err != nil {
+			if err = c.readError(ctx, topic, s, err); err != nil {
+				return nil, err
+			}
+
+			continue
+		}
+
+		if len(s.claimed) > 0 {
+			entry := s.claimed[0]
+			s.claimed = s.claimed[1:]
+
+			return &entry, nil
+		}
+
+		streams, err := c.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+			Group:    c.config.ConsumerGroup,
+			Consumer: c.config.ConsumerName,
+			Streams:  []string{topic, ">"},
+			Count:    1,
+			Block:    c.config.BlockTimeout,
+		}).Result()
+
+		switch {
+		case errors.Is(err, redis.Nil):
+			continue
+		case err != nil:
+			if err = c.readError(ctx, topic, s, err); err != nil {
+				return nil, err
+			}
+		case len(streams) > 0 && len(streams[0].Messages) > 0:
+			return &streams[0].Messages[0], nil
+		}
+	}
+}
+
+// claim claims the messages pending for ClaimIdleTime from the consumers of the group, once the previous claims
+// have gone through all of the pending messages and ClaimIdleTime has passed since.
+func (c *Client) claim(ctx context.Context, topic string, s *stream) error {
+	if len(s.claimed) > 0 || time.Now().Before(s.nextClaim) {
+		return nil
+	}
+
+	if s.claimStart == "" {
+		s.claimStart = firstStreamID
+	}
+
+	messages, next, err := c.client.XAutoClaim(ctx, &redis.XAutoClaimArgs{
+		Stream:   topic,
+		Group:    c.config.ConsumerGroup,
+		Consumer: c.config.ConsumerName,
+		MinIdle:  c.config.ClaimIdleTime,
+		Start:    s.claimStart,
+		Count:    claimCount,
+	}).Result()
+	if err != nil {
+		return err
+	}
+
+	if len(messages) > 0 {
+		c.logger.Debugf("claimed %d pending messages of redis stream %s", len(messages), topic)
+	}
+
+	s.claimed = messages
+	s.claimStart = next
+
+	if next == firstStreamID {
+		s.nextClaim = time.Now().Add(c.config.ClaimIdleTime)
+	}
+
+	return nil
+}
+
+// readError returns nil for the errors the reads of the stream recover from, i.e. ctx being done, and the stream or
+// its consumer group being deleted, in which case they are created again.
+func (c *Client) readError(ctx context.Context, topic string, s *stream, err error) error {
+	if ctx.Err() != nil {
+		return nil
+	}
+
+	// the read deadline of the connection is the deadline of ctx, so the read can time out before ctx reports it.
+	if deadline, ok := ctx.Deadline(); ok && !time.Now().Before(deadline) {
+		return nil
+	}
+
+	if strings.HasPrefix(err.Error(), "NOGROUP") {
+		s.groupReady = false
+		s.claimStart = ""
+
+		return nil
+	}
+
+	c.logger.Errorf("failed to read from redis stream %s: %v", topic, err)
+
+	return err
+}
+
+// createGroup creates the consumer group of the client on the stream, along with the stream if it does not exist.
+// A new group reads the messages of the stream from the oldest one retained.
+func (c *Client) createGroup(ctx context.Context, topic string) error {
+	err := c.client.XGroupCreateMkStream(ctx, topic, c.config.ConsumerGroup, firstStreamID).Err()
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return err
+	}
+
+	return nil
+}
+
+func (c *Client) getStream(topic string) *stream {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s, ok := c.streams[topic]
+	if !ok {
+		s = &stream{}
+		c.streams[topic] = s
+	}
+
+	return s
+}
+
+// Health reports the connection to the server, along with the length of the subscribed streams and the messages
+// pending for the consumer group of the client.
+func (c *Client) Health() datasource.Health {
+	health := datasource.Health{
+		Status: datasource.StatusUp,
+		Details: map[string]any{
+			"backend":        "REDIS",
+			"host":           c.config.Addr,
+			"consumer_group": c.config.ConsumerGroup,
+			"consumer":       c.config.ConsumerName,
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), healthTimeout)
+	defer cancel()
+
+	if err := c.client.Ping(ctx).Err(); err != nil {
+		health.Status = datasource.StatusDown
+		health.Details["error"] = err.Error()
+
+		return health
+	}
+
+	health.Details["streams"] = c.streamStats(ctx)
+
+	return health
+}
+
+func (c *Client) streamStats(ctx context.Context) map[string]any {
+	c.mu.Lock()
+	topics := make([]string, 0, len(c.streams))
+
+	for topic := range c.streams {
+		topics = append(topics, topic)
+	}
+
+	c.mu.Unlock()
+
+	stats := make(map[string]any, len(topics))
+
+	for _, topic := range topics {
+		length, err := c.client.XLen(ctx, topic).Result()
+		if err != nil {
+			continue
+		}
+
+		stat := map[string]any{"length": length}
+
+		if pending, err := c.client.XPending(ctx, topic, c.config.ConsumerGroup).Result(); err == nil {
+			stat["pending"] = pending.Count
+		}
+
+		stats[topic] = stat
+	}
+
+	return stats
+}
+
+// CreateTopic creates the stream of the topic, along with the consumer group of the client when it is set. As with
+// the groups created by Subscribe, the group reads the stream from its oldest message, so that the group created on an
+// existing stream reads the messages it retains as well.
+func (c *Client) CreateTopic(ctx context.Context, name string) error {
+	if name == "" {
+		return errEmptyTopic
+	}
+
+	if c.config.ConsumerGroup != "" {
+		return c.createGroup(ctx, name)
+	}
+
+	// a stream is created with its first message, so the stream is created with a message which is deleted
+	id, err := c.client.XAdd(ctx, &redis.XAddArgs{Stream: name, Values: map[string]any{valueField: ""}}).Result()
+	if err != nil {
+		return err
+	}
+
+	return c.client.XDel(ctx, name, id).Err()
+}
+
+// DeleteTopic deletes the stream of the topic, along with its messages and consumer groups.
+func (c *Client) DeleteTopic(ctx context.Context, name string) error {
+	return c.client.Del(ctx, name).Err()
+}
+
+func (c *Client) Close() error {
+	return c.client.Close()
+}
+
+func (c *Client) incrementCounter(ctx context.Context, name string, labels ...string) {
+	if c.metrics != nil {
+		c.metrics.IncrementCounter(ctx, name, labels...)
+	}
+}
@@ -0,0 +1,225 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gofr.dev/pkg/gofr/datasource"
+	"gofr.dev/pkg/gofr/datasource/pubsub"
+	"gofr.dev/pkg/gofr/logging"
+)
+
+func newTestClient(t *testing.T, server *miniredis.Miniredis, consumer string) *Client {
+	t.Helper()
+
+	c := New(Config{
+		Addr:          server.Addr(),
+		ConsumerGroup: "orders-service",
+		ConsumerName:  consumer,
+		BlockTimeout:  10 * time.Millisecond,
+		ClaimIdleTime: time.Minute,
+	}, logging.NewMockLogger(logging.ERROR), nil)
+
+	t.Cleanup(func() { _ = c.Close() })
+
+	return c
+}
+
+func subscribe(t *testing.T, c *Client, topic string) *pubsub.Message {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	msg, err := c.Subscribe(ctx, topic)
+	require.NoError(t, err)
+	require.NotNil(t, msg, "no message received on topic %s", topic)
+
+	return msg
+}
+
+func TestClient_PublishSubscribe(t *testing.T) {
+	server := miniredis.RunT(t)
+	c := newTestClient(t, server, "consumer-1")
+	ctx := context.Background()
+	timestamp := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	require.NoError(t, c.CreateTopic(ctx, "orders"))
+
+	err := c.PublishMessage(ctx, "orders", &pubsub.PublishMessage{
+		Key:       "order-1",
+		Value:     []byte(`{"orderId":"1"}`),
+		Headers:   map[string]string{"source": "checkout"},
+		Timestamp: timestamp,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, c.Publish(ctx, "orders", []byte(`{"orderId":"2"}`)))
+
+	msg := subscribe(t, c, "orders")
+
+	assert.Equal(t, "orders", msg.Topic)
+	assert.Equal(t, "order-1", msg.Key)
+	assert.JSONEq(t, `{"orderId":"1"}`, string(msg.Value))
+	assert.Equal(t, "checkout", msg.Headers["source"])
+	assert.True(t, timestamp.Equal(msg.Timestamp))
+
+	msg.Commit()
+
+	msg = subscribe(t, c, "orders")
+
+	assert.JSONEq(t, `{"orderId":"2"}`, string(msg.Value))
+	assert.Empty(t, msg.Key)
+	assert.WithinDuration(t, time.Now(), msg.Timestamp, time.Minute)
+
+	msg.Commit()
+
+	pending, err := c.client.XPending(ctx, "orders", "orders-service").Result()
+	require.NoError(t, err)
+	assert.Zero(t, pending.Count)
+}
+
+func TestClient_SubscribeReturnsOnceContextIsDone(t *testing.T) {
+	server := miniredis.RunT(t)
+	c := newTestClient(t, server, "consumer-1")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	msg, err := c.Subscribe(ctx, "orders")
+
+	require.NoError(t, err)
+	assert.Nil(t, msg)
+}
+
+func TestClient_ConsumersOfGroupShareMessages(t *testing.T) {
+	server := miniredis.RunT(t)
+	first := newTestClient(t, server, "consumer-1")
+	second := newTestClient(t, server, "consumer-2")
+	ctx := context.Background()
+
+	require.NoError(t, first.CreateTopic(ctx, "orders"))
+
+	for _, order := range []string{"1", "2"} {
+		require.NoError(t, first.Publish(ctx, "orders", []byte(order)))
+	}
+
+	assert.Equal(t, "1", string(subscribe(t, first, "orders").Value))
+	assert.Equal(t, "2", string(subscribe(t, second, "orders").Value))
+}
+
+func TestClient_ClaimsMessagesOfCrashedConsumer(t *testing.T) {
+	server := miniredis.RunT(t)
+	crashed := newTestClient(t, server, "consumer-1")
+	ctx := context.Background()
+
+	require.NoError(t, crashed.CreateTopic(ctx, "orders"))
+	require.NoError(t, crashed.Publish(ctx, "orders", []byte("1")))
+
+	// the message is delivered to the first consumer, which crashes before committing it
+	subscribe(t, crashed, "orders")
+
+	server.SetTime(time.Now().Add(2 * time.Minute))
+
+	c := newTestClient(t, server, "consumer-2")
+	msg := subscribe(t, c, "orders")
+
+	assert.Equal(t, "1", string(msg.Value))
+
+	msg.Commit()
+
+	pending, err := c.client.XPending(ctx, "orders", "orders-service").Result()
+	require.NoError(t, err)
+	assert.Zero(t, pending.Count)
+}
+
+func TestClient_PublishCapsStreamLength(t *testing.T) {
+	server := miniredis.RunT(t)
+	c := newTestClient(t, server, "consumer-1")
+	c.config.MaxLen = 2
+	ctx := context.Background()
+
+	for _, order := range []string{"1", "2", "3"} {
+		require.NoError(t, c.Publish(ctx, "orders", []byte(order)))
+	}
+
+	length, err := c.client.XLen(ctx, "orders").Result()
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), length)
+}
+
+func TestClient_Errors(t *testing.T) {
+	server := miniredis.RunT(t)
+	c := New(Config{Addr: server.Addr()}, logging.NewMockLogger(logging.FATAL), nil)
+	ctx := context.Background()
+
+	_, err := c.Subscribe(ctx, "orders")
+	require.ErrorIs(t, err, ErrConsumerGroupNotProvided)
+
+	require.ErrorIs(t, c.Publish(ctx, "", []byte("1")), errEmptyTopic)
+	require.ErrorIs(t, c.CreateTopic(ctx, ""), errEmptyTopic)
+
+	server.Close()
+
+	require.Error(t, c.Publish(ctx, "orders", []byte("1")))
+}
+
+func TestClient_CreateAndDeleteTopic(t *testing.T) {
+	server := miniredis.RunT(t)
+	ctx := context.Background()
+
+	publisher := New(Config{Addr: server.Addr()}, logging.NewMockLogger(logging.ERROR), nil)
+	defer publisher.Close()
+
+	require.NoError(t, publisher.CreateTopic(ctx, "payments"))
+	assert.True(t, server.Exists("payments"))
+
+	c := newTestClient(t, server, "consumer-1")
+
+	require.NoError(t, c.CreateTopic(ctx, "orders"))
+	require.NoError(t, c.CreateTopic(ctx, "orders"), "creating an existing topic should not fail")
+
+	groups, err := c.client.XInfoGroups(ctx, "orders").Result()
+	require.NoError(t, err)
+	require.Len(t, groups, 1)
+	assert.Equal(t, "orders-service", groups[0].Name)
+
+	require.NoError(t, c.Publish(ctx, "orders", []byte("1")))
+	subscribe(t, c, "orders").Commit()
+
+	require.NoError(t, c.DeleteTopic(ctx, "orders"))
+	assert.False(t, server.Exists("orders"))
+
+	// the consumer group is created again once the stream is deleted
+	require.NoError(t, publisher.Publish(ctx, "orders", []byte("2")))
+	assert.Equal(t, "2", string(subscribe(t, c, "orders").Value))
+}
+
+func TestClient_Health(t *testing.T) {
+	server := miniredis.RunT(t)
+	c := newTestClient(t, server, "consumer-1")
+	ctx := context.Background()
+
+	require.NoError(t, c.Publish(ctx, "orders", []byte("1")))
+	require.NoError(t, c.Publish(ctx, "orders", []byte("2")))
+	subscribe(t, c, "orders")
+
+	health := c.Health()
+
+	assert.Equal(t, datasource.StatusUp, health.Status)
+	assert.Equal(t, "REDIS", health.Details["backend"])
+	assert.Equal(t, "consumer-1", health.Details["consumer"])
+	assert.Equal(t, map[string]any{"orders": map[string]any{"length": int64(2), "pending": int64(1)}}, health.Details["streams"])
+
+	server.Close()
+
+	health = c.Health()
+
+	assert.Equal(t, datasource.StatusDown, health.Status)
+	assert.Contains(t, health.Details, "error")
+}
@@ -42,6 +42,15 @@ func NewClient(c config.Config, logger datasource.Logger, metrics Metrics) *Redi
 		return nil
 	}
 
+	tlsConfig, err := TLSConfig(c)
+	if err != nil {
+		logger.Errorf("could not connect to redis at '%s:%d', error: %s", redisConfig.HostName, redisConfig.Port, err)
+
+		return nil
+	}
+
+	redisConfig.Options.TLSConfig = tlsConfig
+
 	logger.Debugf("connecting to redis at '%s:%d'", redisConfig.HostName, redisConfig.Port)
 
 	rc := redis.NewClient(redisConfig.Options)
@@ -90,6 +99,9 @@ func getRedisConfig(c config.Config) *Config {
 
 	options := new(redis.Options)
 
+	// the database is selected with REDIS_DB, the database 0 is used by default
+	options.DB, _ = strconv.Atoi(c.Get("REDIS_DB"))
+
 	if options.Addr == "" {
 		options.Addr = fmt.Sprintf("%s:%d", redisConfig.HostName, redisConfig.Port)
 	}
@@ -41,6 +41,30 @@ func Test_NewClient_InvalidPort(t *testing.T) {
 	assert.NotNil(t, client.Client, "Test_NewClient_InvalidPort Failed! Expected redis client not to be nil")
 }
 
+func Test_NewClient_DB(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	s, err := miniredis.Run()
+	require.NoError(t, err)
+
+	defer s.Close()
+
+	mockMetrics := NewMockMetrics(ctrl)
+	mockMetrics.EXPECT().RecordHistogram(gomock.Any(), "app_redis_stats", gomock.Any(), "hostname", gomock.Any(),
+		"type", gomock.Any()).AnyTimes()
+
+	mockConfig := config.NewMockConfig(map[string]string{"REDIS_HOST": s.Host(), "REDIS_PORT": s.Port(), "REDIS_DB": "2"})
+
+	client := NewClient(mockConfig, logging.NewMockLogger(logging.ERROR), mockMetrics)
+	require.NoError(t, client.Set(context.Background(), "key", "value", 0).Err())
+
+	value, err := s.DB(2).Get("key")
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, client.Options().DB)
+	assert.Equal(t, "value", value)
+}
+
 func TestRedis_QueryLogging(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -0,0 +1,63 @@
+package redis
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+
+	"gofr.dev/pkg/gofr/config"
+)
+
+var (
+	errTLSKeyPair = errors.New("REDIS_TLS_CERT_FILE and REDIS_TLS_KEY_FILE must be provided together")
+	errCACert     = errors.New("no certificates found in REDIS_TLS_CA_CERT_FILE")
+)
+
+// TLSConfig returns the TLS config of the connections to the Redis server, from the REDIS_TLS_* configs. It is nil
+// when REDIS_TLS_ENABLED is not true, so that the connections are not encrypted.
+func TLSConfig(c config.Config) (*tls.Config, error) {
+	if enabled, _ := strconv.ParseBool(c.Get("REDIS_TLS_ENABLED")); !enabled {
+		return nil, nil
+	}
+
+	insecureSkipVerify, _ := strconv.ParseBool(c.Get("REDIS_TLS_INSECURE_SKIP_VERIFY"))
+
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: insecureSkipVerify, //nolint:gosec // skipping the verification is opted in by the config
+	}
+
+	certFile, keyFile := c.Get("REDIS_TLS_CERT_FILE"), c.Get("REDIS_TLS_KEY_FILE")
+
+	if (certFile == "") != (keyFile == "") {
+		return nil, errTLSKeyPair
+	}
+
+	if certFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the client certificate: %w", err)
+		}
+
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	if caCertFile := c.Get("REDIS_TLS_CA_CERT_FILE"); caCertFile != "" {
+		caCert, err := os.ReadFile(caCertFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the CA certificate: %w", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caCert) {
+			return nil, errCACert
+		}
+
+		tlsConfig.RootCAs = pool
+	}
+
+	return tlsConfig, nil
+}
@@ -0,0 +1,62 @@
+package redis
+
+import (
+	"crypto/tls"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gofr.dev/pkg/gofr/config"
+	"gofr.dev/pkg/gofr/logging"
+)
+
+func TestTLSConfig(t *testing.T) {
+	invalidCA := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(invalidCA, []byte("not a certificate"), 0o600))
+
+	testCases := []struct {
+		desc    string
+		configs map[string]string
+		expTLS  *tls.Config
+		expErr  error
+	}{
+		{"disabled", map[string]string{"REDIS_TLS_CA_CERT_FILE": invalidCA}, nil, nil},
+		{"enabled", map[string]string{"REDIS_TLS_ENABLED": "true"}, &tls.Config{MinVersion: tls.VersionTLS12}, nil},
+		{"insecure", map[string]string{"REDIS_TLS_ENABLED": "true", "REDIS_TLS_INSECURE_SKIP_VERIFY": "true"},
+			&tls.Config{MinVersion: tls.VersionTLS12, InsecureSkipVerify: true}, nil},
+		{"certificate without key", map[string]string{"REDIS_TLS_ENABLED": "true", "REDIS_TLS_CERT_FILE": "cert.pem"},
+			nil, errTLSKeyPair},
+		{"invalid CA certificate", map[string]string{"REDIS_TLS_ENABLED": "true", "REDIS_TLS_CA_CERT_FILE": invalidCA},
+			nil, errCACert},
+	}
+
+	for i, tc := range testCases {
+		tlsConfig, err := TLSConfig(config.NewMockConfig(tc.configs))
+
+		require.ErrorIs(t, err, tc.expErr, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.expTLS, tlsConfig, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestTLSConfig_MissingFiles(t *testing.T) {
+	configs := []map[string]string{
+		{"REDIS_TLS_ENABLED": "true", "REDIS_TLS_CERT_FILE": "missing.pem", "REDIS_TLS_KEY_FILE": "missing.key"},
+		{"REDIS_TLS_ENABLED": "true", "REDIS_TLS_CA_CERT_FILE": "missing.pem"},
+	}
+
+	for i, c := range configs {
+		_, err := TLSConfig(config.NewMockConfig(c))
+		require.Error(t, err, "TEST[%d], Failed.\n", i)
+	}
+}
+
+func Test_NewClient_InvalidTLSConfig(t *testing.T) {
+	mockConfig := config.NewMockConfig(map[string]string{"REDIS_HOST": "localhost", "REDIS_TLS_ENABLED": "true",
+		"REDIS_TLS_KEY_FILE": "key.pem"})
+
+	client := NewClient(mockConfig, logging.NewMockLogger(logging.ERROR), nil)
+	assert.Nil(t, client)
+}
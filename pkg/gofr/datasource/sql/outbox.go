@@ -0,0 +1,32 @@
+package sql
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	insertOutboxMessage = `INSERT INTO gofr_outbox (topic, payload, status, attempts, created_at) VALUES (?, ?, 'PENDING', 0, ?);`
+
+	insertOutboxMessagePostgres = `INSERT INTO gofr_outbox (topic, payload, status, attempts, created_at) VALUES ($1, $2, 'PENDING', 0, $3);`
+)
+
+var errOutboxTopicEmpty = errors.New("topic of the outbox message cannot be empty")
+
+// PublishLater writes the message to the gofr_outbox table as a part of the transaction, so that it is published to
+// the topic by the outbox relay of the application once the transaction is committed, and is discarded if it is
+// rolled back. The gofr_outbox table is created when the outbox relay is enabled with App.EnableOutboxRelay.
+func (t *Tx) PublishLater(topic string, message []byte) error {
+	if topic == "" {
+		return errOutboxTopicEmpty
+	}
+
+	query := insertOutboxMessage
+	if t.config.Dialect == dialectPostgres {
+		query = insertOutboxMessagePostgres
+	}
+
+	_, err := t.Exec(query, topic, message, time.Now().UTC())
+
+	return err
+}
@@ -0,0 +1,47 @@
+package sql
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTx_PublishLater(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		dialect string
+		query   string
+	}{
+		{"mysql", "mysql", insertOutboxMessage},
+		{"sqlite", "sqlite", insertOutboxMessage},
+		{"postgres", "postgres", insertOutboxMessagePostgres},
+	}
+
+	for i, tc := range testCases {
+		db, mock, _ := NewSQLMocksWithConfig(t, &DBConfig{Dialect: tc.dialect})
+
+		mock.ExpectBegin()
+		mock.ExpectExec(tc.query).WithArgs("orders", []byte(`{"orderId":"1"}`), sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		tx, err := db.Begin()
+		require.NoError(t, err, "TEST[%d], Failed.\n%s", i, tc.desc)
+
+		err = tx.PublishLater("orders", []byte(`{"orderId":"1"}`))
+		require.NoError(t, err, "TEST[%d], Failed.\n%s", i, tc.desc)
+
+		require.NoError(t, mock.ExpectationsWereMet(), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestTx_PublishLater_EmptyTopic(t *testing.T) {
+	db, mock, _ := NewSQLMocks(t)
+
+	mock.ExpectBegin()
+
+	tx, err := db.Begin()
+	require.NoError(t, err)
+
+	require.ErrorIs(t, tx.PublishLater("", []byte("1")), errOutboxTopicEmpty)
+}
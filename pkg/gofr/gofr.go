@@ -8,6 +8,7 @@ import (
 	"os"
 	"os/signal"
 	"path/filepath"
+	"reflect"
 	"strconv"
 	"strings"
 	"sync"
@@ -23,10 +24,13 @@ import (
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
 	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc/reflection"
 
 	"gofr.dev/pkg/gofr/cmd/terminal"
 	"gofr.dev/pkg/gofr/config"
 	"gofr.dev/pkg/gofr/container"
+	"gofr.dev/pkg/gofr/datasource/pubsub"
+	gofr_grpc "gofr.dev/pkg/gofr/grpc"
 	gofrHTTP "gofr.dev/pkg/gofr/http"
 	"gofr.dev/pkg/gofr/http/middleware"
 	"gofr.dev/pkg/gofr/logging"
@@ -61,6 +65,8 @@ type App struct {
 	httpRegistered bool
 
 	subscriptionManager SubscriptionManager
+
+	outbox *outboxRelay
 }
 
 // New creates an HTTP Server Application and returns that App.
@@ -71,6 +77,8 @@ func New() *App {
 
 	app.initTracer()
 
+	app.addHTTPServicesFromConfig()
+
 	// Metrics Server
 	port, err := strconv.Atoi(app.Config.Get("METRICS_PORT"))
 	if err != nil || port <= 0 {
@@ -94,6 +102,11 @@ func New() *App {
 	app.add(http.MethodGet, "/.well-known/alive", liveHandler)
 	app.add(http.MethodGet, "/favicon.ico", faviconHandler)
 
+	// the endpoints controlling the subscriptions are only added when enabled, as they stop the consumption of messages
+	if strings.EqualFold(app.Config.Get("PUBSUB_ENABLE_ADMIN_ENDPOINTS"), "true") {
+		app.addSubscriptionRoutes()
+	}
+
 	// If the openapi.json file exists in the static directory, set up routes for OpenAPI and Swagger documentation.
 	if _, err = os.Stat("./static/" + gofrHTTP.DefaultSwaggerFileName); err == nil {
 		// Route to serve the OpenAPI JSON specification file.
@@ -115,7 +128,15 @@ func New() *App {
 		port = defaultGRPCPort
 	}
 
-	app.grpcServer = newGRPCServer(app.container, port)
+	grpcOptions, err := grpcServerOptions(app.Config)
+
+	app.grpcServer = newGRPCServer(app.container, port, grpcOptions...)
+	app.grpcServer.configErr = err
+
+	// reflection lets tools like grpcurl discover the services, it is meant for lower environments
+	if strings.EqualFold(app.Config.Get("GRPC_ENABLE_REFLECTION"), "true") {
+		reflection.Register(app.grpcServer.server)
+	}
 
 	app.subscriptionManager = newSubscriptionManager(app.container)
 
@@ -141,6 +162,7 @@ func NewCMD() *App {
 	}
 	app.container.Create(app.Config)
 	app.initTracer()
+	app.addHTTPServicesFromConfig()
 
 	return app
 }
@@ -200,6 +222,15 @@ func (a *App) Run() {
 		}(a.grpcServer)
 	}
 
+	if a.outbox != nil {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			a.outbox.run(ctx)
+		}()
+	}
+
 	wg.Add(1)
 
 	go func() {
@@ -277,7 +308,7 @@ func (a *App) httpServerSetup() {
 }
 
 func (a *App) startSubscriptions(ctx context.Context) error {
-	if len(a.subscriptionManager.subscriptions) == 0 {
+	if len(a.subscriptionManager.subscriptions) == 0 && len(a.subscriptionManager.batchSubscriptions) == 0 {
 		return nil
 	}
 
@@ -291,6 +322,14 @@ func (a *App) startSubscriptions(ctx context.Context) error {
 		})
 	}
 
+	for topic, sub := range a.subscriptionManager.batchSubscriptions {
+		subscriberTopic, subscription := topic, sub
+
+		group.Go(func() error {
+			return a.subscriptionManager.startBatchSubscriber(ctx, subscriberTopic, subscription)
+		})
+	}
+
 	return group.Wait()
 }
 
@@ -320,9 +359,30 @@ func (a *App) AddHTTPService(serviceName, serviceAddress string, options ...serv
 		a.container.Debugf("Service already registered Name: %v", serviceName)
 	}
 
+	for _, o := range options {
+		if cacheConfig, ok := o.(*service.CacheConfig); ok {
+			a.setHTTPServiceCacheStore(serviceName, cacheConfig)
+		}
+	}
+
 	a.container.Services[serviceName] = service.NewHTTPService(serviceAddress, a.container.Logger, a.container.Metrics(), options...)
 }
 
+// setHTTPServiceCacheStore uses the Redis of the container as the cache store when the Redis backend is configured.
+func (a *App) setHTTPServiceCacheStore(serviceName string, cacheConfig *service.CacheConfig) {
+	if cacheConfig.Store != nil || cacheConfig.Backend != service.CacheBackendRedis {
+		return
+	}
+
+	if a.container.Redis == nil || reflect.ValueOf(a.container.Redis).IsNil() {
+		a.container.Errorf("redis is not configured, caching responses of service %v in memory", serviceName)
+
+		return
+	}
+
+	cacheConfig.Store = service.NewRedisCacheStore(a.container.Redis)
+}
+
 // GET adds a Handler for HTTP GET method for a route pattern.
 func (a *App) GET(pattern string, handler Handler) {
 	a.add("GET", pattern, handler)
@@ -531,6 +591,8 @@ func (o *otelErrorHandler) Handle(e error) {
 //
 // It takes a variable number of credentials as alternating username and password strings.
 // An error is logged if an odd number of arguments is provided.
+// Both the HTTP requests and the RPCs of the registered gRPC services are authenticated, gRPC clients send the
+// credentials in the "authorization" metadata.
 func (a *App) EnableBasicAuth(credentials ...string) {
 	if len(credentials) == 0 {
 		a.container.Error("No credentials provided for EnableBasicAuth. Proceeding without Authentication")
@@ -548,38 +610,44 @@ func (a *App) EnableBasicAuth(credentials ...string) {
 		users[credentials[i]] = credentials[i+1]
 	}
 
-	a.httpServer.router.Use(middleware.BasicAuthMiddleware(middleware.BasicAuthProvider{Users: users}))
+	a.enableBasicAuth(middleware.BasicAuthProvider{Users: users})
 }
 
 // Deprecated: EnableBasicAuthWithFunc is deprecated and will be removed in future releases, users must use
 // EnableBasicAuthWithValidator as it has access to application datasources.
 func (a *App) EnableBasicAuthWithFunc(validateFunc func(username, password string) bool) {
-	a.httpServer.router.Use(middleware.BasicAuthMiddleware(middleware.BasicAuthProvider{ValidateFunc: validateFunc, Container: a.container}))
+	a.enableBasicAuth(middleware.BasicAuthProvider{ValidateFunc: validateFunc, Container: a.container})
 }
 
-// EnableBasicAuthWithValidator enables basic authentication for the HTTP server with a custom validator.
+// EnableBasicAuthWithValidator enables basic authentication for the HTTP and gRPC servers with a custom validator.
 //
 // The provided `validateFunc` is invoked for each authentication attempt. It receives a container instance,
 // username, and password. The function should return `true` if the credentials are valid, `false` otherwise.
 func (a *App) EnableBasicAuthWithValidator(validateFunc func(c *container.Container, username, password string) bool) {
-	a.httpServer.router.Use(middleware.BasicAuthMiddleware(middleware.BasicAuthProvider{
-		ValidateFuncWithDatasources: validateFunc, Container: a.container}))
+	a.enableBasicAuth(middleware.BasicAuthProvider{ValidateFuncWithDatasources: validateFunc, Container: a.container})
+}
+
+// enableBasicAuth authenticates the HTTP requests and the RPCs of the gRPC services with the given provider.
+func (a *App) enableBasicAuth(provider middleware.BasicAuthProvider) {
+	a.httpServer.router.Use(middleware.BasicAuthMiddleware(provider))
+	a.grpcServer.use(gofr_grpc.BasicAuthInterceptor(provider), gofr_grpc.StreamBasicAuthInterceptor(provider))
 }
 
 // EnableAPIKeyAuth enables API key authentication for the application.
 //
-// It requires at least one API key to be provided. The provided API keys will be used to authenticate requests.
+// It requires at least one API key to be provided. The provided API keys will be used to authenticate requests,
+// as well as the RPCs of the registered gRPC services, for which the key is sent in the "x-api-key" metadata.
 func (a *App) EnableAPIKeyAuth(apiKeys ...string) {
-	a.httpServer.router.Use(middleware.APIKeyAuthMiddleware(middleware.APIKeyAuthProvider{}, apiKeys...))
+	a.enableAPIKeyAuth(middleware.APIKeyAuthProvider{}, apiKeys...)
 }
 
 // Deprecated: EnableAPIKeyAuthWithFunc is deprecated and will be removed in future releases, users must use
 // EnableAPIKeyAuthWithValidator as it has access to application datasources.
 func (a *App) EnableAPIKeyAuthWithFunc(validateFunc func(apiKey string) bool) {
-	a.httpServer.router.Use(middleware.APIKeyAuthMiddleware(middleware.APIKeyAuthProvider{
+	a.enableAPIKeyAuth(middleware.APIKeyAuthProvider{
 		ValidateFunc: validateFunc,
 		Container:    a.container,
-	}))
+	})
 }
 
 // EnableAPIKeyAuthWithValidator enables API key authentication for the application with a custom validation function.
@@ -587,10 +655,17 @@ func (a *App) EnableAPIKeyAuthWithFunc(validateFunc func(apiKey string) bool) {
 // The provided `validateFunc` is used to determine the validity of an API key. It receives the request container
 // and the API key as arguments and should return `true` if the key is valid, `false` otherwise.
 func (a *App) EnableAPIKeyAuthWithValidator(validateFunc func(c *container.Container, apiKey string) bool) {
-	a.httpServer.router.Use(middleware.APIKeyAuthMiddleware(middleware.APIKeyAuthProvider{
+	a.enableAPIKeyAuth(middleware.APIKeyAuthProvider{
 		ValidateFuncWithDatasources: validateFunc,
 		Container:                   a.container,
-	}))
+	})
+}
+
+// enableAPIKeyAuth authenticates the HTTP requests and the RPCs of the gRPC services with the given provider.
+func (a *App) enableAPIKeyAuth(provider middleware.APIKeyAuthProvider, apiKeys ...string) {
+	a.httpServer.router.Use(middleware.APIKeyAuthMiddleware(provider, apiKeys...))
+	a.grpcServer.use(gofr_grpc.APIKeyAuthInterceptor(provider, apiKeys...),
+		gofr_grpc.StreamAPIKeyAuthInterceptor(provider, apiKeys...))
 }
 
 // EnableOAuth configures OAuth middleware for the application.
@@ -600,6 +675,8 @@ func (a *App) EnableAPIKeyAuthWithValidator(validateFunc func(c *container.Conta
 //
 // The JWKS endpoint is used to retrieve JSON Web Key Sets for verifying tokens.
 // The refresh interval specifies how often to refresh the token cache.
+// The RPCs of the registered gRPC services are authenticated with the same keys, gRPC clients send the token
+// in the "authorization" metadata.
 func (a *App) EnableOAuth(jwksEndpoint string, refreshInterval int) {
 	a.AddHTTPService("gofr_oauth", jwksEndpoint)
 
@@ -608,21 +685,73 @@ func (a *App) EnableOAuth(jwksEndpoint string, refreshInterval int) {
 		RefreshInterval: time.Second * time.Duration(refreshInterval),
 	}
 
-	a.httpServer.router.Use(middleware.OAuth(middleware.NewOAuth(oauthOption)))
+	publicKeys := middleware.NewOAuth(oauthOption)
+
+	a.httpServer.router.Use(middleware.OAuth(publicKeys))
+	a.grpcServer.use(gofr_grpc.OAuthInterceptor(publicKeys), gofr_grpc.StreamOAuthInterceptor(publicKeys))
 }
 
 // Subscribe registers a handler for the given topic.
 //
+// Messages are committed when the handler succeeds. The options set up the retries and the dead-letter topic of
+// the messages for which the handler returns an error or panics.
+//
 // If the subscriber is not initialized in the container, an error is logged and
 // the subscription is not registered.
-func (a *App) Subscribe(topic string, handler SubscribeFunc) {
+func (a *App) Subscribe(topic string, handler SubscribeFunc, options ...SubscribeOption) {
 	if a.container.GetSubscriber() == nil {
 		a.container.Logger.Errorf("subscriber not initialized in the container")
 
 		return
 	}
 
+	var opts subscribeOptions
+
+	for _, o := range options {
+		o.apply(&opts)
+	}
+
 	a.subscriptionManager.subscriptions[topic] = handler
+	a.subscriptionManager.options[topic] = opts
+	delete(a.subscriptionManager.batchSubscriptions, topic)
+}
+
+// SubscribeBatch registers a handler for the messages of the topic in batches of up to maxSize messages, the batch
+// is handled once it is full or maxWait has passed since its first message. The messages are committed together
+// once the handler succeeds, the handler can return a PartialBatchError to fail just some of the messages.
+//
+// The batch size and wait configured for the backend, e.g. KAFKA_BATCH_SIZE and KAFKA_SUBSCRIBE_BATCH_WAIT or
+// NATS_BATCH_SIZE and NATS_MAX_WAIT, are used when maxSize or maxWait are zero, or 100 messages and 1s otherwise.
+func (a *App) SubscribeBatch(topic string, handler SubscribeBatchFunc, maxSize int, maxWait time.Duration,
+	options ...SubscribeOption) {
+	if a.container.GetSubscriber() == nil {
+		a.container.Logger.Errorf("subscriber not initialized in the container")
+
+		return
+	}
+
+	var opts subscribeOptions
+
+	for _, o := range options {
+		o.apply(&opts)
+	}
+
+	a.subscriptionManager.batchSubscriptions[topic] = batchSubscription{handler: handler, maxSize: maxSize, maxWait: maxWait}
+	a.subscriptionManager.options[topic] = opts
+	delete(a.subscriptionManager.subscriptions, topic)
+}
+
+// UseCodec sets the codec of the topic, e.g. the Avro codec of the codec package, which decodes its messages in Bind and
+// encodes the messages published to it with the publisher of the container, the messages being the JSON of the values.
+// The values which are not encoded through JSON, e.g. the proto.Message of the Protobuf codec, are encoded with
+// EncodeMessage of the container beforehand, the codecs implementing IsEncoded([]byte) bool then recognize the messages
+// they encoded, while the other messages which are not JSON fail with pubsub.ErrInvalidMessage.
+func (a *App) UseCodec(topic string, codec pubsub.Codec) {
+	if a.container.Codecs == nil {
+		a.container.Codecs = make(map[string]pubsub.Codec)
+	}
+
+	a.container.Codecs[topic] = codec
 }
 
 // AddRESTHandlers creates and registers CRUD routes for the given struct, the struct should always be passed by reference.
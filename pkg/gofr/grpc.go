@@ -6,10 +6,12 @@ import (
 	"net"
 	"reflect"
 	"strconv"
+	"strings"
 
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	grpc_recovery "github.com/grpc-ecosystem/go-grpc-middleware/recovery"
 	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 
 	"gofr.dev/pkg/gofr/container"
 	gofr_grpc "gofr.dev/pkg/gofr/grpc"
@@ -17,23 +19,71 @@ import (
 
 type grpcServer struct {
 	server *grpc.Server
+	health *gofr_grpc.HealthServer
 	port   int
+	// configErr keeps the server from starting when the GRPC_* configs are invalid, e.g. missing certificate files.
+	configErr error
+
+	// interceptors are added after the server is created, e.g. by EnableBasicAuth, and run after logging and metrics.
+	interceptors       []grpc.UnaryServerInterceptor
+	streamInterceptors []grpc.StreamServerInterceptor
+}
+
+func newGRPCServer(c *container.Container, port int, options ...grpc.ServerOption) *grpcServer {
+	g := &grpcServer{port: port}
+
+	g.server = grpc.NewServer(append(options,
+		// recovery is the innermost interceptor, so that panics are logged and measured as internal errors
+		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(
+			gofr_grpc.LoggingInterceptor(c.Logger),
+			gofr_grpc.MetricsInterceptor(c.Metrics()),
+			g.unaryInterceptor,
+			grpcContextInterceptor(c),
+			grpc_recovery.UnaryServerInterceptor(),
+		)),
+		grpc.StreamInterceptor(grpc_middleware.ChainStreamServer(
+			gofr_grpc.StreamLoggingInterceptor(c.Logger),
+			gofr_grpc.StreamMetricsInterceptor(c.Metrics()),
+			g.streamInterceptor,
+			grpcStreamContextInterceptor(c),
+			grpc_recovery.StreamServerInterceptor(),
+		)))...)
+
+	g.health = gofr_grpc.NewHealthServer(c, g.server)
+	healthpb.RegisterHealthServer(g.server, g.health)
+
+	return g
 }
 
-func newGRPCServer(c *container.Container, port int) *grpcServer {
-	return &grpcServer{
-		server: grpc.NewServer(
-			grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(
-				grpc_recovery.UnaryServerInterceptor(),
-				gofr_grpc.LoggingInterceptor(c.Logger),
-			))),
-		port: port,
+// use adds interceptors to the server, they have to be added before the server starts.
+func (g *grpcServer) use(unary grpc.UnaryServerInterceptor, stream grpc.StreamServerInterceptor) {
+	// applications created without a gRPC server, e.g. CMD applications
+	if g == nil {
+		return
 	}
+
+	g.interceptors = append(g.interceptors, unary)
+	g.streamInterceptors = append(g.streamInterceptors, stream)
+}
+
+func (g *grpcServer) unaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+	return grpc_middleware.ChainUnaryServer(g.interceptors...)(ctx, req, info, handler)
+}
+
+func (g *grpcServer) streamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler) error {
+	return grpc_middleware.ChainStreamServer(g.streamInterceptors...)(srv, ss, info, handler)
 }
 
 func (g *grpcServer) Run(c *container.Container) {
 	addr := ":" + strconv.Itoa(g.port)
 
+	if g.configErr != nil {
+		c.Logger.Errorf("error in starting gRPC server at %s: %s", addr, g.configErr)
+		return
+	}
+
 	c.Logger.Infof("starting gRPC server at %s", addr)
 
 	listener, err := net.Listen("tcp", addr)
@@ -50,6 +100,8 @@ func (g *grpcServer) Run(c *container.Container) {
 
 func (g *grpcServer) Shutdown(ctx context.Context) error {
 	return ShutdownWithContext(ctx, func(_ context.Context) error {
+		// health checks report NOT_SERVING while the in-flight RPCs complete, so that clients stop sending new ones
+		g.health.Shutdown()
 		g.server.GracefulStop()
 
 		return nil
@@ -75,6 +127,66 @@ func (a *App) RegisterService(desc *grpc.ServiceDesc, impl any) {
 	}
 
 	a.grpcRegistered = true
+
+	a.addTranscodingRoutes(desc, impl)
+}
+
+// transcodingHeaders are the HTTP headers passed as metadata to the RPCs served over HTTP, along with the headers
+// set in GRPC_TRANSCODING_HEADERS.
+var transcodingHeaders = []string{"Authorization", "Accept-Language", "User-Agent", "X-Correlation-Id", "X-Request-Id"}
+
+// addTranscodingRoutes serves the unary RPCs of the service over HTTP/JSON when GRPC_ENABLE_HTTP_TRANSCODING is set,
+// the requests go through the middlewares of the HTTP server, while the handlers get the same GoFr context as over gRPC.
+func (a *App) addTranscodingRoutes(desc *grpc.ServiceDesc, impl any) {
+	if a.httpServer == nil || !strings.EqualFold(a.Config.Get("GRPC_ENABLE_HTTP_TRANSCODING"), "true") {
+		return
+	}
+
+	headers := append([]string{}, transcodingHeaders...)
+
+	for _, header := range strings.Split(a.Config.Get("GRPC_TRANSCODING_HEADERS"), ",") {
+		if header = strings.TrimSpace(header); header != "" {
+			headers = append(headers, header)
+		}
+	}
+
+	interceptor := grpc_middleware.ChainUnaryServer(grpcContextInterceptor(a.container), grpc_recovery.UnaryServerInterceptor())
+
+	routes := gofr_grpc.TranscodingRoutes(desc, impl, interceptor, headers)
+
+	for _, route := range routes {
+		a.container.Debugf("registering HTTP route %s %s for gRPC service %s", route.Method, route.Pattern, desc.ServiceName)
+		a.httpServer.router.Add(route.Method, route.Pattern, route.Handler)
+	}
+
+	// the HTTP server is only started for the services having unary RPCs to serve
+	if len(routes) > 0 {
+		a.httpRegistered = true
+	}
+}
+
+// AddGRPCService registers a connection to the gRPC service at the given target, e.g. "localhost:9000" or
+// "dns:///customer.internal:9000". The RPCs sent over it are traced and logged, and the service is included in
+// the health checks of the application. The connection is retrieved from the container by name:
+//
+//	client := customer.NewCustomerServiceClient(c.GetGRPCService("customer"))
+func (a *App) AddGRPCService(serviceName, target string, options ...gofr_grpc.ClientOption) {
+	if a.container.GRPCServices == nil {
+		a.container.GRPCServices = make(map[string]container.GRPCService)
+	}
+
+	if _, ok := a.container.GRPCServices[serviceName]; ok {
+		a.container.Debugf("gRPC service already registered Name: %v", serviceName)
+	}
+
+	conn, err := gofr_grpc.NewClientConn(target, a.container.Logger, options...)
+	if err != nil {
+		a.container.Errorf("could not register gRPC service %v: %v", serviceName, err)
+
+		return
+	}
+
+	a.container.GRPCServices[serviceName] = conn
 }
 
 func injectContainer(impl any, c *container.Container) error {
@@ -0,0 +1,157 @@
+package grpc
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"gofr.dev/pkg/gofr/http/middleware"
+)
+
+// unauthenticatedServices are not authenticated, so that health checks and reflection keep working for the
+// clients without credentials.
+var unauthenticatedServices = []string{"/grpc.health.v1.Health/", "/grpc.reflection."}
+
+// authFunc validates the credentials sent in the metadata of an RPC and returns the context carrying the auth info.
+type authFunc func(ctx context.Context, md metadata.MD) (context.Context, error)
+
+// BasicAuthInterceptor authenticates unary RPCs with the credentials sent in the "authorization" metadata
+// as "Basic base64(username:password)". The username is stored in the context just like the HTTP middleware does.
+func BasicAuthInterceptor(provider middleware.BasicAuthProvider) grpc.UnaryServerInterceptor {
+	return unaryAuthInterceptor(basicAuth(provider))
+}
+
+// StreamBasicAuthInterceptor authenticates streaming RPCs the same way as BasicAuthInterceptor.
+func StreamBasicAuthInterceptor(provider middleware.BasicAuthProvider) grpc.StreamServerInterceptor {
+	return streamAuthInterceptor(basicAuth(provider))
+}
+
+// APIKeyAuthInterceptor authenticates unary RPCs with the API key sent in the "x-api-key" metadata.
+func APIKeyAuthInterceptor(provider middleware.APIKeyAuthProvider, apiKeys ...string) grpc.UnaryServerInterceptor {
+	return unaryAuthInterceptor(apiKeyAuth(provider, apiKeys...))
+}
+
+// StreamAPIKeyAuthInterceptor authenticates streaming RPCs the same way as APIKeyAuthInterceptor.
+func StreamAPIKeyAuthInterceptor(provider middleware.APIKeyAuthProvider, apiKeys ...string) grpc.StreamServerInterceptor {
+	return streamAuthInterceptor(apiKeyAuth(provider, apiKeys...))
+}
+
+// OAuthInterceptor authenticates unary RPCs with the JWT sent in the "authorization" metadata as "Bearer {token}",
+// validated against the public keys of the given provider.
+func OAuthInterceptor(key middleware.PublicKeyProvider) grpc.UnaryServerInterceptor {
+	return unaryAuthInterceptor(oAuth(key))
+}
+
+// StreamOAuthInterceptor authenticates streaming RPCs the same way as OAuthInterceptor.
+func StreamOAuthInterceptor(key middleware.PublicKeyProvider) grpc.StreamServerInterceptor {
+	return streamAuthInterceptor(oAuth(key))
+}
+
+func basicAuth(provider middleware.BasicAuthProvider) authFunc {
+	return func(ctx context.Context, md metadata.MD) (context.Context, error) {
+		authHeader := metadataValue(md, "authorization")
+		if authHeader == "" {
+			return nil, status.Error(codes.Unauthenticated, "authorization metadata missing")
+		}
+
+		scheme, credentials, found := strings.Cut(authHeader, " ")
+		if !found || scheme != "Basic" {
+			return nil, status.Error(codes.Unauthenticated, "invalid authorization metadata")
+		}
+
+		payload, err := base64.StdEncoding.DecodeString(credentials)
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, "invalid credentials format")
+		}
+
+		username, password, found := strings.Cut(string(payload), ":")
+		if !found || !provider.Validate(username, password) {
+			return nil, status.Error(codes.Unauthenticated, "invalid username or password")
+		}
+
+		return context.WithValue(ctx, middleware.Username, username), nil
+	}
+}
+
+func apiKeyAuth(provider middleware.APIKeyAuthProvider, apiKeys ...string) authFunc {
+	return func(ctx context.Context, md metadata.MD) (context.Context, error) {
+		apiKey := metadataValue(md, "x-api-key")
+		if apiKey == "" {
+			return nil, status.Error(codes.Unauthenticated, "x-api-key metadata missing")
+		}
+
+		if !provider.Validate(apiKey, apiKeys...) {
+			return nil, status.Error(codes.Unauthenticated, "invalid api key")
+		}
+
+		return context.WithValue(ctx, middleware.APIKey, apiKey), nil
+	}
+}
+
+func oAuth(key middleware.PublicKeyProvider) authFunc {
+	return func(ctx context.Context, md metadata.MD) (context.Context, error) {
+		claims, err := middleware.ValidateToken(metadataValue(md, "authorization"), key)
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+
+		return context.WithValue(ctx, middleware.JWTClaim, claims), nil
+	}
+}
+
+func unaryAuthInterceptor(auth authFunc) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := authenticate(ctx, info.FullMethod, auth)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+func streamAuthInterceptor(auth authFunc) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authenticate(ss.Context(), info.FullMethod, auth)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &authenticatedServerStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+func authenticate(ctx context.Context, fullMethod string, auth authFunc) (context.Context, error) {
+	for _, prefix := range unauthenticatedServices {
+		if strings.HasPrefix(fullMethod, prefix) {
+			return ctx, nil
+		}
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	return auth(ctx, md)
+}
+
+func metadataValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+
+	return ""
+}
+
+// authenticatedServerStream carries the context with the auth info of a streaming RPC.
+type authenticatedServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (a *authenticatedServerStream) Context() context.Context {
+	return a.ctx
+}
@@ -0,0 +1,161 @@
+package grpc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"gofr.dev/pkg/gofr/http/middleware"
+)
+
+func basicCredentials(username, password string) string {
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+password))
+}
+
+func callUnary(interceptor grpc.UnaryServerInterceptor, method string, md metadata.MD) (context.Context, error) {
+	var handlerCtx context.Context
+
+	_, err := interceptor(metadata.NewIncomingContext(context.Background(), md), nil,
+		&grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, _ interface{}) (interface{}, error) {
+			handlerCtx = ctx
+
+			return nil, nil
+		})
+
+	return handlerCtx, err
+}
+
+func TestBasicAuthInterceptor(t *testing.T) {
+	provider := middleware.BasicAuthProvider{Users: map[string]string{"user": "password"}}
+
+	tests := []struct {
+		desc    string
+		md      metadata.MD
+		expCode codes.Code
+	}{
+		{"valid credentials", metadata.Pairs("authorization", basicCredentials("user", "password")), codes.OK},
+		{"missing metadata", metadata.MD{}, codes.Unauthenticated},
+		{"invalid scheme", metadata.Pairs("authorization", "Bearer token"), codes.Unauthenticated},
+		{"invalid encoding", metadata.Pairs("authorization", "Basic invalid-base64"), codes.Unauthenticated},
+		{"invalid password", metadata.Pairs("authorization", basicCredentials("user", "wrong")), codes.Unauthenticated},
+	}
+
+	for i, tc := range tests {
+		ctx, err := callUnary(BasicAuthInterceptor(provider), "/Example/Get", tc.md)
+
+		assert.Equal(t, tc.expCode, status.Code(err), "TEST[%d], Failed.\n%s", i, tc.desc)
+
+		if tc.expCode == codes.OK {
+			assert.Equal(t, "user", ctx.Value(middleware.Username), "TEST[%d], Failed.\n%s", i, tc.desc)
+		}
+	}
+}
+
+func TestAPIKeyAuthInterceptor(t *testing.T) {
+	validator := func(apiKey string) bool { return apiKey == "validated-key" }
+
+	tests := []struct {
+		desc     string
+		provider middleware.APIKeyAuthProvider
+		md       metadata.MD
+		expCode  codes.Code
+	}{
+		{"valid key", middleware.APIKeyAuthProvider{}, metadata.Pairs("x-api-key", "valid-key"), codes.OK},
+		{"missing key", middleware.APIKeyAuthProvider{}, metadata.MD{}, codes.Unauthenticated},
+		{"invalid key", middleware.APIKeyAuthProvider{}, metadata.Pairs("x-api-key", "invalid-key"), codes.Unauthenticated},
+		{"valid key by validator", middleware.APIKeyAuthProvider{ValidateFunc: validator},
+			metadata.Pairs("x-api-key", "validated-key"), codes.OK},
+	}
+
+	for i, tc := range tests {
+		ctx, err := callUnary(APIKeyAuthInterceptor(tc.provider, "valid-key"), "/Example/Get", tc.md)
+
+		assert.Equal(t, tc.expCode, status.Code(err), "TEST[%d], Failed.\n%s", i, tc.desc)
+
+		if tc.expCode == codes.OK {
+			assert.Equal(t, tc.md.Get("x-api-key")[0], ctx.Value(middleware.APIKey), "TEST[%d], Failed.\n%s", i, tc.desc)
+		}
+	}
+}
+
+type mockPublicKeys struct {
+	key *rsa.PublicKey
+}
+
+func (m mockPublicKeys) Get(kid string) *rsa.PublicKey {
+	if kid == "key-1" {
+		return m.key
+	}
+
+	return nil
+}
+
+func TestOAuthInterceptor(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{"sub": "user"})
+	token.Header["kid"] = "key-1"
+
+	signed, err := token.SignedString(privateKey)
+	require.NoError(t, err)
+
+	interceptor := OAuthInterceptor(mockPublicKeys{key: &privateKey.PublicKey})
+
+	ctx, err := callUnary(interceptor, "/Example/Get", metadata.Pairs("authorization", "Bearer "+signed))
+	require.NoError(t, err)
+
+	assert.Equal(t, jwt.MapClaims{"sub": "user"}, ctx.Value(middleware.JWTClaim))
+
+	_, err = callUnary(interceptor, "/Example/Get", metadata.Pairs("authorization", "Bearer invalid"))
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	_, err = callUnary(interceptor, "/Example/Get", metadata.MD{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestAuthInterceptor_SkipsHealthAndReflection(t *testing.T) {
+	interceptor := APIKeyAuthInterceptor(middleware.APIKeyAuthProvider{}, "valid-key")
+
+	for _, method := range []string{"/grpc.health.v1.Health/Check", "/grpc.reflection.v1.ServerReflection/ServerReflectionInfo"} {
+		_, err := callUnary(interceptor, method, metadata.MD{})
+
+		require.NoError(t, err, method)
+	}
+}
+
+func TestStreamAuthInterceptor(t *testing.T) {
+	provider := middleware.BasicAuthProvider{Users: map[string]string{"user": "password"}}
+	info := &grpc.StreamServerInfo{FullMethod: "/Example/Watch"}
+
+	md := metadata.Pairs("authorization", basicCredentials("user", "password"))
+	stream := &mockServerStream{ctx: metadata.NewIncomingContext(context.Background(), md)}
+
+	var username interface{}
+
+	err := StreamBasicAuthInterceptor(provider)(nil, stream, info, func(_ interface{}, ss grpc.ServerStream) error {
+		username = ss.Context().Value(middleware.Username)
+
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "user", username)
+
+	stream = &mockServerStream{ctx: context.Background()}
+
+	err = StreamAPIKeyAuthInterceptor(middleware.APIKeyAuthProvider{}, "valid-key")(nil, stream, info,
+		func(interface{}, grpc.ServerStream) error { return nil })
+
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
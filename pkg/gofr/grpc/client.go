@@ -0,0 +1,357 @@
+package grpc
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"gofr.dev/pkg/gofr/datasource"
+)
+
+const (
+	defaultHealthCheckTimeout = 5 * time.Second
+	defaultRetryAttempts      = 3
+	defaultInitialBackoff     = 100 * time.Millisecond
+	defaultMaxBackoff         = time.Second
+	defaultBackoffMultiplier  = 2
+	statusDown                = "DOWN"
+)
+
+var errInvalidCACert = errors.New("no valid certificates found in CA file")
+
+// ClientOption configures the connection to a gRPC service.
+type ClientOption interface {
+	DialOptions() ([]grpc.DialOption, error)
+}
+
+// TLSConfig secures the connection to a gRPC service with TLS. Connections are insecure (plaintext) unless
+// TLSConfig is given.
+type TLSConfig struct {
+	// CACertFile verifies the certificate of the server, the system certificates are used when it is not set.
+	CACertFile string
+	// CertFile and KeyFile are the client certificate and key, required by servers using mutual TLS.
+	CertFile string
+	KeyFile  string
+	// ServerName overrides the name used to verify the certificate of the server.
+	ServerName string
+}
+
+func (t *TLSConfig) DialOptions() ([]grpc.DialOption, error) {
+	tlsConfig := &tls.Config{ServerName: t.ServerName, MinVersion: tls.VersionTLS12}
+
+	if t.CACertFile != "" {
+		caCert, err := os.ReadFile(t.CACertFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read CA file %v: %w", t.CACertFile, err)
+		}
+
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("%w %v", errInvalidCACert, t.CACertFile)
+		}
+	}
+
+	if t.CertFile != "" || t.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(t.CertFile, t.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load client certificate: %w", err)
+		}
+
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return []grpc.DialOption{grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig))}, nil
+}
+
+// RetryConfig retries the RPCs failing with one of the retryable status codes, waiting with an exponential
+// backoff between the attempts.
+type RetryConfig struct {
+	// MaxAttempts including the original RPC, 3 by default.
+	MaxAttempts int
+	// InitialBackoff is 100ms and MaxBackoff is 1s by default.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// BackoffMultiplier is 2 by default.
+	BackoffMultiplier float64
+	// RetryableCodes is codes.Unavailable by default.
+	RetryableCodes []codes.Code
+}
+
+func (r *RetryConfig) DialOptions() ([]grpc.DialOption, error) {
+	retryableCodes := r.RetryableCodes
+	if len(retryableCodes) == 0 {
+		retryableCodes = []codes.Code{codes.Unavailable}
+	}
+
+	codeNames := make([]string, 0, len(retryableCodes))
+	for _, code := range retryableCodes {
+		codeNames = append(codeNames, serviceConfigCode(code))
+	}
+
+	policy := map[string]interface{}{
+		"maxAttempts":          defaultRetryAttempts,
+		"initialBackoff":       serviceConfigDuration(defaultInitialBackoff),
+		"maxBackoff":           serviceConfigDuration(defaultMaxBackoff),
+		"backoffMultiplier":    defaultBackoffMultiplier,
+		"retryableStatusCodes": codeNames,
+	}
+
+	if r.MaxAttempts > 0 {
+		policy["maxAttempts"] = r.MaxAttempts
+	}
+
+	if r.InitialBackoff > 0 {
+		policy["initialBackoff"] = serviceConfigDuration(r.InitialBackoff)
+	}
+
+	if r.MaxBackoff > 0 {
+		policy["maxBackoff"] = serviceConfigDuration(r.MaxBackoff)
+	}
+
+	if r.BackoffMultiplier > 0 {
+		policy["backoffMultiplier"] = r.BackoffMultiplier
+	}
+
+	serviceConfig, err := json.Marshal(map[string]interface{}{
+		"methodConfig": []interface{}{
+			map[string]interface{}{"name": []interface{}{map[string]string{}}, "retryPolicy": policy},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return []grpc.DialOption{grpc.WithDefaultServiceConfig(string(serviceConfig))}, nil
+}
+
+// KeepaliveConfig pings the server on idle connections, so that broken connections are detected early.
+type KeepaliveConfig struct {
+	// Time after which the server is pinged when there is no activity.
+	Time time.Duration
+	// Timeout after which the connection is closed when the ping is not acknowledged.
+	Timeout time.Duration
+	// PermitWithoutStream pings the server even when there are no active RPCs.
+	PermitWithoutStream bool
+}
+
+func (k *KeepaliveConfig) DialOptions() ([]grpc.DialOption, error) {
+	return []grpc.DialOption{grpc.WithKeepaliveParams(keepalive.ClientParameters{
+		Time:                k.Time,
+		Timeout:             k.Timeout,
+		PermitWithoutStream: k.PermitWithoutStream,
+	})}, nil
+}
+
+// DialOptions are passed as they are to the connection, for the settings not covered by the other options.
+type DialOptions []grpc.DialOption
+
+func (d DialOptions) DialOptions() ([]grpc.DialOption, error) {
+	return d, nil
+}
+
+// ClientConn is a connection to a gRPC service which traces and logs the RPCs. It can be used with the generated
+// clients of the service, e.g. NewCustomerServiceClient(conn).
+type ClientConn struct {
+	*grpc.ClientConn
+
+	target string
+	health healthpb.HealthClient
+}
+
+// NewClientConn creates the connection to the gRPC service at the given target. The connection is established
+// lazily, when the first RPC is sent.
+func NewClientConn(target string, logger Logger, options ...ClientOption) (*ClientConn, error) {
+	dialOptions := []grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(ClientLoggingInterceptor(logger)),
+		grpc.WithChainStreamInterceptor(StreamClientLoggingInterceptor(logger)),
+	}
+
+	for _, o := range options {
+		opts, err := o.DialOptions()
+		if err != nil {
+			return nil, err
+		}
+
+		dialOptions = append(dialOptions, opts...)
+	}
+
+	conn, err := grpc.NewClient(target, dialOptions...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ClientConn{ClientConn: conn, target: target, health: healthpb.NewHealthClient(conn)}, nil
+}
+
+// HealthCheck reports the service as UP when it is serving as per the grpc.health.v1.Health service. Services
+// which do not implement the health service are reported as UP as long as they are reachable.
+func (c *ClientConn) HealthCheck(ctx context.Context) datasource.Health {
+	ctx, cancel := context.WithTimeout(ctx, defaultHealthCheckTimeout)
+	defer cancel()
+
+	health := datasource.Health{
+		Status:  statusUp,
+		Details: map[string]interface{}{"target": c.target},
+	}
+
+	resp, err := c.health.Check(ctx, &healthpb.HealthCheckRequest{})
+
+	switch {
+	case status.Code(err) == codes.Unimplemented:
+		// the service is reachable, but does not implement the health service
+	case err != nil:
+		health.Status = statusDown
+		health.Details["error"] = err.Error()
+	case resp.GetStatus() != healthpb.HealthCheckResponse_SERVING:
+		health.Status = statusDown
+		health.Details["status"] = resp.GetStatus().String()
+	}
+
+	return health
+}
+
+// ClientLoggingInterceptor traces and logs the unary RPCs sent to a service, propagating the trace context
+// in the metadata of the RPCs.
+func ClientLoggingInterceptor(logger Logger) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx, span := startClientSpan(ctx, method)
+		start := time.Now()
+
+		err := invoker(ctx, method, req, reply, cc, opts...)
+
+		endClientSpan(ctx, span, logger, method, start, err)
+
+		return err
+	}
+}
+
+// StreamClientLoggingInterceptor traces and logs the streaming RPCs sent to a service. The RPC is logged once the
+// stream ends, that is when receiving from the stream fails or returns io.EOF, or once the single response of a
+// client-streaming RPC is received.
+func StreamClientLoggingInterceptor(logger Logger) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string,
+		streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		ctx, span := startClientSpan(ctx, method)
+		start := time.Now()
+
+		stream, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			endClientSpan(ctx, span, logger, method, start, err)
+
+			return nil, err
+		}
+
+		return &wrappedClientStream{ClientStream: stream, serverStreams: desc.ServerStreams, end: func(err error) {
+			endClientSpan(ctx, span, logger, method, start, err)
+		}}, nil
+	}
+}
+
+func startClientSpan(ctx context.Context, method string) (context.Context, trace.Span) {
+	ctx, span := otel.GetTracerProvider().Tracer("gofr-grpc-client").Start(ctx, method,
+		trace.WithSpanKind(trace.SpanKindClient))
+
+	md, _ := metadata.FromOutgoingContext(ctx)
+	md = md.Copy()
+
+	otel.GetTextMapPropagator().Inject(ctx, metadataCarrier(md))
+
+	return metadata.NewOutgoingContext(ctx, md), span
+}
+
+func endClientSpan(ctx context.Context, span trace.Span, logger Logger, method string, start time.Time, err error) {
+	l := newRPCLog(ctx, start, method, err)
+
+	span.SetAttributes(attribute.Int("rpc.grpc.status_code", int(l.StatusCode)))
+
+	if err != nil {
+		span.SetStatus(otelcodes.Error, err.Error())
+	}
+
+	span.End()
+
+	if logger != nil {
+		logger.Info(l)
+	}
+}
+
+// wrappedClientStream ends the span of a streaming RPC once the stream ends.
+type wrappedClientStream struct {
+	grpc.ClientStream
+
+	// serverStreams is false for client-streaming RPCs, which end with the single response of the server
+	serverStreams bool
+
+	once sync.Once
+	end  func(err error)
+}
+
+func (w *wrappedClientStream) RecvMsg(m interface{}) error {
+	err := w.ClientStream.RecvMsg(m)
+	if err == nil {
+		if !w.serverStreams {
+			w.once.Do(func() { w.end(nil) })
+		}
+
+		return nil
+	}
+
+	w.once.Do(func() {
+		if errors.Is(err, io.EOF) {
+			w.end(nil)
+
+			return
+		}
+
+		w.end(err)
+	})
+
+	return err
+}
+
+// serviceConfigCode returns the name of a status code as used in service configs, e.g. DEADLINE_EXCEEDED.
+func serviceConfigCode(code codes.Code) string {
+	var name strings.Builder
+
+	previous := rune(0)
+
+	for _, r := range code.String() {
+		if unicode.IsUpper(r) && unicode.IsLower(previous) {
+			name.WriteByte('_')
+		}
+
+		name.WriteRune(unicode.ToUpper(r))
+
+		previous = r
+	}
+
+	return name.String()
+}
+
+func serviceConfigDuration(d time.Duration) string {
+	return fmt.Sprintf("%gs", d.Seconds())
+}
@@ -0,0 +1,226 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+)
+
+type mockLogger struct {
+	logs []interface{}
+}
+
+func (m *mockLogger) Info(args ...interface{}) {
+	m.logs = append(m.logs, args...)
+}
+
+// startTestServer starts a gRPC server serving the given health server, and returns its address along with the
+// metadata of the last RPC it received.
+func startTestServer(t *testing.T, healthServer healthpb.HealthServer) (string, *metadata.MD) {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	var md metadata.MD
+
+	server := grpc.NewServer(grpc.UnaryInterceptor(func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+		md, _ = metadata.FromIncomingContext(ctx)
+
+		return handler(ctx, req)
+	}))
+
+	if healthServer != nil {
+		healthpb.RegisterHealthServer(server, healthServer)
+	}
+
+	go func() {
+		_ = server.Serve(listener)
+	}()
+
+	t.Cleanup(server.Stop)
+
+	return listener.Addr().String(), &md
+}
+
+func TestClientConn_HealthCheck(t *testing.T) {
+	notServing := health.NewServer()
+	notServing.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+
+	servingAddr, _ := startTestServer(t, health.NewServer())
+	notServingAddr, _ := startTestServer(t, notServing)
+	noHealthAddr, _ := startTestServer(t, nil)
+
+	tests := []struct {
+		desc      string
+		target    string
+		expStatus string
+	}{
+		{"serving", servingAddr, statusUp},
+		{"not serving", notServingAddr, statusDown},
+		{"health service not implemented", noHealthAddr, statusUp},
+		{"unreachable", "127.0.0.1:1", statusDown},
+	}
+
+	for i, tc := range tests {
+		conn, err := NewClientConn(tc.target, nil)
+		require.NoError(t, err)
+
+		h := conn.HealthCheck(context.Background())
+
+		assert.Equal(t, tc.expStatus, h.Status, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.target, h.Details["target"], "TEST[%d], Failed.\n%s", i, tc.desc)
+
+		require.NoError(t, conn.Close())
+	}
+}
+
+func TestClientConn_TracesAndLogsRPCs(t *testing.T) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	otel.SetTracerProvider(sdktrace.NewTracerProvider())
+
+	addr, md := startTestServer(t, health.NewServer())
+	logger := &mockLogger{}
+
+	conn, err := NewClientConn(addr, logger, &RetryConfig{}, &KeepaliveConfig{Time: time.Minute},
+		DialOptions{grpc.WithUserAgent("gofr-test")})
+	require.NoError(t, err)
+
+	defer conn.Close()
+
+	client := healthpb.NewHealthClient(conn)
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-tenant", "gofr")
+
+	_, err = client.Check(ctx, &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+
+	assert.NotEmpty(t, md.Get("traceparent"))
+	assert.Equal(t, []string{"gofr"}, md.Get("x-tenant"))
+
+	require.Len(t, logger.logs, 1)
+
+	l, ok := logger.logs[0].(RPCLog)
+	require.True(t, ok)
+
+	assert.Equal(t, "/grpc.health.v1.Health/Check", l.Method)
+	assert.Equal(t, int32(codes.OK), l.StatusCode)
+	assert.Len(t, l.ID, 32)
+}
+
+func TestClientConn_LogsStreamsOnceEnded(t *testing.T) {
+	addr, _ := startTestServer(t, health.NewServer())
+	logger := &mockLogger{}
+
+	conn, err := NewClientConn(addr, logger)
+	require.NoError(t, err)
+
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	stream, err := healthpb.NewHealthClient(conn).Watch(ctx, &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.GetStatus())
+	assert.Empty(t, logger.logs)
+
+	cancel()
+
+	_, err = stream.Recv()
+	require.Error(t, err)
+
+	require.Len(t, logger.logs, 1)
+	assert.Equal(t, int32(codes.Canceled), logger.logs[0].(RPCLog).StatusCode)
+}
+
+// testClientStream answers every message received with an empty response.
+type testClientStream struct {
+	grpc.ClientStream
+}
+
+func (*testClientStream) CloseSend() error { return nil }
+
+func (*testClientStream) RecvMsg(interface{}) error { return nil }
+
+func TestClientConn_LogsClientStreamsOnceAnswered(t *testing.T) {
+	logger := &mockLogger{}
+
+	streamer := func(context.Context, *grpc.StreamDesc, *grpc.ClientConn, string, ...grpc.CallOption) (grpc.ClientStream, error) {
+		return &testClientStream{}, nil
+	}
+
+	// a client-streaming RPC is answered with a single response, received by CloseAndRecv
+	stream, err := StreamClientLoggingInterceptor(logger)(context.Background(), &grpc.StreamDesc{ClientStreams: true},
+		nil, "/Upload/Send", streamer)
+	require.NoError(t, err)
+
+	require.NoError(t, stream.CloseSend())
+	require.NoError(t, stream.RecvMsg(&healthpb.HealthCheckResponse{}))
+
+	require.Len(t, logger.logs, 1)
+	assert.Equal(t, "/Upload/Send", logger.logs[0].(RPCLog).Method)
+	assert.Equal(t, int32(codes.OK), logger.logs[0].(RPCLog).StatusCode)
+
+	// receiving again does not log the RPC twice
+	require.NoError(t, stream.RecvMsg(&healthpb.HealthCheckResponse{}))
+	assert.Len(t, logger.logs, 1)
+}
+
+func TestRetryConfig_DialOptions(t *testing.T) {
+	r := RetryConfig{MaxAttempts: 4, InitialBackoff: 50 * time.Millisecond,
+		RetryableCodes: []codes.Code{codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted}}
+
+	opts, err := r.DialOptions()
+	require.NoError(t, err)
+	assert.Len(t, opts, 1)
+
+	assert.Equal(t, "DEADLINE_EXCEEDED", serviceConfigCode(codes.DeadlineExceeded))
+	assert.Equal(t, "OK", serviceConfigCode(codes.OK))
+	assert.Equal(t, "0.05s", serviceConfigDuration(50*time.Millisecond))
+}
+
+func TestTLSConfig_DialOptions(t *testing.T) {
+	dir := t.TempDir()
+	invalidCA := filepath.Join(dir, "ca.pem")
+
+	require.NoError(t, os.WriteFile(invalidCA, []byte("not a certificate"), 0o600))
+
+	tests := []struct {
+		desc   string
+		config TLSConfig
+		expErr bool
+	}{
+		{"system certificates", TLSConfig{ServerName: "customer.internal"}, false},
+		{"missing CA file", TLSConfig{CACertFile: filepath.Join(dir, "missing.pem")}, true},
+		{"invalid CA file", TLSConfig{CACertFile: invalidCA}, true},
+		{"missing client certificate", TLSConfig{CertFile: filepath.Join(dir, "cert.pem")}, true},
+	}
+
+	for i, tc := range tests {
+		_, err := tc.config.DialOptions()
+
+		assert.Equal(t, tc.expErr, err != nil, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+
+	_, err := NewClientConn("localhost:9000", nil, &TLSConfig{CACertFile: invalidCA})
+	require.ErrorIs(t, err, errInvalidCACert)
+}
@@ -0,0 +1,143 @@
+package grpc
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	healthWatchInterval = 5 * time.Second
+	statusUp            = "UP"
+)
+
+// HealthChecker reports the health of the application along with its datasources and services, keyed by their names.
+type HealthChecker interface {
+	Health(ctx context.Context) interface{}
+}
+
+// HealthServer implements the grpc.health.v1.Health service. The empty service name and the names of the
+// registered gRPC services report the health of the server, while the names of datasources and HTTP services,
+// e.g. "sql" or "redis", report their own health as given by the HealthChecker.
+type HealthServer struct {
+	healthpb.UnimplementedHealthServer
+
+	checker HealthChecker
+	server  *grpc.Server
+
+	shutdown     chan struct{}
+	shutdownOnce sync.Once
+}
+
+// NewHealthServer returns a HealthServer reporting the health of the given server and checker.
+func NewHealthServer(checker HealthChecker, server *grpc.Server) *HealthServer {
+	return &HealthServer{
+		checker:  checker,
+		server:   server,
+		shutdown: make(chan struct{}),
+	}
+}
+
+// Shutdown marks all the services as NOT_SERVING and ends the Watch streams, so that the clients stop sending
+// requests before the server is stopped.
+func (h *HealthServer) Shutdown() {
+	h.shutdownOnce.Do(func() {
+		close(h.shutdown)
+	})
+}
+
+func (h *HealthServer) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	servingStatus := h.servingStatus(ctx, req.GetService())
+	if servingStatus == healthpb.HealthCheckResponse_SERVICE_UNKNOWN {
+		return nil, status.Errorf(codes.NotFound, "unknown service %q", req.GetService())
+	}
+
+	return &healthpb.HealthCheckResponse{Status: servingStatus}, nil
+}
+
+// Watch sends the serving status of the service whenever it changes, until the client cancels the stream or
+// the server shuts down.
+func (h *HealthServer) Watch(req *healthpb.HealthCheckRequest, stream grpc.ServerStreamingServer[healthpb.HealthCheckResponse]) error {
+	ticker := time.NewTicker(healthWatchInterval)
+	defer ticker.Stop()
+
+	lastStatus := healthpb.HealthCheckResponse_ServingStatus(-1)
+
+	for {
+		servingStatus := h.servingStatus(stream.Context(), req.GetService())
+
+		if servingStatus != lastStatus {
+			if err := stream.Send(&healthpb.HealthCheckResponse{Status: servingStatus}); err != nil {
+				return err
+			}
+
+			lastStatus = servingStatus
+		}
+
+		select {
+		case <-stream.Context().Done():
+			return status.FromContextError(stream.Context().Err()).Err()
+		case <-h.shutdown:
+			if lastStatus != healthpb.HealthCheckResponse_NOT_SERVING {
+				return stream.Send(&healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_NOT_SERVING})
+			}
+
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (h *HealthServer) servingStatus(ctx context.Context, service string) healthpb.HealthCheckResponse_ServingStatus {
+	select {
+	case <-h.shutdown:
+		return healthpb.HealthCheckResponse_NOT_SERVING
+	default:
+	}
+
+	if service == "" {
+		return healthpb.HealthCheckResponse_SERVING
+	}
+
+	if _, ok := h.server.GetServiceInfo()[service]; ok {
+		return healthpb.HealthCheckResponse_SERVING
+	}
+
+	health, ok := h.checker.Health(ctx).(map[string]interface{})
+	if !ok {
+		return healthpb.HealthCheckResponse_SERVICE_UNKNOWN
+	}
+
+	// the name, version and status of the application are not health checks of their own
+	if _, isString := health[service].(string); isString || health[service] == nil {
+		return healthpb.HealthCheckResponse_SERVICE_UNKNOWN
+	}
+
+	if healthStatus(health[service]) != statusUp {
+		return healthpb.HealthCheckResponse_NOT_SERVING
+	}
+
+	return healthpb.HealthCheckResponse_SERVING
+}
+
+// healthStatus returns the status of the health of a datasource or a service, which are of different types.
+func healthStatus(health interface{}) string {
+	var h struct {
+		Status string `json:"status"`
+	}
+
+	data, err := json.Marshal(health)
+	if err != nil {
+		return ""
+	}
+
+	_ = json.Unmarshal(data, &h)
+
+	return h.Status
+}
@@ -0,0 +1,137 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+type mockHealthChecker struct {
+	health map[string]interface{}
+}
+
+func (m mockHealthChecker) Health(context.Context) interface{} {
+	return m.health
+}
+
+type healthDetails struct {
+	Status string `json:"status"`
+}
+
+func newTestHealthServer() *HealthServer {
+	checker := mockHealthChecker{health: map[string]interface{}{
+		"sql":     healthDetails{Status: "UP"},
+		"redis":   &healthDetails{Status: "DOWN"},
+		"name":    "test-app",
+		"version": "dev",
+		"status":  "DEGRADED",
+	}}
+
+	server := grpc.NewServer()
+	h := NewHealthServer(checker, server)
+
+	healthpb.RegisterHealthServer(server, h)
+
+	return h
+}
+
+func TestHealthServer_Check(t *testing.T) {
+	h := newTestHealthServer()
+
+	tests := []struct {
+		desc      string
+		service   string
+		expStatus healthpb.HealthCheckResponse_ServingStatus
+		expCode   codes.Code
+	}{
+		{"server health", "", healthpb.HealthCheckResponse_SERVING, codes.OK},
+		{"registered gRPC service", "grpc.health.v1.Health", healthpb.HealthCheckResponse_SERVING, codes.OK},
+		{"healthy datasource", "sql", healthpb.HealthCheckResponse_SERVING, codes.OK},
+		{"unhealthy datasource", "redis", healthpb.HealthCheckResponse_NOT_SERVING, codes.OK},
+		{"app details", "version", healthpb.HealthCheckResponse_UNKNOWN, codes.NotFound},
+		{"unknown service", "mongo", healthpb.HealthCheckResponse_UNKNOWN, codes.NotFound},
+	}
+
+	for i, tc := range tests {
+		resp, err := h.Check(context.Background(), &healthpb.HealthCheckRequest{Service: tc.service})
+
+		assert.Equal(t, tc.expCode, status.Code(err), "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.expStatus, resp.GetStatus(), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestHealthServer_CheckAfterShutdown(t *testing.T) {
+	h := newTestHealthServer()
+
+	h.Shutdown()
+	h.Shutdown()
+
+	for _, service := range []string{"", "sql"} {
+		resp, err := h.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+		require.NoError(t, err)
+
+		assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, resp.GetStatus(), service)
+	}
+}
+
+type mockHealthStream struct {
+	mockServerStream
+	sent chan *healthpb.HealthCheckResponse
+}
+
+func (m *mockHealthStream) Send(resp *healthpb.HealthCheckResponse) error {
+	m.sent <- resp
+
+	return nil
+}
+
+func TestHealthServer_Watch(t *testing.T) {
+	h := newTestHealthServer()
+	stream := &mockHealthStream{
+		mockServerStream: mockServerStream{ctx: context.Background()},
+		sent:             make(chan *healthpb.HealthCheckResponse, 2),
+	}
+
+	done := make(chan error)
+
+	go func() {
+		done <- h.Watch(&healthpb.HealthCheckRequest{Service: "sql"}, stream)
+	}()
+
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, (<-stream.sent).GetStatus())
+
+	h.Shutdown()
+
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, (<-stream.sent).GetStatus())
+
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("Watch did not return on shutdown")
+	}
+}
+
+func TestHealthServer_WatchCancelled(t *testing.T) {
+	h := newTestHealthServer()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	stream := &mockHealthStream{
+		mockServerStream: mockServerStream{ctx: ctx},
+		sent:             make(chan *healthpb.HealthCheckResponse, 1),
+	}
+
+	cancel()
+
+	err := h.Watch(&healthpb.HealthCheckRequest{Service: "unknown"}, stream)
+
+	assert.Equal(t, codes.Canceled, status.Code(err))
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVICE_UNKNOWN, (<-stream.sent).GetStatus())
+}
@@ -6,12 +6,15 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"sync/atomic"
 	"time"
 
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
@@ -20,11 +23,13 @@ type Logger interface {
 }
 
 type RPCLog struct {
-	ID           string `json:"id"`
-	StartTime    string `json:"startTime"`
-	ResponseTime int64  `json:"responseTime"`
-	Method       string `json:"method"`
-	StatusCode   int32  `json:"statusCode"`
+	ID               string `json:"id"`
+	StartTime        string `json:"startTime"`
+	ResponseTime     int64  `json:"responseTime"`
+	Method           string `json:"method"`
+	StatusCode       int32  `json:"statusCode"`
+	MessagesReceived int64  `json:"messagesReceived,omitempty"`
+	MessagesSent     int64  `json:"messagesSent,omitempty"`
 }
 
 func (l RPCLog) PrettyPrint(writer io.Writer) {
@@ -57,30 +62,13 @@ func (l RPCLog) String() string {
 
 func LoggingInterceptor(logger Logger) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-		ctx, span := otel.GetTracerProvider().Tracer("gofr",
-			trace.WithInstrumentationVersion("v0.1")).Start(ctx, info.FullMethod)
+		ctx, span := startServerSpan(ctx, info.FullMethod)
 		start := time.Now()
 
 		resp, err := handler(ctx, req)
 
 		defer func() {
-			l := RPCLog{
-				ID:           trace.SpanFromContext(ctx).SpanContext().TraceID().String(),
-				StartTime:    start.Format("2006-01-02T15:04:05.999999999-07:00"),
-				ResponseTime: time.Since(start).Milliseconds(),
-				Method:       info.FullMethod,
-			}
-
-			if err != nil {
-				// Check if the error is a gRPC status error
-				if statusErr, ok := status.FromError(err); ok {
-					// You can access the gRPC status code here
-					l.StatusCode = int32(statusErr.Code())
-				}
-			} else {
-				// If there was no error, you can access the response status code here
-				l.StatusCode = int32(codes.OK)
-			}
+			l := newRPCLog(ctx, start, info.FullMethod, err)
 
 			if logger != nil {
 				logger.Info(l)
@@ -92,3 +80,123 @@ func LoggingInterceptor(logger Logger) grpc.UnaryServerInterceptor {
 		return resp, err
 	}
 }
+
+// StreamLoggingInterceptor logs the streaming RPCs once the stream is closed, along with the number of messages
+// received and sent over it.
+func StreamLoggingInterceptor(logger Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, span := startServerSpan(ss.Context(), info.FullMethod)
+		start := time.Now()
+
+		stream := &wrappedServerStream{ServerStream: ss, ctx: ctx}
+
+		err := handler(srv, stream)
+
+		l := newRPCLog(ctx, start, info.FullMethod, err)
+		l.MessagesReceived = stream.received.Load()
+		l.MessagesSent = stream.sent.Load()
+
+		span.SetAttributes(
+			attribute.Int64("rpc.messages_received", l.MessagesReceived),
+			attribute.Int64("rpc.messages_sent", l.MessagesSent),
+			attribute.Int("rpc.grpc.status_code", int(l.StatusCode)),
+		)
+
+		if logger != nil {
+			logger.Info(l)
+		}
+
+		span.End()
+
+		return err
+	}
+}
+
+// startServerSpan starts the span of an RPC, as a child of the span propagated through the incoming metadata.
+func startServerSpan(ctx context.Context, fullMethod string) (context.Context, trace.Span) {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		ctx = otel.GetTextMapPropagator().Extract(ctx, metadataCarrier(md))
+	}
+
+	return otel.GetTracerProvider().Tracer("gofr",
+		trace.WithInstrumentationVersion("v0.1")).Start(ctx, fullMethod, trace.WithSpanKind(trace.SpanKindServer))
+}
+
+func newRPCLog(ctx context.Context, start time.Time, fullMethod string, err error) RPCLog {
+	return RPCLog{
+		ID:           trace.SpanFromContext(ctx).SpanContext().TraceID().String(),
+		StartTime:    start.Format("2006-01-02T15:04:05.999999999-07:00"),
+		ResponseTime: time.Since(start).Milliseconds(),
+		Method:       fullMethod,
+		StatusCode:   int32(statusCode(err)),
+	}
+}
+
+// statusCode returns the gRPC status code of the error returned by an RPC.
+func statusCode(err error) codes.Code {
+	if err == nil {
+		return codes.OK
+	}
+
+	if statusErr, ok := status.FromError(err); ok {
+		return statusErr.Code()
+	}
+
+	return codes.Unknown
+}
+
+// wrappedServerStream carries the context of the RPC span and counts the messages received and sent over the stream.
+type wrappedServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+
+	received atomic.Int64
+	sent     atomic.Int64
+}
+
+func (w *wrappedServerStream) Context() context.Context {
+	return w.ctx
+}
+
+func (w *wrappedServerStream) RecvMsg(m interface{}) error {
+	err := w.ServerStream.RecvMsg(m)
+	if err == nil {
+		w.received.Add(1)
+	}
+
+	return err
+}
+
+func (w *wrappedServerStream) SendMsg(m interface{}) error {
+	err := w.ServerStream.SendMsg(m)
+	if err == nil {
+		w.sent.Add(1)
+	}
+
+	return err
+}
+
+// metadataCarrier adapts gRPC metadata to propagate the trace context.
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	if v := metadata.MD(c).Get(key); len(v) > 0 {
+		return v[0]
+	}
+
+	return ""
+}
+
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+
+	for k := range c {
+		keys = append(keys, k)
+	}
+
+	return keys
+}
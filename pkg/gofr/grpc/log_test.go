@@ -3,13 +3,20 @@ package grpc
 import (
 	"context"
 	"errors"
+	"io"
 	"net/http"
 	"os"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 
 	"gofr.dev/pkg/gofr/logging"
 	"gofr.dev/pkg/gofr/testutil"
@@ -124,3 +131,80 @@ func TestRPCLog_PrettyPrint(t *testing.T) {
 	// Check if ID is coming
 	assert.Contains(t, log, `1`)
 }
+
+type mockServerStream struct {
+	grpc.ServerStream
+	ctx      context.Context
+	received []string
+}
+
+func (m *mockServerStream) Context() context.Context {
+	return m.ctx
+}
+
+func (m *mockServerStream) RecvMsg(msg interface{}) error {
+	if len(m.received) == 0 {
+		return io.EOF
+	}
+
+	*(msg.(*string)) = m.received[0]
+	m.received = m.received[1:]
+
+	return nil
+}
+
+func (*mockServerStream) SendMsg(interface{}) error {
+	return nil
+}
+
+func TestStreamLoggingInterceptor(t *testing.T) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	traceID := "4bf92f3577b34da6a3ce929d0e0e4736"
+	md := metadata.Pairs("traceparent", "00-"+traceID+"-00f067aa0ba902b7-01")
+
+	streamErr := status.Error(codes.NotFound, "not found")
+
+	tests := []struct {
+		desc      string
+		handlerFn func(stream grpc.ServerStream) error
+		expLogs   []string
+	}{
+		{"messages are counted", func(stream grpc.ServerStream) error {
+			var msg string
+
+			for stream.RecvMsg(&msg) == nil {
+				_ = stream.SendMsg(msg)
+			}
+
+			return nil
+		}, []string{`"id":"` + traceID + `"`, `"messagesReceived":2`, `"messagesSent":2`, `"statusCode":0`}},
+		{"handler returns error", func(grpc.ServerStream) error {
+			return streamErr
+		}, []string{`"method":"/ExampleService/Chat"`, `"statusCode":5`}},
+	}
+
+	for i, tc := range tests {
+		var err error
+
+		logs := testutil.StdoutOutputForFunc(func() {
+			ss := &mockServerStream{ctx: metadata.NewIncomingContext(context.Background(), md), received: []string{"a", "b"}}
+
+			err = StreamLoggingInterceptor(logging.NewMockLogger(logging.INFO))(nil, ss,
+				&grpc.StreamServerInfo{FullMethod: "/ExampleService/Chat", IsClientStream: true, IsServerStream: true},
+				func(_ interface{}, stream grpc.ServerStream) error {
+					assert.Equal(t, traceID, trace.SpanFromContext(stream.Context()).SpanContext().TraceID().String())
+
+					return tc.handlerFn(stream)
+				})
+		})
+
+		if tc.desc == "handler returns error" {
+			assert.Equal(t, streamErr, err, "TEST[%d], Failed.\n%s", i, tc.desc)
+		}
+
+		for _, expLog := range tc.expLogs {
+			assert.Contains(t, logs, expLog, "TEST[%d], Failed.\n%s", i, tc.desc)
+		}
+	}
+}
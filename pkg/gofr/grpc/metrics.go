@@ -0,0 +1,47 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+type Metrics interface {
+	RecordHistogram(ctx context.Context, name string, value float64, labels ...string)
+}
+
+// MetricsInterceptor records the response time of unary RPCs in the app_grpc_response histogram.
+func MetricsInterceptor(metrics Metrics) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+
+		resp, err := handler(ctx, req)
+
+		recordRPCMetrics(ctx, metrics, info.FullMethod, start, err)
+
+		return resp, err
+	}
+}
+
+// StreamMetricsInterceptor records the duration of streaming RPCs in the app_grpc_response histogram.
+func StreamMetricsInterceptor(metrics Metrics) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+
+		err := handler(srv, ss)
+
+		recordRPCMetrics(ss.Context(), metrics, info.FullMethod, start, err)
+
+		return err
+	}
+}
+
+func recordRPCMetrics(ctx context.Context, metrics Metrics, fullMethod string, start time.Time, err error) {
+	if metrics == nil {
+		return
+	}
+
+	metrics.RecordHistogram(ctx, "app_grpc_response", time.Since(start).Seconds(),
+		"method", fullMethod, "status", statusCode(err).String())
+}
@@ -0,0 +1,63 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type mockMetrics struct {
+	name   string
+	value  float64
+	labels []string
+}
+
+func (m *mockMetrics) RecordHistogram(_ context.Context, name string, value float64, labels ...string) {
+	m.name = name
+	m.value = value
+	m.labels = labels
+}
+
+func TestMetricsInterceptor(t *testing.T) {
+	tests := []struct {
+		desc      string
+		err       error
+		expStatus string
+	}{
+		{"successful RPC", nil, "OK"},
+		{"status error", status.Error(codes.PermissionDenied, "denied"), "PermissionDenied"},
+		{"non status error", errors.New("db error"), "Unknown"}, //nolint:err113 // We are testing if a dynamic error would work
+	}
+
+	for i, tc := range tests {
+		m := &mockMetrics{}
+
+		_, err := MetricsInterceptor(m)(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/Example/Get"},
+			func(context.Context, interface{}) (interface{}, error) { return nil, tc.err })
+
+		assert.Equal(t, tc.err, err, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, "app_grpc_response", m.name, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, []string{"method", "/Example/Get", "status", tc.expStatus}, m.labels, "TEST[%d], Failed.\n%s", i, tc.desc)
+
+		m = &mockMetrics{}
+
+		err = StreamMetricsInterceptor(m)(nil, &mockServerStream{ctx: context.Background()},
+			&grpc.StreamServerInfo{FullMethod: "/Example/Watch"}, func(interface{}, grpc.ServerStream) error { return tc.err })
+
+		assert.Equal(t, tc.err, err, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, []string{"method", "/Example/Watch", "status", tc.expStatus}, m.labels, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestMetricsInterceptor_NilMetrics(t *testing.T) {
+	resp, err := MetricsInterceptor(nil)(context.Background(), "req", &grpc.UnaryServerInfo{FullMethod: "/Example/Get"},
+		func(context.Context, interface{}) (interface{}, error) { return "resp", nil })
+
+	assert.NoError(t, err)
+	assert.Equal(t, "resp", resp)
+}
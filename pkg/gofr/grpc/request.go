@@ -0,0 +1,118 @@
+package grpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+var errNoRequestMessage = errors.New("bind error, streaming RPCs receive their messages from the stream")
+
+// Request is an abstraction over an RPC, exposing the metadata sent by the client as parameters, just like
+// http.Request exposes the query parameters.
+type Request struct {
+	ctx     context.Context
+	method  string
+	message interface{}
+}
+
+// NewRequest creates a new GoFr Request for the RPC of the given context. The message is the request message
+// of unary RPCs and is nil for streaming RPCs.
+func NewRequest(ctx context.Context, fullMethod string, message interface{}) *Request {
+	return &Request{
+		ctx:     ctx,
+		method:  fullMethod,
+		message: message,
+	}
+}
+
+// Context returns the context of the RPC.
+func (r *Request) Context() context.Context {
+	return r.ctx
+}
+
+// Param returns the first value of the metadata with the given key.
+func (r *Request) Param(key string) string {
+	values := metadata.ValueFromIncomingContext(r.ctx, key)
+	if len(values) == 0 {
+		return ""
+	}
+
+	return values[0]
+}
+
+// Params returns the values of the metadata with the given key, splitting the comma separated values.
+// If the metadata is not present, an empty slice is returned.
+func (r *Request) Params(key string) []string {
+	var result []string
+
+	for _, value := range metadata.ValueFromIncomingContext(r.ctx, key) {
+		result = append(result, strings.Split(value, ",")...)
+	}
+
+	return result
+}
+
+// PathParam returns the service or the method name of the RPC for the keys "service" and "method",
+// as RPCs have no other path parameters.
+func (r *Request) PathParam(key string) string {
+	service, method, _ := strings.Cut(strings.TrimPrefix(r.method, "/"), "/")
+
+	switch key {
+	case "service":
+		return service
+	case "method":
+		return method
+	default:
+		return ""
+	}
+}
+
+// Bind copies the request message of a unary RPC to the provided interface, which can be a message of the same
+// type or a struct with JSON tags matching the field names of the message.
+func (r *Request) Bind(i interface{}) error {
+	if r.message == nil {
+		return errNoRequestMessage
+	}
+
+	src, isProto := r.message.(proto.Message)
+
+	if dst, ok := i.(proto.Message); ok && isProto && dst.ProtoReflect().Descriptor() == src.ProtoReflect().Descriptor() {
+		proto.Merge(dst, src)
+
+		return nil
+	}
+
+	var (
+		body []byte
+		err  error
+	)
+
+	if isProto {
+		body, err = protojson.MarshalOptions{UseProtoNames: true}.Marshal(src)
+	} else {
+		body, err = json.Marshal(r.message)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(body, i)
+}
+
+// HostName returns the address of the client which sent the RPC.
+func (r *Request) HostName() string {
+	p, ok := peer.FromContext(r.ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+
+	return p.Addr.String()
+}
@@ -0,0 +1,60 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+func TestRequest_Params(t *testing.T) {
+	md := metadata.Pairs("x-tenant", "gofr", "x-roles", "admin,user", "x-roles", "viewer")
+	r := NewRequest(metadata.NewIncomingContext(context.Background(), md), "/customer.CustomerService/Get", nil)
+
+	assert.Equal(t, "gofr", r.Param("x-tenant"))
+	assert.Equal(t, "gofr", r.Param("X-Tenant"))
+	assert.Empty(t, r.Param("missing"))
+	assert.Equal(t, []string{"admin", "user", "viewer"}, r.Params("x-roles"))
+	assert.Empty(t, r.Params("missing"))
+
+	assert.Equal(t, "customer.CustomerService", r.PathParam("service"))
+	assert.Equal(t, "Get", r.PathParam("method"))
+	assert.Empty(t, r.PathParam("id"))
+}
+
+func TestRequest_HostName(t *testing.T) {
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 5000}})
+
+	assert.Equal(t, "10.0.0.1:5000", NewRequest(ctx, "/Example/Get", nil).HostName())
+	assert.Empty(t, NewRequest(context.Background(), "/Example/Get", nil).HostName())
+}
+
+func TestRequest_Bind(t *testing.T) {
+	r := NewRequest(context.Background(), "/grpc.health.v1.Health/Check", &healthpb.HealthCheckRequest{Service: "sql"})
+
+	var message healthpb.HealthCheckRequest
+
+	require.NoError(t, r.Bind(&message))
+	assert.Equal(t, "sql", message.GetService())
+
+	var data struct {
+		Service string `json:"service"`
+	}
+
+	require.NoError(t, r.Bind(&data))
+	assert.Equal(t, "sql", data.Service)
+
+	r = NewRequest(context.Background(), "/Example/Get", map[string]string{"service": "redis"})
+
+	require.NoError(t, r.Bind(&data))
+	assert.Equal(t, "redis", data.Service)
+
+	r = NewRequest(context.Background(), "/Example/Watch", nil)
+
+	require.ErrorIs(t, r.Bind(&data), errNoRequestMessage)
+}
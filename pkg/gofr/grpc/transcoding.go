@@ -0,0 +1,442 @@
+package grpc
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// statusClientClosedRequest is the non-standard HTTP status code for requests cancelled by the client.
+const statusClientClosedRequest = 499
+
+var (
+	errNotProtoMessage  = errors.New("request message is not a protocol buffer message")
+	errUnknownField     = errors.New("unknown field")
+	errUnsupportedField = errors.New("field cannot be set from a path or query parameter")
+
+	// pathVariable matches the variables of google.api.http path templates, e.g. {name=projects/*}.
+	pathVariable = regexp.MustCompile(`\{([^{}=]+)(?:=([^{}]*))?\}`)
+)
+
+// TranscodingRoute is an HTTP route serving a unary RPC, converting the JSON requests and responses with protojson.
+type TranscodingRoute struct {
+	Method  string
+	Pattern string
+	Handler http.Handler
+}
+
+type httpBinding struct {
+	method string
+	path   string
+	body   string
+}
+
+// TranscodingRoutes returns the HTTP routes serving the unary RPCs of a service. The routes are taken from the
+// google.api.http annotations of the methods, and are POST /package.Service/Method with the message as body for
+// the methods without annotations. Streaming RPCs are not served over HTTP.
+//
+// The interceptor is called for each RPC served over HTTP, just like the interceptors of the gRPC server, and only
+// the given HTTP headers are passed to the RPCs as metadata.
+func TranscodingRoutes(desc *grpc.ServiceDesc, impl interface{}, interceptor grpc.UnaryServerInterceptor,
+	headers []string) []TranscodingRoute {
+	var serviceDesc protoreflect.ServiceDescriptor
+
+	if d, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(desc.ServiceName)); err == nil {
+		serviceDesc, _ = d.(protoreflect.ServiceDescriptor)
+	}
+
+	routes := make([]TranscodingRoute, 0, len(desc.Methods))
+
+	for i := range desc.Methods {
+		method := desc.Methods[i]
+		fullMethod := "/" + desc.ServiceName + "/" + method.MethodName
+
+		for _, b := range httpBindings(serviceDesc, method.MethodName, fullMethod) {
+			routes = append(routes, TranscodingRoute{
+				Method:  b.method,
+				Pattern: muxPattern(b.path),
+				Handler: &transcodingHandler{
+					impl:        impl,
+					method:      method,
+					body:        b.body,
+					interceptor: interceptor,
+					headers:     headers,
+				},
+			})
+		}
+	}
+
+	return routes
+}
+
+// httpBindings returns the bindings of the google.api.http annotation of a method, including the additional ones.
+func httpBindings(serviceDesc protoreflect.ServiceDescriptor, methodName, fullMethod string) []httpBinding {
+	defaultBinding := []httpBinding{{method: http.MethodPost, path: fullMethod, body: "*"}}
+
+	if serviceDesc == nil {
+		return defaultBinding
+	}
+
+	methodDesc := serviceDesc.Methods().ByName(protoreflect.Name(methodName))
+	if methodDesc == nil || !proto.HasExtension(methodDesc.Options(), annotations.E_Http) {
+		return defaultBinding
+	}
+
+	rule, ok := proto.GetExtension(methodDesc.Options(), annotations.E_Http).(*annotations.HttpRule)
+	if !ok || rule == nil {
+		return defaultBinding
+	}
+
+	var bindings []httpBinding
+
+	for _, r := range append([]*annotations.HttpRule{rule}, rule.GetAdditionalBindings()...) {
+		if b, valid := bindingFromRule(r); valid {
+			bindings = append(bindings, b)
+		}
+	}
+
+	if len(bindings) == 0 {
+		return defaultBinding
+	}
+
+	return bindings
+}
+
+func bindingFromRule(rule *annotations.HttpRule) (httpBinding, bool) {
+	b := httpBinding{body: rule.GetBody()}
+
+	switch pattern := rule.GetPattern().(type) {
+	case *annotations.HttpRule_Get:
+		b.method, b.path = http.MethodGet, pattern.Get
+	case *annotations.HttpRule_Put:
+		b.method, b.path = http.MethodPut, pattern.Put
+	case *annotations.HttpRule_Post:
+		b.method, b.path = http.MethodPost, pattern.Post
+	case *annotations.HttpRule_Delete:
+		b.method, b.path = http.MethodDelete, pattern.Delete
+	case *annotations.HttpRule_Patch:
+		b.method, b.path = http.MethodPatch, pattern.Patch
+	case *annotations.HttpRule_Custom:
+		b.method, b.path = pattern.Custom.GetKind(), pattern.Custom.GetPath()
+	default:
+		return b, false
+	}
+
+	return b, b.path != ""
+}
+
+// muxPattern converts a path template of google.api.http to a route pattern, e.g. /v1/{name=shelves/*} to
+// /v1/{name:shelves/[^/]+}.
+func muxPattern(template string) string {
+	return pathVariable.ReplaceAllStringFunc(template, func(variable string) string {
+		match := pathVariable.FindStringSubmatch(variable)
+		if match[2] == "" {
+			return "{" + match[1] + "}"
+		}
+
+		segments := strings.Split(match[2], "/")
+		for i, segment := range segments {
+			switch segment {
+			case "**":
+				segments[i] = ".+"
+			case "*":
+				segments[i] = "[^/]+"
+			default:
+				segments[i] = regexp.QuoteMeta(segment)
+			}
+		}
+
+		return "{" + match[1] + ":" + strings.Join(segments, "/") + "}"
+	})
+}
+
+type transcodingHandler struct {
+	impl        interface{}
+	method      grpc.MethodDesc
+	body        string
+	interceptor grpc.UnaryServerInterceptor
+	headers     []string
+}
+
+func (t *transcodingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := metadata.NewIncomingContext(r.Context(), headerMetadata(r.Header, t.headers))
+	ctx = peer.NewContext(ctx, &peer.Peer{Addr: httpAddr(r.RemoteAddr)})
+
+	decode := func(m interface{}) error {
+		if err := t.decode(r, m); err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		return nil
+	}
+
+	resp, err := t.method.Handler(t.impl, ctx, decode, t.interceptor)
+	if err != nil {
+		writeTranscodingError(w, err)
+
+		return
+	}
+
+	message, ok := resp.(proto.Message)
+	if !ok {
+		writeTranscodingError(w, status.Error(codes.Internal, "response message is not a protocol buffer message"))
+
+		return
+	}
+
+	body, err := protojson.Marshal(message)
+	if err != nil {
+		writeTranscodingError(w, status.Error(codes.Internal, err.Error()))
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(body)
+}
+
+// decode sets the request message from the body, the path variables and, unless the whole message is the body,
+// the query parameters.
+func (t *transcodingHandler) decode(r *http.Request, m interface{}) error {
+	message, ok := m.(proto.Message)
+	if !ok {
+		return errNotProtoMessage
+	}
+
+	if t.body != "" {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			return err
+		}
+
+		if len(body) > 0 {
+			if t.body != "*" {
+				// the body is one of the fields of the message
+				body, err = json.Marshal(map[string]json.RawMessage{t.body: body})
+				if err != nil {
+					return err
+				}
+			}
+
+			if err = protojson.Unmarshal(body, message); err != nil {
+				return err
+			}
+		}
+	}
+
+	for name, value := range mux.Vars(r) {
+		if err := setField(message.ProtoReflect(), name, []string{value}); err != nil {
+			return err
+		}
+	}
+
+	if t.body == "*" {
+		return nil
+	}
+
+	for name, values := range r.URL.Query() {
+		err := setField(message.ProtoReflect(), name, values)
+
+		// query parameters not matching the fields of the message are ignored
+		if err != nil && !errors.Is(err, errUnknownField) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// setField sets the field of the message at the given path, e.g. "book.author", to the given values.
+func setField(message protoreflect.Message, path string, values []string) error {
+	names := strings.Split(path, ".")
+
+	for i, name := range names {
+		fields := message.Descriptor().Fields()
+
+		field := fields.ByName(protoreflect.Name(name))
+		if field == nil {
+			field = fields.ByJSONName(name)
+		}
+
+		if field == nil {
+			return fmt.Errorf("%w %v", errUnknownField, path)
+		}
+
+		if i < len(names)-1 {
+			if field.Kind() != protoreflect.MessageKind || field.IsList() || field.IsMap() {
+				return fmt.Errorf("%w %v", errUnsupportedField, path)
+			}
+
+			message = message.Mutable(field).Message()
+
+			continue
+		}
+
+		if field.IsMap() || field.Kind() == protoreflect.MessageKind || field.Kind() == protoreflect.GroupKind {
+			return fmt.Errorf("%w %v", errUnsupportedField, path)
+		}
+
+		if field.IsList() {
+			list := message.Mutable(field).List()
+
+			for _, v := range values {
+				value, err := parseScalar(field, v)
+				if err != nil {
+					return fmt.Errorf("invalid value %q for %v: %w", v, path, err)
+				}
+
+				list.Append(value)
+			}
+
+			return nil
+		}
+
+		value, err := parseScalar(field, values[len(values)-1])
+		if err != nil {
+			return fmt.Errorf("invalid value %q for %v: %w", values[len(values)-1], path, err)
+		}
+
+		message.Set(field, value)
+	}
+
+	return nil
+}
+
+//nolint:gocyclo // the scalar kinds of protocol buffers are parsed in a single switch
+func parseScalar(field protoreflect.FieldDescriptor, value string) (protoreflect.Value, error) {
+	switch field.Kind() {
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(value), nil
+	case protoreflect.BoolKind:
+		b, err := strconv.ParseBool(value)
+		return protoreflect.ValueOfBool(b), err
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		i, err := strconv.ParseInt(value, 10, 32)
+		return protoreflect.ValueOfInt32(int32(i)), err
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		i, err := strconv.ParseInt(value, 10, 64)
+		return protoreflect.ValueOfInt64(i), err
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		u, err := strconv.ParseUint(value, 10, 32)
+		return protoreflect.ValueOfUint32(uint32(u)), err
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		u, err := strconv.ParseUint(value, 10, 64)
+		return protoreflect.ValueOfUint64(u), err
+	case protoreflect.FloatKind:
+		f, err := strconv.ParseFloat(value, 32)
+		return protoreflect.ValueOfFloat32(float32(f)), err
+	case protoreflect.DoubleKind:
+		f, err := strconv.ParseFloat(value, 64)
+		return protoreflect.ValueOfFloat64(f), err
+	case protoreflect.BytesKind:
+		b, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			b, err = base64.URLEncoding.DecodeString(value)
+		}
+
+		return protoreflect.ValueOfBytes(b), err
+	case protoreflect.EnumKind:
+		if enumValue := field.Enum().Values().ByName(protoreflect.Name(value)); enumValue != nil {
+			return protoreflect.ValueOfEnum(enumValue.Number()), nil
+		}
+
+		i, err := strconv.ParseInt(value, 10, 32)
+
+		return protoreflect.ValueOfEnum(protoreflect.EnumNumber(i)), err
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return protoreflect.Value{}, errUnsupportedField
+	default:
+		return protoreflect.Value{}, errUnsupportedField
+	}
+}
+
+// headerMetadata passes the allowed HTTP headers to the RPC as metadata, the other headers, e.g. cookies, are dropped.
+func headerMetadata(header http.Header, allowed []string) metadata.MD {
+	md := make(metadata.MD, len(allowed))
+
+	for _, key := range allowed {
+		if values := header.Values(key); len(values) > 0 {
+			md.Append(key, values...)
+		}
+	}
+
+	return md
+}
+
+// httpAddr is the address of the client of an RPC served over HTTP.
+type httpAddr string
+
+func (httpAddr) Network() string {
+	return "tcp"
+}
+
+func (a httpAddr) String() string {
+	return string(a)
+}
+
+func writeTranscodingError(w http.ResponseWriter, err error) {
+	s := status.Convert(err)
+
+	body, _ := json.Marshal(map[string]interface{}{
+		"error": map[string]interface{}{
+			"message": s.Message(),
+			"code":    s.Code().String(),
+		},
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(HTTPStatusFromCode(s.Code()))
+	_, _ = w.Write(body)
+}
+
+// HTTPStatusFromCode returns the HTTP status code corresponding to a gRPC status code.
+//
+//nolint:gocyclo // the mapping of each status code is listed in a single switch
+func HTTPStatusFromCode(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		return statusClientClosedRequest
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.Unknown, codes.Internal, codes.DataLoss:
+		return http.StatusInternalServerError
+	default:
+		return http.StatusInternalServerError
+	}
+}
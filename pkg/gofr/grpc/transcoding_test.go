@@ -0,0 +1,306 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+var registerLibrary sync.Once
+
+// libraryFile registers and returns the descriptor of the following service:
+//
+//	message Shelf { string id = 1; }
+//	message GetBookRequest { string name = 1; int32 page = 2; repeated string tags = 3; Shelf shelf = 4; }
+//	message Book { string name = 1; string title = 2; Shelf shelf = 3; }
+//
+//	service Library {
+//	  rpc GetBook(GetBookRequest) returns (Book) {
+//	    option (google.api.http) = {
+//	      get: "/v1/{name=books/*}"
+//	      additional_bindings { post: "/v1/{name=books/*}:fetch" body: "shelf" }
+//	    };
+//	  }
+//	  rpc Echo(Book) returns (Book);
+//	  rpc Watch(GetBookRequest) returns (stream Book);
+//	}
+func libraryFile(t *testing.T) protoreflect.FileDescriptor {
+	t.Helper()
+
+	registerLibrary.Do(func() {
+		field := func(name string, number int32, kind descriptorpb.FieldDescriptorProto_Type,
+			label descriptorpb.FieldDescriptorProto_Label, typeName string) *descriptorpb.FieldDescriptorProto {
+			f := &descriptorpb.FieldDescriptorProto{Name: proto.String(name), Number: proto.Int32(number),
+				Type: kind.Enum(), Label: label.Enum(), JsonName: proto.String(name)}
+			if typeName != "" {
+				f.TypeName = proto.String(typeName)
+			}
+
+			return f
+		}
+
+		optional := descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL
+		str := descriptorpb.FieldDescriptorProto_TYPE_STRING
+		msg := descriptorpb.FieldDescriptorProto_TYPE_MESSAGE
+
+		getBookOptions := &descriptorpb.MethodOptions{}
+		proto.SetExtension(getBookOptions, annotations.E_Http, &annotations.HttpRule{
+			Pattern: &annotations.HttpRule_Get{Get: "/v1/{name=books/*}"},
+			AdditionalBindings: []*annotations.HttpRule{
+				{Pattern: &annotations.HttpRule_Post{Post: "/v1/{name=books/*}:fetch"}, Body: "shelf"},
+			},
+		})
+
+		file := &descriptorpb.FileDescriptorProto{
+			Name:    proto.String("transcoding_test.proto"),
+			Package: proto.String("transcoding.test"),
+			Syntax:  proto.String("proto3"),
+			MessageType: []*descriptorpb.DescriptorProto{
+				{Name: proto.String("Shelf"), Field: []*descriptorpb.FieldDescriptorProto{field("id", 1, str, optional, "")}},
+				{Name: proto.String("GetBookRequest"), Field: []*descriptorpb.FieldDescriptorProto{
+					field("name", 1, str, optional, ""),
+					field("page", 2, descriptorpb.FieldDescriptorProto_TYPE_INT32, optional, ""),
+					field("tags", 3, str, descriptorpb.FieldDescriptorProto_LABEL_REPEATED, ""),
+					field("shelf", 4, msg, optional, ".transcoding.test.Shelf"),
+				}},
+				{Name: proto.String("Book"), Field: []*descriptorpb.FieldDescriptorProto{
+					field("name", 1, str, optional, ""),
+					field("title", 2, str, optional, ""),
+					field("shelf", 3, msg, optional, ".transcoding.test.Shelf"),
+				}},
+			},
+			Service: []*descriptorpb.ServiceDescriptorProto{{
+				Name: proto.String("Library"),
+				Method: []*descriptorpb.MethodDescriptorProto{
+					{Name: proto.String("GetBook"), InputType: proto.String(".transcoding.test.GetBookRequest"),
+						OutputType: proto.String(".transcoding.test.Book"), Options: getBookOptions},
+					{Name: proto.String("Echo"), InputType: proto.String(".transcoding.test.Book"),
+						OutputType: proto.String(".transcoding.test.Book")},
+					{Name: proto.String("Watch"), InputType: proto.String(".transcoding.test.GetBookRequest"),
+						OutputType: proto.String(".transcoding.test.Book"), ServerStreaming: proto.Bool(true)},
+				},
+			}},
+		}
+
+		fd, err := protodesc.NewFile(file, protoregistry.GlobalFiles)
+		if err == nil {
+			err = protoregistry.GlobalFiles.RegisterFile(fd)
+		}
+
+		require.NoError(t, err)
+	})
+
+	fd, err := protoregistry.GlobalFiles.FindFileByPath("transcoding_test.proto")
+	require.NoError(t, err)
+
+	return fd
+}
+
+type library struct {
+	file protoreflect.FileDescriptor
+}
+
+func (l *library) getBook(ctx context.Context, req *dynamicpb.Message) (interface{}, error) {
+	fields := req.Descriptor().Fields()
+	name := req.Get(fields.ByName("name")).String()
+
+	if name == "books/missing" {
+		return nil, status.Error(codes.NotFound, "book not found")
+	}
+
+	var tags []string
+
+	list := req.Get(fields.ByName("tags")).List()
+	for i := range list.Len() {
+		tags = append(tags, list.Get(i).String())
+	}
+
+	book := dynamicpb.NewMessage(l.file.Messages().ByName("Book"))
+	bookFields := book.Descriptor().Fields()
+
+	book.Set(bookFields.ByName("name"), protoreflect.ValueOfString(name))
+	book.Set(bookFields.ByName("title"), protoreflect.ValueOfString(fmt.Sprintf("page %d tags %v tenant %v",
+		req.Get(fields.ByName("page")).Int(), tags, metadata.ValueFromIncomingContext(ctx, "x-tenant"))))
+
+	if req.Has(fields.ByName("shelf")) {
+		book.Set(bookFields.ByName("shelf"), req.Get(fields.ByName("shelf")))
+	}
+
+	return book, nil
+}
+
+func unaryMethod(name string, input protoreflect.MessageDescriptor,
+	handle func(ctx context.Context, req *dynamicpb.Message) (interface{}, error)) grpc.MethodDesc {
+	return grpc.MethodDesc{
+		MethodName: name,
+		Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error,
+			interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+			in := dynamicpb.NewMessage(input)
+			if err := dec(in); err != nil {
+				return nil, err
+			}
+
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				return handle(ctx, req.(*dynamicpb.Message))
+			}
+
+			if interceptor == nil {
+				return handler(ctx, in)
+			}
+
+			return interceptor(ctx, in, &grpc.UnaryServerInfo{Server: srv, FullMethod: "/transcoding.test.Library/" + name}, handler)
+		},
+	}
+}
+
+func newLibraryServer(t *testing.T, interceptor grpc.UnaryServerInterceptor) *httptest.Server {
+	t.Helper()
+
+	file := libraryFile(t)
+	l := &library{file: file}
+
+	desc := &grpc.ServiceDesc{
+		ServiceName: "transcoding.test.Library",
+		Methods: []grpc.MethodDesc{
+			unaryMethod("GetBook", file.Messages().ByName("GetBookRequest"), l.getBook),
+			unaryMethod("Echo", file.Messages().ByName("Book"), func(_ context.Context, req *dynamicpb.Message) (interface{}, error) {
+				return req, nil
+			}),
+		},
+		Streams: []grpc.StreamDesc{{StreamName: "Watch", ServerStreams: true}},
+	}
+
+	router := mux.NewRouter()
+
+	for _, route := range TranscodingRoutes(desc, l, interceptor, []string{"X-Tenant"}) {
+		router.NewRoute().Methods(route.Method).Path(route.Pattern).Handler(route.Handler)
+	}
+
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestTranscodingRoutes(t *testing.T) {
+	server := newLibraryServer(t, nil)
+
+	tests := []struct {
+		desc       string
+		method     string
+		path       string
+		body       string
+		expStatus  int
+		expBody    string
+		expHeaders map[string]string
+	}{
+		{"path and query parameters", http.MethodGet, "/v1/books/go?page=2&tags=a&tags=b&unknown=x", "", http.StatusOK,
+			`{"name":"books/go","title":"page 2 tags [a b] tenant [gofr]"}`, nil},
+		{"error status", http.MethodGet, "/v1/books/missing", "", http.StatusNotFound,
+			`{"error":{"code":"NotFound","message":"book not found"}}`, nil},
+		{"invalid query parameter", http.MethodGet, "/v1/books/go?page=abc", "", http.StatusBadRequest, "", nil},
+		{"path not matching template", http.MethodGet, "/v1/shelves/go", "", http.StatusNotFound, "", nil},
+		{"body field of additional binding", http.MethodPost, "/v1/books/go:fetch", `{"id":"s1"}`, http.StatusOK,
+			`{"name":"books/go","title":"page 0 tags [] tenant [gofr]","shelf":{"id":"s1"}}`, nil},
+		{"default route", http.MethodPost, "/transcoding.test.Library/Echo", `{"name":"go","title":"Go"}`, http.StatusOK,
+			`{"name":"go","title":"Go"}`, nil},
+		{"invalid body", http.MethodPost, "/transcoding.test.Library/Echo", `{"pages":1}`, http.StatusBadRequest, "", nil},
+		{"streaming RPCs are not served", http.MethodPost, "/transcoding.test.Library/Watch", `{}`, http.StatusNotFound, "", nil},
+	}
+
+	for i, tc := range tests {
+		req, err := http.NewRequestWithContext(context.Background(), tc.method, server.URL+tc.path, strings.NewReader(tc.body))
+		require.NoError(t, err)
+
+		req.Header.Set("X-Tenant", "gofr")
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		require.NoError(t, err)
+
+		assert.Equal(t, tc.expStatus, resp.StatusCode, "TEST[%d], Failed.\n%s", i, tc.desc)
+
+		if tc.expBody != "" {
+			assert.JSONEq(t, tc.expBody, string(body), "TEST[%d], Failed.\n%s", i, tc.desc)
+		}
+	}
+}
+
+func TestTranscodingRoutes_Interceptor(t *testing.T) {
+	var (
+		fullMethod string
+		md         metadata.MD
+	)
+
+	server := newLibraryServer(t, func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+		fullMethod = info.FullMethod
+		md, _ = metadata.FromIncomingContext(ctx)
+
+		return handler(ctx, req)
+	})
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, server.URL+"/transcoding.test.Library/Echo",
+		strings.NewReader(`{}`))
+	require.NoError(t, err)
+
+	req.Header.Set("X-Tenant", "gofr")
+	req.Header.Set("Cookie", "session=secret")
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "/transcoding.test.Library/Echo", fullMethod)
+
+	// only the allowed headers are passed as metadata
+	assert.Equal(t, metadata.MD{"x-tenant": {"gofr"}}, md)
+}
+
+func TestMuxPattern(t *testing.T) {
+	tests := []struct {
+		template string
+		expected string
+	}{
+		{"/v1/books/{id}", "/v1/books/{id}"},
+		{"/v1/{name=shelves/*/books/*}", `/v1/{name:shelves/[^/]+/books/[^/]+}`},
+		{"/v1/{path=files/**}:download", `/v1/{path:files/.+}:download`},
+		{"/package.Service/Method", "/package.Service/Method"},
+	}
+
+	for i, tc := range tests {
+		assert.Equal(t, tc.expected, muxPattern(tc.template), "TEST[%d], Failed.\n%s", i, tc.template)
+	}
+}
+
+func TestHTTPStatusFromCode(t *testing.T) {
+	assert.Equal(t, http.StatusOK, HTTPStatusFromCode(codes.OK))
+	assert.Equal(t, http.StatusUnauthorized, HTTPStatusFromCode(codes.Unauthenticated))
+	assert.Equal(t, http.StatusServiceUnavailable, HTTPStatusFromCode(codes.Unavailable))
+	assert.Equal(t, statusClientClosedRequest, HTTPStatusFromCode(codes.Canceled))
+	assert.Equal(t, http.StatusInternalServerError, HTTPStatusFromCode(codes.Code(100)))
+}
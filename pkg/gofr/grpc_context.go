@@ -0,0 +1,150 @@
+package gofr
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+
+	"gofr.dev/pkg/gofr/container"
+	gofr_grpc "gofr.dev/pkg/gofr/grpc"
+	"gofr.dev/pkg/gofr/logging"
+)
+
+type grpcContextKey struct{}
+
+// GRPCContext returns the GoFr context of the RPC being served, giving the gRPC handlers the same capabilities as
+// the HTTP handlers: Trace, GetAuthInfo, the metadata as Param and a logger adding the trace ID to the logs.
+// It returns nil when the context does not belong to an RPC served by GoFr.
+//
+//	func (s *Server) SayHello(ctx context.Context, req *HelloRequest) (*HelloResponse, error) {
+//		c := gofr.GRPCContext(ctx)
+//		c.Info("saying hello to ", req.Name)
+//		...
+//	}
+func GRPCContext(ctx context.Context) *Context {
+	c, _ := ctx.Value(grpcContextKey{}).(*Context)
+
+	return c
+}
+
+// grpcContextInterceptor adds the GoFr context of each unary RPC to the context passed to the handler.
+func grpcContextInterceptor(c *container.Container) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(withGRPCContext(ctx, gofr_grpc.NewRequest(ctx, info.FullMethod, req), c), req)
+	}
+}
+
+// grpcStreamContextInterceptor adds the GoFr context of each streaming RPC to the context of the stream.
+func grpcStreamContextInterceptor(c *container.Container) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := withGRPCContext(ss.Context(), gofr_grpc.NewRequest(ss.Context(), info.FullMethod, nil), c)
+
+		return handler(srv, &grpcContextServerStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+func withGRPCContext(ctx context.Context, r Request, c *container.Container) context.Context {
+	gofrCtx := newContext(nil, r, c)
+
+	if spanContext := trace.SpanFromContext(ctx).SpanContext(); spanContext.HasTraceID() {
+		gofrCtx.logger = &tracedLogger{Logger: c.Logger, traceID: spanContext.TraceID().String()}
+	}
+
+	return context.WithValue(ctx, grpcContextKey{}, gofrCtx)
+}
+
+type grpcContextServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (g *grpcContextServerStream) Context() context.Context {
+	return g.ctx
+}
+
+// TracedLogEntry is a log message along with the trace ID of the request it was logged for.
+type TracedLogEntry struct {
+	TraceID string      `json:"trace_id,omitempty"`
+	Message interface{} `json:"message"`
+}
+
+func (t *TracedLogEntry) PrettyPrint(writer io.Writer) {
+	fmt.Fprintf(writer, "\u001B[38;5;8m%s\u001B[0m %v\n", t.TraceID, t.Message)
+}
+
+// tracedLogger adds the trace ID of a request to its logs.
+type tracedLogger struct {
+	logging.Logger
+	traceID string
+}
+
+func (t *tracedLogger) entry(args []interface{}) *TracedLogEntry {
+	if len(args) == 1 {
+		return &TracedLogEntry{TraceID: t.traceID, Message: args[0]}
+	}
+
+	return &TracedLogEntry{TraceID: t.traceID, Message: fmt.Sprint(args...)}
+}
+
+func (t *tracedLogger) entryf(format string, args []interface{}) *TracedLogEntry {
+	return &TracedLogEntry{TraceID: t.traceID, Message: fmt.Sprintf(format, args...)}
+}
+
+func (t *tracedLogger) Debug(args ...interface{}) {
+	t.Logger.Debug(t.entry(args))
+}
+
+func (t *tracedLogger) Debugf(format string, args ...interface{}) {
+	t.Logger.Debug(t.entryf(format, args))
+}
+
+func (t *tracedLogger) Log(args ...interface{}) {
+	t.Logger.Log(t.entry(args))
+}
+
+func (t *tracedLogger) Logf(format string, args ...interface{}) {
+	t.Logger.Log(t.entryf(format, args))
+}
+
+func (t *tracedLogger) Info(args ...interface{}) {
+	t.Logger.Info(t.entry(args))
+}
+
+func (t *tracedLogger) Infof(format string, args ...interface{}) {
+	t.Logger.Info(t.entryf(format, args))
+}
+
+func (t *tracedLogger) Notice(args ...interface{}) {
+	t.Logger.Notice(t.entry(args))
+}
+
+func (t *tracedLogger) Noticef(format string, args ...interface{}) {
+	t.Logger.Notice(t.entryf(format, args))
+}
+
+func (t *tracedLogger) Warn(args ...interface{}) {
+	t.Logger.Warn(t.entry(args))
+}
+
+func (t *tracedLogger) Warnf(format string, args ...interface{}) {
+	t.Logger.Warn(t.entryf(format, args))
+}
+
+func (t *tracedLogger) Error(args ...interface{}) {
+	t.Logger.Error(t.entry(args))
+}
+
+func (t *tracedLogger) Errorf(format string, args ...interface{}) {
+	t.Logger.Error(t.entryf(format, args))
+}
+
+func (t *tracedLogger) Fatal(args ...interface{}) {
+	t.Logger.Fatal(t.entry(args))
+}
+
+func (t *tracedLogger) Fatalf(format string, args ...interface{}) {
+	t.Logger.Fatal(t.entryf(format, args))
+}
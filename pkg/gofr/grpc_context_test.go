@@ -0,0 +1,93 @@
+package gofr
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"gofr.dev/pkg/gofr/container"
+	"gofr.dev/pkg/gofr/http/middleware"
+	"gofr.dev/pkg/gofr/logging"
+	"gofr.dev/pkg/gofr/testutil"
+)
+
+func TestGRPCContext(t *testing.T) {
+	c := &container.Container{Logger: logging.NewMockLogger(logging.DEBUG)}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-tenant", "gofr"))
+	ctx = context.WithValue(ctx, middleware.Username, "user")
+
+	var gofrCtx *Context
+
+	_, err := grpcContextInterceptor(c)(ctx, "req", &grpc.UnaryServerInfo{FullMethod: "/Example/Get"},
+		func(ctx context.Context, _ interface{}) (interface{}, error) {
+			gofrCtx = GRPCContext(ctx)
+
+			return nil, nil
+		})
+
+	require.NoError(t, err)
+	require.NotNil(t, gofrCtx)
+
+	assert.Equal(t, "gofr", gofrCtx.Param("x-tenant"))
+	assert.Equal(t, "Get", gofrCtx.PathParam("method"))
+	assert.Equal(t, "user", gofrCtx.GetAuthInfo().GetUsername())
+	assert.Same(t, c, gofrCtx.Container)
+
+	var bound string
+
+	require.NoError(t, gofrCtx.Bind(&bound))
+	assert.Equal(t, "req", bound)
+
+	assert.Nil(t, GRPCContext(context.Background()))
+}
+
+func TestGRPCContext_Stream(t *testing.T) {
+	c := &container.Container{Logger: logging.NewMockLogger(logging.DEBUG)}
+
+	var gofrCtx *Context
+
+	err := grpcStreamContextInterceptor(c)(nil, &testServerStream{ctx: context.Background()},
+		&grpc.StreamServerInfo{FullMethod: "/Example/Watch"}, func(_ interface{}, ss grpc.ServerStream) error {
+			gofrCtx = GRPCContext(ss.Context())
+
+			return nil
+		})
+
+	require.NoError(t, err)
+	require.NotNil(t, gofrCtx)
+
+	assert.Equal(t, "Watch", gofrCtx.PathParam("method"))
+}
+
+func TestGRPCContext_LogsTraceID(t *testing.T) {
+	ctx, span := sdktrace.NewTracerProvider().Tracer("test").Start(context.Background(), "rpc")
+	defer span.End()
+
+	traceID := span.SpanContext().TraceID().String()
+
+	logs := testutil.StdoutOutputForFunc(func() {
+		c := &container.Container{Logger: logging.NewLogger(logging.INFO)}
+
+		_, _ = grpcContextInterceptor(c)(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/Example/Get"},
+			func(ctx context.Context, _ interface{}) (interface{}, error) {
+				gofrCtx := GRPCContext(ctx)
+				gofrCtx.Infof("fetching customer %d", 1)
+
+				// the container, along with its logger, is shared with the application
+				assert.Same(t, c, gofrCtx.Container)
+
+				return nil, nil
+			})
+
+		c.Info("application log")
+	})
+
+	assert.Contains(t, logs, `"trace_id":"`+traceID+`","message":"fetching customer 1"`)
+	assert.Contains(t, logs, `"message":"application log"`)
+}
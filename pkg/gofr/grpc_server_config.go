@@ -0,0 +1,176 @@
+package gofr
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/keepalive"
+
+	"gofr.dev/pkg/gofr/config"
+)
+
+// defaultKeepaliveMinTime is the minimum interval between the pings of the clients as enforced by grpc-go.
+const defaultKeepaliveMinTime = 5 * time.Minute
+
+var (
+	errIncompleteGRPCKeyPair = errors.New("GRPC_CERT_FILE and GRPC_KEY_FILE have to be set together")
+	errClientCAWithoutTLS    = errors.New("GRPC_CLIENT_CA_FILE requires GRPC_CERT_FILE and GRPC_KEY_FILE to be set")
+	errInvalidClientCAFile   = errors.New("invalid client CA file")
+)
+
+// grpcServerOptions returns the options of the gRPC server as per the GRPC_* configs, the options which are not set
+// keep the defaults of grpc-go.
+func grpcServerOptions(conf config.Config) ([]grpc.ServerOption, error) {
+	get := func(key string) string {
+		return strings.TrimSpace(conf.Get(key))
+	}
+
+	var options []grpc.ServerOption
+
+	tlsOption, err := grpcTLSOption(get("GRPC_CERT_FILE"), get("GRPC_KEY_FILE"), get("GRPC_CLIENT_CA_FILE"))
+	if err != nil {
+		return nil, err
+	}
+
+	if tlsOption != nil {
+		options = append(options, tlsOption)
+	}
+
+	limits := []struct {
+		key    string
+		option func(int) grpc.ServerOption
+	}{
+		{"GRPC_MAX_RECV_MSG_SIZE", grpc.MaxRecvMsgSize},
+		{"GRPC_MAX_SEND_MSG_SIZE", grpc.MaxSendMsgSize},
+		{"GRPC_MAX_CONCURRENT_STREAMS", func(i int) grpc.ServerOption { return grpc.MaxConcurrentStreams(uint32(i)) }},
+	}
+
+	for _, l := range limits {
+		value, limitErr := intFromConfig(get(l.key), l.key)
+		if limitErr != nil {
+			return nil, limitErr
+		}
+
+		if value > 0 {
+			options = append(options, l.option(value))
+		}
+	}
+
+	keepaliveOptions, err := grpcKeepaliveOptions(get)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(options, keepaliveOptions...), nil
+}
+
+// grpcTLSOption serves the RPCs over TLS when the certificate and key are set, requiring the clients to present
+// a certificate signed by the client CA when it is set as well.
+func grpcTLSOption(certFile, keyFile, clientCAFile string) (grpc.ServerOption, error) {
+	if certFile == "" && keyFile == "" {
+		if clientCAFile != "" {
+			return nil, errClientCAWithoutTLS
+		}
+
+		return nil, nil
+	}
+
+	if certFile == "" || keyFile == "" {
+		return nil, errIncompleteGRPCKeyPair
+	}
+
+	if err := validateCertificateAndKeyFiles(certFile, keyFile); err != nil {
+		return nil, err
+	}
+
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load the gRPC server certificate: %w", err)
+	}
+
+	tlsConfig := &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12}
+
+	if clientCAFile != "" {
+		caCert, readErr := os.ReadFile(clientCAFile)
+		if readErr != nil {
+			return nil, fmt.Errorf("%w : %v", errInvalidClientCAFile, clientCAFile)
+		}
+
+		tlsConfig.ClientCAs = x509.NewCertPool()
+		if !tlsConfig.ClientCAs.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("%w : %v", errInvalidClientCAFile, clientCAFile)
+		}
+
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return grpc.Creds(credentials.NewTLS(tlsConfig)), nil
+}
+
+// grpcKeepaliveOptions returns the keepalive parameters of the server and the policy enforced on the pings of the
+// clients, only when any of them is configured.
+func grpcKeepaliveOptions(get func(key string) string) ([]grpc.ServerOption, error) {
+	var (
+		params        keepalive.ServerParameters
+		paramsSet     bool
+		policy        = keepalive.EnforcementPolicy{MinTime: defaultKeepaliveMinTime}
+		policySet     bool
+		durationsKeys = []struct {
+			key   string
+			value *time.Duration
+			set   *bool
+		}{
+			{"GRPC_KEEPALIVE_TIME", &params.Time, &paramsSet},
+			{"GRPC_KEEPALIVE_TIMEOUT", &params.Timeout, &paramsSet},
+			{"GRPC_MAX_CONNECTION_IDLE", &params.MaxConnectionIdle, &paramsSet},
+			{"GRPC_MAX_CONNECTION_AGE", &params.MaxConnectionAge, &paramsSet},
+			{"GRPC_MAX_CONNECTION_AGE_GRACE", &params.MaxConnectionAgeGrace, &paramsSet},
+			{"GRPC_KEEPALIVE_MIN_TIME", &policy.MinTime, &policySet},
+		}
+	)
+
+	for _, d := range durationsKeys {
+		value := get(d.key)
+		if value == "" {
+			continue
+		}
+
+		duration, err := time.ParseDuration(value)
+		if err != nil || duration <= 0 {
+			return nil, fmt.Errorf("invalid value %q for %v, expected a duration like 30s", value, d.key)
+		}
+
+		*d.value = duration
+		*d.set = true
+	}
+
+	if value := get("GRPC_KEEPALIVE_PERMIT_WITHOUT_STREAM"); value != "" {
+		permit, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %q for GRPC_KEEPALIVE_PERMIT_WITHOUT_STREAM, expected true or false", value)
+		}
+
+		policy.PermitWithoutStream = permit
+		policySet = true
+	}
+
+	var options []grpc.ServerOption
+
+	if paramsSet {
+		options = append(options, grpc.KeepaliveParams(params))
+	}
+
+	if policySet {
+		options = append(options, grpc.KeepaliveEnforcementPolicy(policy))
+	}
+
+	return options, nil
+}
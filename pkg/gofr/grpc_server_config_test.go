@@ -0,0 +1,194 @@
+package gofr
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gofr.dev/pkg/gofr/config"
+	"gofr.dev/pkg/gofr/container"
+	gofr_grpc "gofr.dev/pkg/gofr/grpc"
+	"gofr.dev/pkg/gofr/logging"
+	"gofr.dev/pkg/gofr/testutil"
+)
+
+func TestGRPCServerOptions(t *testing.T) {
+	certs := writeTestCertificates(t)
+
+	testCases := []struct {
+		desc       string
+		configs    map[string]string
+		expOptions int
+		expErr     string
+	}{
+		{"defaults", map[string]string{}, 0, ""},
+		{"tls", map[string]string{"GRPC_CERT_FILE": certs.serverCert, "GRPC_KEY_FILE": certs.serverKey}, 1, ""},
+		{"mutual tls", map[string]string{"GRPC_CERT_FILE": certs.serverCert, "GRPC_KEY_FILE": certs.serverKey,
+			"GRPC_CLIENT_CA_FILE": certs.ca}, 1, ""},
+		{"limits", map[string]string{"GRPC_MAX_RECV_MSG_SIZE": "8388608", "GRPC_MAX_SEND_MSG_SIZE": "8388608",
+			"GRPC_MAX_CONCURRENT_STREAMS": "100"}, 3, ""},
+		{"keepalive and connection age", map[string]string{"GRPC_KEEPALIVE_TIME": "1m", "GRPC_KEEPALIVE_TIMEOUT": "10s",
+			"GRPC_MAX_CONNECTION_AGE": "30m", "GRPC_MAX_CONNECTION_AGE_GRACE": "1m"}, 1, ""},
+		{"keepalive enforcement policy", map[string]string{"GRPC_KEEPALIVE_MIN_TIME": "10s",
+			"GRPC_KEEPALIVE_PERMIT_WITHOUT_STREAM": "true"}, 1, ""},
+		{"missing certificate file", map[string]string{"GRPC_CERT_FILE": "missing.pem", "GRPC_KEY_FILE": certs.serverKey}, 0,
+			"invalid certificate file : missing.pem"},
+		{"missing key file", map[string]string{"GRPC_CERT_FILE": certs.serverCert}, 0,
+			"GRPC_CERT_FILE and GRPC_KEY_FILE have to be set together"},
+		{"client CA without tls", map[string]string{"GRPC_CLIENT_CA_FILE": certs.ca}, 0,
+			"GRPC_CLIENT_CA_FILE requires GRPC_CERT_FILE and GRPC_KEY_FILE to be set"},
+		{"invalid client CA", map[string]string{"GRPC_CERT_FILE": certs.serverCert, "GRPC_KEY_FILE": certs.serverKey,
+			"GRPC_CLIENT_CA_FILE": certs.serverKey}, 0, "invalid client CA file : " + certs.serverKey},
+		{"invalid message size", map[string]string{"GRPC_MAX_RECV_MSG_SIZE": "8MB"}, 0,
+			`invalid value "8MB" for GRPC_MAX_RECV_MSG_SIZE, expected a non-negative number`},
+		{"invalid duration", map[string]string{"GRPC_MAX_CONNECTION_AGE": "30"}, 0,
+			`invalid value "30" for GRPC_MAX_CONNECTION_AGE, expected a duration like 30s`},
+		{"invalid bool", map[string]string{"GRPC_KEEPALIVE_PERMIT_WITHOUT_STREAM": "yes"}, 0,
+			`invalid value "yes" for GRPC_KEEPALIVE_PERMIT_WITHOUT_STREAM, expected true or false`},
+	}
+
+	for i, tc := range testCases {
+		options, err := grpcServerOptions(config.NewMockConfig(tc.configs))
+
+		if tc.expErr != "" {
+			require.EqualError(t, err, tc.expErr, "TEST[%d], Failed.\n%s", i, tc.desc)
+
+			continue
+		}
+
+		require.NoError(t, err, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Len(t, options, tc.expOptions, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestGRPC_ServerMutualTLS(t *testing.T) {
+	certs := writeTestCertificates(t)
+
+	options, err := grpcServerOptions(config.NewMockConfig(map[string]string{
+		"GRPC_CERT_FILE":      certs.serverCert,
+		"GRPC_KEY_FILE":       certs.serverKey,
+		"GRPC_CLIENT_CA_FILE": certs.ca,
+	}))
+	require.NoError(t, err)
+
+	g := newGRPCServer(&container.Container{Logger: logging.NewMockLogger(logging.ERROR)}, 0, options...)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	go func() { _ = g.server.Serve(listener) }()
+
+	t.Cleanup(g.server.Stop)
+
+	testCases := []struct {
+		desc      string
+		tlsConfig *gofr_grpc.TLSConfig
+		expStatus string
+	}{
+		{"client certificate signed by the client CA", &gofr_grpc.TLSConfig{CACertFile: certs.ca, CertFile: certs.clientCert,
+			KeyFile: certs.clientKey, ServerName: "localhost"}, "UP"},
+		{"no client certificate", &gofr_grpc.TLSConfig{CACertFile: certs.ca, ServerName: "localhost"}, "DOWN"},
+	}
+
+	for i, tc := range testCases {
+		conn, err := gofr_grpc.NewClientConn(listener.Addr().String(), nil, tc.tlsConfig)
+		require.NoError(t, err)
+
+		assert.Equal(t, tc.expStatus, conn.HealthCheck(context.Background()).Status, "TEST[%d], Failed.\n%s", i, tc.desc)
+
+		require.NoError(t, conn.Close())
+	}
+}
+
+func TestGRPC_ServerRun_InvalidConfig(t *testing.T) {
+	out := testutil.StderrOutputForFunc(func() {
+		c := container.Container{Logger: logging.NewLogger(logging.ERROR)}
+
+		g := newGRPCServer(&c, 9999)
+		g.configErr = errIncompleteGRPCKeyPair
+
+		g.Run(&c)
+	})
+
+	assert.Contains(t, out, "GRPC_CERT_FILE and GRPC_KEY_FILE have to be set together")
+}
+
+type testCertificates struct {
+	ca, serverCert, serverKey, clientCert, clientKey string
+}
+
+// writeTestCertificates writes a CA along with a server and a client certificate signed by it.
+func writeTestCertificates(t *testing.T) testCertificates {
+	t.Helper()
+
+	dir := t.TempDir()
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "gofr-test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	require.NoError(t, err)
+
+	certs := testCertificates{ca: writePEM(t, dir, "ca.pem", "CERTIFICATE", caDER)}
+
+	issue := func(name string, serial int64, usage x509.ExtKeyUsage) (certFile, keyFile string) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(serial),
+			Subject:      pkix.Name{CommonName: name},
+			DNSNames:     []string{"localhost"},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(time.Hour),
+			KeyUsage:     x509.KeyUsageDigitalSignature,
+			ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+		}
+
+		der, err := x509.CreateCertificate(rand.Reader, template, caTemplate, &key.PublicKey, caKey)
+		require.NoError(t, err)
+
+		keyDER, err := x509.MarshalECPrivateKey(key)
+		require.NoError(t, err)
+
+		return writePEM(t, dir, name+".pem", "CERTIFICATE", der), writePEM(t, dir, name+"-key.pem", "EC PRIVATE KEY", keyDER)
+	}
+
+	certs.serverCert, certs.serverKey = issue("server", 2, x509.ExtKeyUsageServerAuth)
+	certs.clientCert, certs.clientKey = issue("client", 3, x509.ExtKeyUsageClientAuth)
+
+	return certs
+}
+
+func writePEM(t *testing.T, dir, name, blockType string, der []byte) string {
+	t.Helper()
+
+	path := filepath.Join(dir, name)
+
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600))
+
+	return path
+}
@@ -8,8 +8,14 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 
 	"gofr.dev/pkg/gofr/container"
+	"gofr.dev/pkg/gofr/datasource"
+	gofr_grpc "gofr.dev/pkg/gofr/grpc"
 	"gofr.dev/pkg/gofr/logging"
 	"gofr.dev/pkg/gofr/testutil"
 )
@@ -163,3 +169,124 @@ func Test_injectContainer(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, srv3.C)
 }
+
+func TestGRPC_EnableAPIKeyAuth(t *testing.T) {
+	app := New()
+	app.EnableAPIKeyAuth("valid-key")
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/Example/Get"}
+	handler := func(ctx context.Context, _ interface{}) (interface{}, error) {
+		return AuthInfoFromContext(ctx).GetAPIKey(), nil
+	}
+
+	_, err := app.grpcServer.unaryInterceptor(context.Background(), nil, info, handler)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-api-key", "valid-key"))
+
+	resp, err := app.grpcServer.unaryInterceptor(ctx, nil, info, handler)
+	require.NoError(t, err)
+	assert.Equal(t, "valid-key", resp)
+
+	err = app.grpcServer.streamInterceptor(nil, &testServerStream{ctx: context.Background()},
+		&grpc.StreamServerInfo{FullMethod: "/Example/Watch"}, func(interface{}, grpc.ServerStream) error { return nil })
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+type testServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *testServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestGRPC_HealthAndReflection(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		reflection    string
+		expReflection bool
+	}{
+		{"reflection enabled", "true", true},
+		{"reflection disabled by default", "", false},
+	}
+
+	for i, tc := range testCases {
+		t.Setenv("GRPC_ENABLE_REFLECTION", tc.reflection)
+
+		services := New().grpcServer.server.GetServiceInfo()
+
+		assert.Contains(t, services, "grpc.health.v1.Health", "TEST[%d], Failed.\n%s", i, tc.desc)
+
+		_, ok := services["grpc.reflection.v1.ServerReflection"]
+		assert.Equal(t, tc.expReflection, ok, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestApp_RegisterService_HTTPTranscoding(t *testing.T) {
+	unary := grpc.MethodDesc{MethodName: "Get", Handler: func(interface{}, context.Context, func(interface{}) error,
+		grpc.UnaryServerInterceptor) (interface{}, error) {
+		return nil, nil
+	}}
+
+	testCases := []struct {
+		desc          string
+		transcoding   string
+		methods       []grpc.MethodDesc
+		expRegistered bool
+	}{
+		{"transcoding disabled by default", "", []grpc.MethodDesc{unary}, false},
+		{"transcoding enabled", "true", []grpc.MethodDesc{unary}, true},
+		{"no unary RPCs to serve", "true", nil, false},
+	}
+
+	for i, tc := range testCases {
+		t.Setenv("GRPC_ENABLE_HTTP_TRANSCODING", tc.transcoding)
+
+		app := New()
+		// the static directory of the package registers a route on its own
+		app.httpRegistered = false
+
+		app.RegisterService(&grpc.ServiceDesc{ServiceName: "test.Orders", HandlerType: (*interface{})(nil),
+			Methods: tc.methods, Streams: []grpc.StreamDesc{{StreamName: "Watch", ServerStreams: true}}}, &struct{}{})
+
+		assert.True(t, app.grpcRegistered, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.expRegistered, app.httpRegistered, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestGRPC_ServerShutdown_HealthNotServing(t *testing.T) {
+	c := container.Container{
+		Logger: logging.NewLogger(logging.DEBUG),
+	}
+
+	g := newGRPCServer(&c, 9999)
+
+	require.NoError(t, g.Shutdown(context.Background()))
+
+	resp, err := g.health.Check(context.Background(), &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, resp.GetStatus())
+}
+
+func TestApp_AddGRPCService(t *testing.T) {
+	app := New()
+
+	app.AddGRPCService("customer", "127.0.0.1:1")
+	app.AddGRPCService("orders", "localhost:9000", &gofr_grpc.TLSConfig{CACertFile: "missing-ca.pem"})
+
+	conn := app.container.GetGRPCService("customer")
+	require.NotNil(t, conn)
+
+	assert.Nil(t, app.container.GetGRPCService("orders"), "service with invalid options is not registered")
+
+	health, ok := app.container.Health(context.Background()).(map[string]interface{})
+	require.True(t, ok)
+
+	assert.Equal(t, "DOWN", health["customer"].(datasource.Health).Status)
+	assert.Equal(t, "DEGRADED", health["status"])
+
+	require.NoError(t, app.container.Close())
+}
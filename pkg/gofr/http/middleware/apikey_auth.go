@@ -47,6 +47,11 @@ func APIKeyAuthMiddleware(a APIKeyAuthProvider, apiKeys ...string) func(handler
 	}
 }
 
+// Validate reports whether the given API key is accepted by the provider or is one of the given API keys.
+func (a APIKeyAuthProvider) Validate(apiKey string, apiKeys ...string) bool {
+	return validateKey(a, apiKey, apiKeys...)
+}
+
 func isPresent(authKey string, apiKeys ...string) bool {
 	for _, key := range apiKeys {
 		if authKey == key {
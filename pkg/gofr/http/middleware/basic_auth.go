@@ -65,6 +65,11 @@ func BasicAuthMiddleware(basicAuthProvider BasicAuthProvider) func(handler http.
 	}
 }
 
+// Validate reports whether the given credentials are accepted by the provider.
+func (b BasicAuthProvider) Validate(username, password string) bool {
+	return validateCredentials(b, username, password)
+}
+
 func validateCredentials(provider BasicAuthProvider, username, password string) bool {
 	// If ValidateFunc is provided, use it.
 	if provider.ValidateFunc != nil {
@@ -122,19 +122,13 @@ func OAuth(key PublicKeyProvider) func(inner http.Handler) http.Handler {
 				return
 			}
 
-			tokenString, err := extractToken(r.Header.Get("Authorization"))
+			claims, err := ValidateToken(r.Header.Get("Authorization"), key)
 			if err != nil {
 				http.Error(w, err.Error(), http.StatusUnauthorized)
 				return
 			}
 
-			token, err := parseToken(tokenString, key)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusUnauthorized)
-				return
-			}
-
-			ctx := context.WithValue(r.Context(), JWTClaim, token.Claims)
+			ctx := context.WithValue(r.Context(), JWTClaim, claims)
 			*r = *r.Clone(ctx)
 
 			inner.ServeHTTP(w, r)
@@ -142,6 +136,21 @@ func OAuth(key PublicKeyProvider) func(inner http.Handler) http.Handler {
 	}
 }
 
+// ValidateToken validates the JWT of an Authorization header value of the form "Bearer {token}" and returns its claims.
+func ValidateToken(authHeader string, key PublicKeyProvider) (jwt.Claims, error) {
+	tokenString, err := extractToken(authHeader)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := parseToken(tokenString, key)
+	if err != nil {
+		return nil, err
+	}
+
+	return token.Claims, nil
+}
+
 // ExtractToken validates the Authorization header and extracts the JWT token.
 func extractToken(authHeader string) (string, error) {
 	if authHeader == "" {
@@ -0,0 +1,178 @@
+package gofr
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"gofr.dev/pkg/gofr/config"
+	"gofr.dev/pkg/gofr/service"
+)
+
+const defaultCircuitBreakerInterval = 5 * time.Second
+
+var errUnsupportedServiceAuth = errors.New("unsupported auth type")
+
+// addHTTPServicesFromConfig registers the HTTP services listed in HTTP_SERVICES, configured through
+// SERVICE_<NAME>_* keys, e.g. SERVICE_PAYMENTS_URL for the service "payments".
+func (a *App) addHTTPServicesFromConfig() {
+	for _, name := range strings.Split(a.Config.Get("HTTP_SERVICES"), ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+
+		address, options, err := httpServiceOptionsFromConfig(a.Config, name)
+		if err != nil {
+			a.container.Errorf("could not register HTTP service %v from config: %v", name, err)
+
+			continue
+		}
+
+		a.AddHTTPService(name, address, options...)
+	}
+}
+
+// httpServiceOptionsFromConfig returns the address and options of the service as given in the config.
+func httpServiceOptionsFromConfig(conf config.Config, name string) (string, []service.Options, error) {
+	prefix := "SERVICE_" + strings.ToUpper(strings.NewReplacer("-", "_", ".", "_").Replace(name)) + "_"
+	get := func(key string) string {
+		return strings.TrimSpace(conf.Get(prefix + key))
+	}
+
+	address := get("URL")
+	if address == "" {
+		return "", nil, fmt.Errorf("%vURL is not set", prefix)
+	}
+
+	var options []service.Options
+
+	if timeout := get("TIMEOUT"); timeout != "" {
+		d, err := time.ParseDuration(timeout)
+		if err != nil || d <= 0 {
+			return "", nil, fmt.Errorf("invalid value %q for %vTIMEOUT, expected a duration like 5s", timeout, prefix)
+		}
+
+		options = append(options, &service.TimeoutConfig{Timeout: d})
+	}
+
+	if endpoint := get("HEALTH_ENDPOINT"); endpoint != "" {
+		timeout, err := intFromConfig(get("HEALTH_TIMEOUT"), prefix+"HEALTH_TIMEOUT")
+		if err != nil {
+			return "", nil, err
+		}
+
+		options = append(options, &service.HealthConfig{HealthEndpoint: endpoint, Timeout: timeout})
+	}
+
+	if headers := get("HEADERS"); headers != "" {
+		options = append(options, &service.DefaultHeaders{Headers: parseHeaders(headers)})
+	}
+
+	authOption, err := httpServiceAuthFromConfig(get, prefix)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if authOption != nil {
+		options = append(options, authOption)
+	}
+
+	if retries := get("RETRY_MAX"); retries != "" {
+		maxRetries, err := intFromConfig(retries, prefix+"RETRY_MAX")
+		if err != nil {
+			return "", nil, err
+		}
+
+		options = append(options, &service.RetryConfig{MaxRetries: maxRetries})
+	}
+
+	// circuit breaker is added after retries, so that a call is counted as failed only once all retries fail
+	if threshold := get("CB_THRESHOLD"); threshold != "" {
+		cbConfig, err := circuitBreakerFromConfig(get, prefix, threshold)
+		if err != nil {
+			return "", nil, err
+		}
+
+		options = append(options, cbConfig)
+	}
+
+	return address, options, nil
+}
+
+func httpServiceAuthFromConfig(get func(string) string, prefix string) (service.Options, error) {
+	switch authType := strings.ToLower(get("AUTH")); authType {
+	case "":
+		return nil, nil
+	case "basic":
+		return &service.BasicAuthConfig{UserName: get("AUTH_USERNAME"), Password: get("AUTH_PASSWORD")}, nil
+	case "apikey":
+		return &service.APIKeyConfig{APIKey: get("AUTH_API_KEY")}, nil
+	case "oauth":
+		var scopes []string
+
+		for _, scope := range strings.Split(get("AUTH_SCOPES"), ",") {
+			if scope = strings.TrimSpace(scope); scope != "" {
+				scopes = append(scopes, scope)
+			}
+		}
+
+		return &service.OAuthConfig{
+			ClientID:     get("AUTH_CLIENT_ID"),
+			ClientSecret: get("AUTH_CLIENT_SECRET"),
+			TokenURL:     get("AUTH_TOKEN_URL"),
+			Scopes:       scopes,
+		}, nil
+	default:
+		return nil, fmt.Errorf("%w %q in %vAUTH", errUnsupportedServiceAuth, authType, prefix)
+	}
+}
+
+func circuitBreakerFromConfig(get func(string) string, prefix, threshold string) (*service.CircuitBreakerConfig, error) {
+	cbThreshold, err := intFromConfig(threshold, prefix+"CB_THRESHOLD")
+	if err != nil {
+		return nil, err
+	}
+
+	interval := defaultCircuitBreakerInterval
+
+	if v := get("CB_INTERVAL"); v != "" {
+		interval, err = time.ParseDuration(v)
+		if err != nil || interval <= 0 {
+			return nil, fmt.Errorf("invalid value %q for %vCB_INTERVAL, expected a duration like 5s", v, prefix)
+		}
+	}
+
+	return &service.CircuitBreakerConfig{Threshold: cbThreshold, Interval: interval}, nil
+}
+
+func intFromConfig(value, key string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	i, err := strconv.Atoi(value)
+	if err != nil || i < 0 {
+		return 0, fmt.Errorf("invalid value %q for %v, expected a non-negative number", value, key)
+	}
+
+	return i, nil
+}
+
+// parseHeaders parses headers given as "key1:value1,key2:value2".
+func parseHeaders(headers string) map[string]string {
+	h := make(map[string]string)
+
+	for _, header := range strings.Split(headers, ",") {
+		key, value, ok := strings.Cut(header, ":")
+		if !ok || strings.TrimSpace(key) == "" {
+			continue
+		}
+
+		h[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+
+	return h
+}
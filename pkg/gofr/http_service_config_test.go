@@ -0,0 +1,149 @@
+package gofr
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gofr.dev/pkg/gofr/config"
+	"gofr.dev/pkg/gofr/service"
+	"gofr.dev/pkg/gofr/testutil"
+)
+
+func Test_httpServiceOptionsFromConfig(t *testing.T) {
+	tests := []struct {
+		desc            string
+		configs         map[string]string
+		expectedOptions []service.Options
+		expectedErr     string
+	}{
+		{
+			desc:    "only URL",
+			configs: map[string]string{"SERVICE_PAYMENTS_URL": "http://payments"},
+		},
+		{
+			desc: "all options",
+			configs: map[string]string{
+				"SERVICE_PAYMENTS_URL":                "http://payments",
+				"SERVICE_PAYMENTS_TIMEOUT":            "3s",
+				"SERVICE_PAYMENTS_HEALTH_ENDPOINT":    "health",
+				"SERVICE_PAYMENTS_HEALTH_TIMEOUT":     "10",
+				"SERVICE_PAYMENTS_HEADERS":            "X-Team: billing, X-Source:gofr",
+				"SERVICE_PAYMENTS_AUTH":               "OAuth",
+				"SERVICE_PAYMENTS_AUTH_CLIENT_ID":     "client",
+				"SERVICE_PAYMENTS_AUTH_CLIENT_SECRET": "secret",
+				"SERVICE_PAYMENTS_AUTH_TOKEN_URL":     "http://token",
+				"SERVICE_PAYMENTS_AUTH_SCOPES":        "read, write",
+				"SERVICE_PAYMENTS_RETRY_MAX":          "3",
+				"SERVICE_PAYMENTS_CB_THRESHOLD":       "5",
+				"SERVICE_PAYMENTS_CB_INTERVAL":        "2s",
+			},
+			expectedOptions: []service.Options{
+				&service.TimeoutConfig{Timeout: 3 * time.Second},
+				&service.HealthConfig{HealthEndpoint: "health", Timeout: 10},
+				&service.DefaultHeaders{Headers: map[string]string{"X-Team": "billing", "X-Source": "gofr"}},
+				&service.OAuthConfig{ClientID: "client", ClientSecret: "secret", TokenURL: "http://token",
+					Scopes: []string{"read", "write"}},
+				&service.RetryConfig{MaxRetries: 3},
+				&service.CircuitBreakerConfig{Threshold: 5, Interval: 2 * time.Second},
+			},
+		},
+		{
+			desc: "basic auth and default circuit breaker interval",
+			configs: map[string]string{
+				"SERVICE_PAYMENTS_URL":           "http://payments",
+				"SERVICE_PAYMENTS_AUTH":          "basic",
+				"SERVICE_PAYMENTS_AUTH_USERNAME": "gofr",
+				"SERVICE_PAYMENTS_AUTH_PASSWORD": "cGFzc3dvcmQ=",
+				"SERVICE_PAYMENTS_CB_THRESHOLD":  "5",
+			},
+			expectedOptions: []service.Options{
+				&service.BasicAuthConfig{UserName: "gofr", Password: "cGFzc3dvcmQ="},
+				&service.CircuitBreakerConfig{Threshold: 5, Interval: defaultCircuitBreakerInterval},
+			},
+		},
+		{
+			desc: "api key auth",
+			configs: map[string]string{
+				"SERVICE_PAYMENTS_URL":          "http://payments",
+				"SERVICE_PAYMENTS_AUTH":         "apikey",
+				"SERVICE_PAYMENTS_AUTH_API_KEY": "key",
+			},
+			expectedOptions: []service.Options{&service.APIKeyConfig{APIKey: "key"}},
+		},
+		{
+			desc:        "missing URL",
+			configs:     map[string]string{},
+			expectedErr: "SERVICE_PAYMENTS_URL is not set",
+		},
+		{
+			desc:        "unsupported auth",
+			configs:     map[string]string{"SERVICE_PAYMENTS_URL": "http://payments", "SERVICE_PAYMENTS_AUTH": "digest"},
+			expectedErr: `unsupported auth type "digest" in SERVICE_PAYMENTS_AUTH`,
+		},
+		{
+			desc:        "invalid retries",
+			configs:     map[string]string{"SERVICE_PAYMENTS_URL": "http://payments", "SERVICE_PAYMENTS_RETRY_MAX": "many"},
+			expectedErr: `invalid value "many" for SERVICE_PAYMENTS_RETRY_MAX`,
+		},
+		{
+			desc:        "invalid timeout",
+			configs:     map[string]string{"SERVICE_PAYMENTS_URL": "http://payments", "SERVICE_PAYMENTS_TIMEOUT": "-1s"},
+			expectedErr: `invalid value "-1s" for SERVICE_PAYMENTS_TIMEOUT`,
+		},
+		{
+			desc: "invalid circuit breaker interval",
+			configs: map[string]string{"SERVICE_PAYMENTS_URL": "http://payments", "SERVICE_PAYMENTS_CB_THRESHOLD": "5",
+				"SERVICE_PAYMENTS_CB_INTERVAL": "5"},
+			expectedErr: `invalid value "5" for SERVICE_PAYMENTS_CB_INTERVAL`,
+		},
+	}
+
+	for i, tc := range tests {
+		address, options, err := httpServiceOptionsFromConfig(config.NewMockConfig(tc.configs), "payments")
+
+		if tc.expectedErr != "" {
+			require.ErrorContains(t, err, tc.expectedErr, "TEST[%d], Failed.\n%s", i, tc.desc)
+
+			continue
+		}
+
+		require.NoError(t, err, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, "http://payments", address, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.expectedOptions, options, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func Test_addHTTPServicesFromConfig(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "billing", r.Header.Get("X-Team"))
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	t.Setenv("HTTP_SERVICES", "payments, order-service")
+	t.Setenv("SERVICE_PAYMENTS_URL", server.URL)
+	t.Setenv("SERVICE_PAYMENTS_HEADERS", "X-Team:billing")
+
+	var app *App
+
+	logs := testutil.StderrOutputForFunc(func() {
+		app = New()
+	})
+
+	assert.Contains(t, logs, "could not register HTTP service order-service from config: SERVICE_ORDER_SERVICE_URL is not set")
+	assert.Nil(t, app.container.GetHTTPService("order-service"))
+
+	resp, err := app.container.GetHTTPService("payments").Get(context.Background(), "test", nil)
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
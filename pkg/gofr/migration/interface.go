@@ -8,6 +8,7 @@ import (
 	goRedis "github.com/redis/go-redis/v9"
 
 	"gofr.dev/pkg/gofr/container"
+	"gofr.dev/pkg/gofr/datasource/pubsub"
 )
 
 type Redis interface {
@@ -27,6 +28,8 @@ type SQL interface {
 
 type PubSub interface {
 	CreateTopic(context context.Context, name string) error
+	// CreateTopicWithConfig creates the topic with partitions, replication and configs, on the backends supporting them.
+	CreateTopicWithConfig(context context.Context, name string, config pubsub.TopicConfig) error
 	DeleteTopic(context context.Context, name string) error
 }
 
@@ -149,7 +149,7 @@ func getMigrator(c *container.Container) (Datasource, migrator, bool) {
 	if c.PubSub != nil {
 		ok = true
 
-		ds.PubSub = c.PubSub
+		ds.PubSub = pubSubDS{c.PubSub}
 	}
 
 	if !isNil(c.Cassandra) {
@@ -19,6 +19,7 @@ import (
 	gomock "go.uber.org/mock/gomock"
 
 	container "gofr.dev/pkg/gofr/container"
+	pubsub "gofr.dev/pkg/gofr/datasource/pubsub"
 )
 
 // MockRedis is a mock of Redis interface.
@@ -263,6 +264,20 @@ func (mr *MockPubSubMockRecorder) CreateTopic(context, name any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTopic", reflect.TypeOf((*MockPubSub)(nil).CreateTopic), context, name)
 }
 
+// CreateTopicWithConfig mocks base method.
+func (m *MockPubSub) CreateTopicWithConfig(context context.Context, name string, config pubsub.TopicConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTopicWithConfig", context, name, config)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTopicWithConfig indicates an expected call of CreateTopicWithConfig.
+func (mr *MockPubSubMockRecorder) CreateTopicWithConfig(context, name, config any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTopicWithConfig", reflect.TypeOf((*MockPubSub)(nil).CreateTopicWithConfig), context, name, config)
+}
+
 // DeleteTopic mocks base method.
 func (m *MockPubSub) DeleteTopic(context context.Context, name string) error {
 	m.ctrl.T.Helper()
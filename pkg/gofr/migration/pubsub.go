@@ -0,0 +1,25 @@
+package migration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gofr.dev/pkg/gofr/datasource/pubsub"
+)
+
+var errTopicConfigNotSupported = errors.New("topic configs are not supported by the pub/sub backend")
+
+// pubSubDS is the pub/sub of the migrations, creating the topics with configs on the backends supporting them.
+type pubSubDS struct {
+	pubsub.Client
+}
+
+func (p pubSubDS) CreateTopicWithConfig(ctx context.Context, name string, config pubsub.TopicConfig) error {
+	configurer, ok := p.Client.(pubsub.TopicConfigurer)
+	if !ok {
+		return fmt.Errorf("%w: %T", errTopicConfigNotSupported, p.Client)
+	}
+
+	return configurer.CreateTopicWithConfig(ctx, name, config)
+}
@@ -0,0 +1,37 @@
+package migration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gofr.dev/pkg/gofr/container"
+	"gofr.dev/pkg/gofr/datasource/pubsub"
+)
+
+type topicConfigurer struct {
+	container.MockPubSub
+
+	topics map[string]pubsub.TopicConfig
+}
+
+func (t *topicConfigurer) CreateTopicWithConfig(_ context.Context, name string, config pubsub.TopicConfig) error {
+	t.topics[name] = config
+
+	return nil
+}
+
+func TestPubSub_CreateTopicWithConfig(t *testing.T) {
+	config := pubsub.TopicConfig{Partitions: 3, ReplicationFactor: 2, Configs: map[string]string{"retention.ms": "3600000"}}
+
+	configurer := &topicConfigurer{topics: make(map[string]pubsub.TopicConfig)}
+
+	err := pubSubDS{configurer}.CreateTopicWithConfig(context.Background(), "orders", config)
+	require.NoError(t, err)
+	assert.Equal(t, config, configurer.topics["orders"])
+
+	err = pubSubDS{&container.MockPubSub{}}.CreateTopicWithConfig(context.Background(), "orders", config)
+	require.ErrorIs(t, err, errTopicConfigNotSupported)
+}
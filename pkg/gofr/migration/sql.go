@@ -17,6 +17,43 @@ const (
     constraint primary_key primary key (version, method)
 );`
 
+	// gofr_outbox holds the messages written with Tx.PublishLater until they are published by the outbox relay.
+	createSQLGoFrOutboxTableMySQL = `CREATE TABLE IF NOT EXISTS gofr_outbox (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    topic VARCHAR(255) not null ,
+    payload LONGBLOB not null ,
+    status VARCHAR(10) not null ,
+    attempts INT not null ,
+    last_error TEXT,
+    created_at TIMESTAMP not null ,
+    published_at TIMESTAMP NULL,
+    locked_until TIMESTAMP NULL
+);`
+
+	createSQLGoFrOutboxTablePostgres = `CREATE TABLE IF NOT EXISTS gofr_outbox (
+    id BIGSERIAL PRIMARY KEY,
+    topic VARCHAR(255) not null ,
+    payload BYTEA not null ,
+    status VARCHAR(10) not null ,
+    attempts INT not null ,
+    last_error TEXT,
+    created_at TIMESTAMP not null ,
+    published_at TIMESTAMP,
+    locked_until TIMESTAMP
+);`
+
+	createSQLGoFrOutboxTableSQLite = `CREATE TABLE IF NOT EXISTS gofr_outbox (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    topic VARCHAR(255) not null ,
+    payload BLOB not null ,
+    status VARCHAR(10) not null ,
+    attempts INT not null ,
+    last_error TEXT,
+    created_at TIMESTAMP not null ,
+    published_at TIMESTAMP,
+    locked_until TIMESTAMP
+);`
+
 	getLastSQLGoFrMigration = `SELECT COALESCE(MAX(version), 0) FROM gofr_migrations;`
 
 	insertGoFrMigrationRowMySQL = `INSERT INTO gofr_migrations (version, method, start_time,duration) VALUES (?, ?, ?, ?);`
@@ -47,9 +84,29 @@ func (d sqlMigrator) checkAndCreateMigrationTable(c *container.Container) error
 		return err
 	}
 
+	if createOutboxTable := outboxTableQuery(c.SQL.Dialect()); createOutboxTable != "" {
+		if _, err := c.SQL.Exec(createOutboxTable); err != nil {
+			return err
+		}
+	}
+
 	return d.migrator.checkAndCreateMigrationTable(c)
 }
 
+// outboxTableQuery returns the query creating the gofr_outbox table for the dialect.
+func outboxTableQuery(dialect string) string {
+	switch dialect {
+	case "mysql":
+		return createSQLGoFrOutboxTableMySQL
+	case "postgres":
+		return createSQLGoFrOutboxTablePostgres
+	case "sqlite":
+		return createSQLGoFrOutboxTableSQLite
+	default:
+		return ""
+	}
+}
+
 func (d sqlMigrator) getLastMigration(c *container.Container) int64 {
 	var lastMigration int64
 
@@ -193,6 +193,8 @@ func TestCheckAndCreateMigrationTableSuccess(t *testing.T) {
 
 	mockMigrator.EXPECT().checkAndCreateMigrationTable(mockContainer)
 	mocks.SQL.ExpectExec(createSQLGoFrMigrationsTable).WillReturnResult(mocks.SQL.NewResult(1, 1))
+	mocks.SQL.ExpectDialect().WillReturnString("mysql")
+	mocks.SQL.ExpectExec(createSQLGoFrOutboxTableMySQL).WillReturnResult(mocks.SQL.NewResult(0, 0))
 
 	migrator := sqlMigrator{
 		SQL:      mockContainer.SQL,
@@ -203,6 +205,41 @@ func TestCheckAndCreateMigrationTableSuccess(t *testing.T) {
 	require.NoError(t, err, "TestCheckAndCreateMigrationTable: error while executing mock query")
 }
 
+func TestCheckAndCreateMigrationTableOutboxError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockMigrator := NewMockmigrator(ctrl)
+	mockContainer, mocks := container.NewMockContainer(t)
+	expectedErr := sql.ErrConnDone
+
+	mocks.SQL.ExpectExec(createSQLGoFrMigrationsTable).WillReturnResult(mocks.SQL.NewResult(1, 1))
+	mocks.SQL.ExpectDialect().WillReturnString("postgres")
+	mocks.SQL.ExpectExec(createSQLGoFrOutboxTablePostgres).WillReturnError(expectedErr)
+
+	migrator := sqlMigrator{
+		SQL:      mockContainer.SQL,
+		migrator: mockMigrator,
+	}
+
+	err := migrator.checkAndCreateMigrationTable(mockContainer)
+	require.ErrorIs(t, err, expectedErr)
+}
+
+func TestOutboxTableQuery(t *testing.T) {
+	testCases := []struct {
+		dialect  string
+		expQuery string
+	}{
+		{"mysql", createSQLGoFrOutboxTableMySQL},
+		{"postgres", createSQLGoFrOutboxTablePostgres},
+		{"sqlite", createSQLGoFrOutboxTableSQLite},
+		{"unknown", ""},
+	}
+
+	for i, tc := range testCases {
+		require.Equal(t, tc.expQuery, outboxTableQuery(tc.dialect), "TEST[%d], Failed.\n%s", i, tc.dialect)
+	}
+}
+
 func TestCheckAndCreateMigrationTableExecError(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mockMigrator := NewMockmigrator(ctrl)
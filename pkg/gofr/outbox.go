@@ -0,0 +1,354 @@
+package gofr
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+	"time"
+
+	"gofr.dev/pkg/gofr/container"
+	gofrSQL "gofr.dev/pkg/gofr/datasource/sql"
+)
+
+const (
+	defaultOutboxPollInterval        = time.Second
+	defaultOutboxBatchSize           = 100
+	defaultOutboxRetention           = 24 * time.Hour
+	defaultOutboxClaimTimeout        = time.Minute
+	defaultOutboxMaintenanceInterval = time.Minute
+
+	outboxStatusPending   = "PENDING"
+	outboxStatusPublished = "PUBLISHED"
+	outboxStatusFailed    = "FAILED"
+
+	selectPendingOutboxMessages = `SELECT id, topic, payload, attempts, created_at, locked_until FROM gofr_outbox
+    WHERE status = 'PENDING' ORDER BY id LIMIT ?`
+
+	selectPendingOutboxMessagesPostgres = `SELECT id, topic, payload, attempts, created_at, locked_until FROM gofr_outbox
+    WHERE status = 'PENDING' ORDER BY id LIMIT $1`
+
+	claimOutboxMessage = `UPDATE gofr_outbox SET locked_until = ? WHERE id = ?`
+
+	claimOutboxMessagePostgres = `UPDATE gofr_outbox SET locked_until = $1 WHERE id = $2`
+
+	releaseOutboxMessage = `UPDATE gofr_outbox SET locked_until = NULL WHERE id = ?`
+
+	releaseOutboxMessagePostgres = `UPDATE gofr_outbox SET locked_until = NULL WHERE id = $1`
+
+	updateOutboxMessage = `UPDATE gofr_outbox SET status = ?, attempts = ?, last_error = ?, published_at = ?, locked_until = NULL
+    WHERE id = ?`
+
+	updateOutboxMessagePostgres = `UPDATE gofr_outbox SET status = $1, attempts = $2, last_error = $3, published_at = $4,
+    locked_until = NULL WHERE id = $5`
+
+	countPendingOutboxMessages = `SELECT COUNT(*) FROM gofr_outbox WHERE status = 'PENDING'`
+
+	deletePublishedOutboxMessages = `DELETE FROM gofr_outbox WHERE status = 'PUBLISHED' AND published_at < ?`
+
+	deletePublishedOutboxMessagesPostgres = `DELETE FROM gofr_outbox WHERE status = 'PUBLISHED' AND published_at < $1`
+)
+
+// OutboxConfig configures the relay publishing the messages written with gofrSQL.Tx.PublishLater, the fields which are
+// not set use their defaults.
+type OutboxConfig struct {
+	// PollInterval is the interval at which the pending messages are published, 1s by default.
+	PollInterval time.Duration
+	// BatchSize is the maximum number of messages published in a poll, 100 by default.
+	BatchSize int
+	// MaxAttempts is the number of failed attempts after which a message is marked as FAILED, so that the later
+	// messages of its topic are published. By default, a message is retried until it is published.
+	MaxAttempts int
+	// Retention is the duration for which the published messages are kept in the gofr_outbox table, 24h by default.
+	// A negative value keeps them, while the FAILED messages are always kept.
+	Retention time.Duration
+	// ClaimTimeout is the duration for which the messages of a poll are claimed by the relay publishing them, 1m by
+	// default. The messages which are still claimed after it, as the application stopped, are published by another relay.
+	ClaimTimeout time.Duration
+	// MaintenanceInterval is the interval at which the pending messages are counted and the published messages older
+	// than the retention are deleted, 1m by default.
+	MaintenanceInterval time.Duration
+}
+
+type outboxRelay struct {
+	container *container.Container
+	config    OutboxConfig
+	dialect   string
+}
+
+type outboxMessage struct {
+	id          int64
+	topic       string
+	payload     []byte
+	attempts    int
+	createdAt   time.Time
+	lockedUntil sql.NullTime
+}
+
+// EnableOutboxRelay publishes the messages written to the gofr_outbox table with gofrSQL.Tx.PublishLater through the
+// configured pub/sub backend while the application runs. The messages of a topic are published in the order they are
+// written, a message is retried until it is published, or MaxAttempts is reached, before the later ones of its topic.
+// The gofr_outbox table is created by the migrations, along with the gofr_migrations table.
+func (a *App) EnableOutboxRelay(config OutboxConfig) {
+	if config.PollInterval <= 0 {
+		config.PollInterval = defaultOutboxPollInterval
+	}
+
+	if config.BatchSize <= 0 {
+		config.BatchSize = defaultOutboxBatchSize
+	}
+
+	if config.Retention == 0 {
+		config.Retention = defaultOutboxRetention
+	}
+
+	if config.ClaimTimeout <= 0 {
+		config.ClaimTimeout = defaultOutboxClaimTimeout
+	}
+
+	if config.MaintenanceInterval <= 0 {
+		config.MaintenanceInterval = defaultOutboxMaintenanceInterval
+	}
+
+	a.outbox = &outboxRelay{container: a.container, config: config}
+}
+
+// run publishes the pending messages at every poll interval, and maintains the gofr_outbox table at every maintenance
+// interval, until ctx is done.
+func (r *outboxRelay) run(ctx context.Context) {
+	if isNil(r.container.SQL) || isNil(r.container.PubSub) {
+		r.container.Errorf("outbox relay is not started as both SQL and pubsub have to be configured")
+
+		return
+	}
+
+	r.dialect = r.container.SQL.Dialect()
+
+	ticker := time.NewTicker(r.config.PollInterval)
+	defer ticker.Stop()
+
+	maintenance := time.NewTicker(r.config.MaintenanceInterval)
+	defer maintenance.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			r.container.Infof("shutting down outbox relay")
+
+			return
+		case <-ticker.C:
+			if err := r.relay(ctx); err != nil {
+				r.container.Errorf("error while publishing outbox messages: %v", err)
+			}
+		case <-maintenance.C:
+			r.recordPending()
+
+			if r.config.Retention > 0 {
+				r.cleanup(ctx)
+			}
+		}
+	}
+}
+
+// relay claims a batch of the pending messages and publishes them.
+func (r *outboxRelay) relay(ctx context.Context) error {
+	messages, err := r.claim(ctx)
+	if err != nil {
+		return err
+	}
+
+	return r.publish(ctx, messages)
+}
+
+// claim marks a batch of the pending messages as claimed until the claim timeout, so that the relays of the other
+// instances of the application do not publish them, nor the later messages of their topics, while they are published.
+// The batch is locked, on the databases supporting it, only until the messages are claimed.
+func (r *outboxRelay) claim(ctx context.Context) ([]outboxMessage, error) {
+	tx, err := r.container.SQL.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	messages, err := r.pendingMessages(tx)
+	if err == nil {
+		messages, err = r.claimMessages(ctx, tx, messages)
+	}
+
+	if err != nil {
+		_ = tx.Rollback()
+
+		return nil, err
+	}
+
+	return messages, tx.Commit()
+}
+
+func (r *outboxRelay) pendingMessages(tx *gofrSQL.Tx) ([]outboxMessage, error) {
+	query := selectPendingOutboxMessages
+
+	switch r.dialect {
+	case "postgres":
+		query = selectPendingOutboxMessagesPostgres + " FOR UPDATE"
+	case "mysql":
+		query += " FOR UPDATE"
+	}
+
+	rows, err := tx.Query(query, r.config.BatchSize)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var messages []outboxMessage
+
+	for rows.Next() {
+		var msg outboxMessage
+
+		if err = rows.Scan(&msg.id, &msg.topic, &msg.payload, &msg.attempts, &msg.createdAt, &msg.lockedUntil); err != nil {
+			return nil, err
+		}
+
+		messages = append(messages, msg)
+	}
+
+	return messages, rows.Err()
+}
+
+// claimMessages claims the messages which are not claimed by another relay, and the later messages of their topics
+// are skipped so that they are published after them.
+func (r *outboxRelay) claimMessages(ctx context.Context, tx *gofrSQL.Tx, messages []outboxMessage) ([]outboxMessage, error) {
+	query := claimOutboxMessage
+	if r.dialect == "postgres" {
+		query = claimOutboxMessagePostgres
+	}
+
+	now := time.Now().UTC()
+	claimedTopics := make(map[string]bool)
+	claimed := make([]outboxMessage, 0, len(messages))
+
+	for _, msg := range messages {
+		if claimedTopics[msg.topic] {
+			continue
+		}
+
+		if msg.lockedUntil.Valid && msg.lockedUntil.Time.After(now) {
+			claimedTopics[msg.topic] = true
+
+			continue
+		}
+
+		if _, err := tx.ExecContext(ctx, query, now.Add(r.config.ClaimTimeout), msg.id); err != nil {
+			return nil, err
+		}
+
+		claimed = append(claimed, msg)
+	}
+
+	return claimed, nil
+}
+
+// publish publishes the claimed messages, once a message of a topic fails the later messages of the topic are released
+// pending, so that they are published after it.
+func (r *outboxRelay) publish(ctx context.Context, messages []outboxMessage) error {
+	failedTopics := make(map[string]bool)
+
+	for _, msg := range messages {
+		if failedTopics[msg.topic] {
+			if err := r.release(ctx, msg); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		status, lastError := outboxStatusPublished, ""
+
+		publishErr := r.container.GetPublisher().Publish(ctx, msg.topic, msg.payload)
+		if publishErr != nil {
+			r.container.Errorf("failed to publish outbox message %d to topic %s: %v", msg.id, msg.topic, publishErr)
+			r.container.Metrics().IncrementCounter(ctx, "app_outbox_publish_failed_count", "topic", msg.topic)
+
+			status, lastError = outboxStatusPending, publishErr.Error()
+
+			if r.config.MaxAttempts > 0 && msg.attempts+1 >= r.config.MaxAttempts {
+				status = outboxStatusFailed
+			} else {
+				failedTopics[msg.topic] = true
+			}
+		}
+
+		if err := r.update(ctx, msg, status, lastError); err != nil {
+			return err
+		}
+
+		if publishErr == nil {
+			r.container.Metrics().IncrementCounter(ctx, "app_outbox_published_count", "topic", msg.topic)
+			r.container.Metrics().RecordHistogram(ctx, "app_outbox_publish_lag", time.Since(msg.createdAt).Seconds(),
+				"topic", msg.topic)
+		}
+	}
+
+	return nil
+}
+
+// update records the attempt to publish the message with its status, and releases its claim.
+func (r *outboxRelay) update(ctx context.Context, msg outboxMessage, status, lastError string) error {
+	query := updateOutboxMessage
+	if r.dialect == "postgres" {
+		query = updateOutboxMessagePostgres
+	}
+
+	var publishedAt *time.Time
+
+	if status == outboxStatusPublished {
+		now := time.Now().UTC()
+		publishedAt = &now
+	}
+
+	_, err := r.container.SQL.ExecContext(ctx, query, status, msg.attempts+1, lastError, publishedAt, msg.id)
+
+	return err
+}
+
+// release releases the claim of the message which is not published.
+func (r *outboxRelay) release(ctx context.Context, msg outboxMessage) error {
+	query := releaseOutboxMessage
+	if r.dialect == "postgres" {
+		query = releaseOutboxMessagePostgres
+	}
+
+	_, err := r.container.SQL.ExecContext(ctx, query, msg.id)
+
+	return err
+}
+
+// recordPending records the number of pending messages, which are yet to be published.
+func (r *outboxRelay) recordPending() {
+	var pending int
+
+	if err := r.container.SQL.QueryRow(countPendingOutboxMessages).Scan(&pending); err != nil {
+		r.container.Errorf("failed to count pending outbox messages: %v", err)
+
+		return
+	}
+
+	r.container.Metrics().SetGauge("app_outbox_pending_messages", float64(pending))
+}
+
+// cleanup deletes the messages published before the retention.
+func (r *outboxRelay) cleanup(ctx context.Context) {
+	query := deletePublishedOutboxMessages
+	if r.dialect == "postgres" {
+		query = deletePublishedOutboxMessagesPostgres
+	}
+
+	if _, err := r.container.SQL.ExecContext(ctx, query, time.Now().UTC().Add(-r.config.Retention)); err != nil {
+		r.container.Errorf("failed to delete published outbox messages: %v", err)
+	}
+}
+
+func isNil(i any) bool {
+	val := reflect.ValueOf(i)
+
+	return !val.IsValid() || val.IsNil()
+}
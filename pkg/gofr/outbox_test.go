@@ -0,0 +1,207 @@
+package gofr
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"gofr.dev/pkg/gofr/container"
+	"gofr.dev/pkg/gofr/logging"
+)
+
+var errOutboxPublish = errors.New("broker unavailable")
+
+type outboxUpdate struct {
+	id       int64
+	status   string
+	attempts int
+}
+
+func TestOutboxRelay_Relay(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		publishErr  error
+		maxAttempts int
+		expUpdates  []outboxUpdate
+	}{
+		{"messages are published", nil, 0,
+			[]outboxUpdate{{1, outboxStatusPublished, 1}, {2, outboxStatusPublished, 1}, {3, outboxStatusPublished, 2}}},
+		{"later messages of a failed topic are left pending", errOutboxPublish, 0,
+			[]outboxUpdate{{1, outboxStatusPending, 1}, {2, "", 0}, {3, outboxStatusPending, 2}}},
+		{"messages are failed after max attempts", errOutboxPublish, 2,
+			[]outboxUpdate{{1, outboxStatusPending, 1}, {2, "", 0}, {3, outboxStatusFailed, 2}}},
+	}
+
+	for i, tc := range testCases {
+		c, mocks := container.NewMockContainer(t, container.WithMemoryPubSub())
+		c.Logger = logging.NewMockLogger(logging.FATAL)
+
+		if tc.publishErr != nil {
+			c.PubSub = &retrySubscriber{published: map[string][]byte{}, publishErr: tc.publishErr}
+		}
+
+		createdAt := time.Now().Add(-time.Minute)
+
+		mocks.SQL.ExpectBegin()
+		mocks.SQL.ExpectQuery(selectPendingOutboxMessages + " FOR UPDATE").WithArgs(defaultOutboxBatchSize).
+			WillReturnRows(outboxRows().
+				AddRow(1, "orders", []byte("order-1"), 0, createdAt, nil).
+				AddRow(2, "orders", []byte("order-2"), 0, createdAt, nil).
+				AddRow(3, "payments", []byte("payment-1"), 1, createdAt, nil))
+
+		for _, id := range []int64{1, 2, 3} {
+			mocks.SQL.ExpectExec(claimOutboxMessage).WithArgs(sqlmock.AnyArg(), id).WillReturnResult(sqlmock.NewResult(0, 1))
+		}
+
+		mocks.SQL.ExpectCommit()
+
+		for _, update := range tc.expUpdates {
+			if update.status == "" {
+				mocks.SQL.ExpectExec(releaseOutboxMessage).WithArgs(update.id).WillReturnResult(sqlmock.NewResult(0, 1))
+
+				continue
+			}
+
+			var publishedAt any
+			if update.status == outboxStatusPublished {
+				publishedAt = sqlmock.AnyArg()
+			}
+
+			lastError := ""
+			if tc.publishErr != nil {
+				lastError = tc.publishErr.Error()
+			}
+
+			mocks.SQL.ExpectExec(updateOutboxMessage).WithArgs(update.status, update.attempts, lastError, publishedAt, update.id).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+		}
+
+		if tc.publishErr != nil {
+			mocks.Metrics.EXPECT().IncrementCounter(gomock.Any(), "app_outbox_publish_failed_count", "topic", gomock.Any()).
+				Times(2)
+		} else {
+			mocks.Metrics.EXPECT().IncrementCounter(gomock.Any(), "app_outbox_published_count", "topic", gomock.Any()).Times(3)
+			mocks.Metrics.EXPECT().RecordHistogram(gomock.Any(), "app_outbox_publish_lag", gomock.Any(), "topic", gomock.Any()).
+				Times(3)
+		}
+
+		relay := &outboxRelay{container: c, dialect: "mysql", config: OutboxConfig{BatchSize: defaultOutboxBatchSize,
+			MaxAttempts: tc.maxAttempts, ClaimTimeout: time.Minute}}
+
+		require.NoError(t, relay.relay(context.Background()), "TEST[%d], Failed.\n%s", i, tc.desc)
+		require.NoError(t, mocks.SQL.ExpectationsWereMet(), "TEST[%d], Failed.\n%s", i, tc.desc)
+
+		if tc.publishErr == nil {
+			assert.Equal(t, [][]byte{[]byte("order-1"), []byte("order-2")}, mocks.PubSub.Messages("orders"),
+				"TEST[%d], Failed.\n%s", i, tc.desc)
+			assert.Equal(t, [][]byte{[]byte("payment-1")}, mocks.PubSub.Messages("payments"), "TEST[%d], Failed.\n%s", i, tc.desc)
+		}
+	}
+}
+
+func TestOutboxRelay_RelaySkipsClaimedTopics(t *testing.T) {
+	c, mocks := container.NewMockContainer(t, container.WithMemoryPubSub())
+	c.Logger = logging.NewMockLogger(logging.FATAL)
+
+	createdAt := time.Now().Add(-time.Minute)
+
+	mocks.SQL.ExpectBegin()
+	mocks.SQL.ExpectQuery(selectPendingOutboxMessagesPostgres + " FOR UPDATE").WithArgs(defaultOutboxBatchSize).
+		WillReturnRows(outboxRows().
+			AddRow(1, "orders", []byte("order-1"), 0, createdAt, time.Now().UTC().Add(time.Minute)).
+			AddRow(2, "orders", []byte("order-2"), 0, createdAt, nil).
+			AddRow(3, "payments", []byte("payment-1"), 0, createdAt, time.Now().UTC().Add(-time.Minute)))
+	mocks.SQL.ExpectExec(claimOutboxMessagePostgres).WithArgs(sqlmock.AnyArg(), int64(3)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mocks.SQL.ExpectCommit()
+	mocks.SQL.ExpectExec(updateOutboxMessagePostgres).WithArgs(outboxStatusPublished, 1, "", sqlmock.AnyArg(), int64(3)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	mocks.Metrics.EXPECT().IncrementCounter(gomock.Any(), "app_outbox_published_count", "topic", "payments")
+	mocks.Metrics.EXPECT().RecordHistogram(gomock.Any(), "app_outbox_publish_lag", gomock.Any(), "topic", "payments")
+
+	relay := &outboxRelay{container: c, config: OutboxConfig{BatchSize: defaultOutboxBatchSize, ClaimTimeout: time.Minute},
+		dialect: "postgres"}
+
+	require.NoError(t, relay.relay(context.Background()))
+	require.NoError(t, mocks.SQL.ExpectationsWereMet())
+
+	assert.Empty(t, mocks.PubSub.Messages("orders"))
+	assert.Equal(t, [][]byte{[]byte("payment-1")}, mocks.PubSub.Messages("payments"))
+}
+
+func TestOutboxRelay_RelayClaimError(t *testing.T) {
+	c, mocks := container.NewMockContainer(t, container.WithMemoryPubSub())
+	c.Logger = logging.NewMockLogger(logging.FATAL)
+
+	mocks.SQL.ExpectBegin()
+	mocks.SQL.ExpectQuery(selectPendingOutboxMessagesPostgres + " FOR UPDATE").WithArgs(defaultOutboxBatchSize).
+		WillReturnRows(outboxRows().AddRow(1, "orders", []byte("order-1"), 0, time.Now(), nil))
+	mocks.SQL.ExpectExec(claimOutboxMessagePostgres).WillReturnError(errOutboxPublish)
+	mocks.SQL.ExpectRollback()
+
+	relay := &outboxRelay{container: c, config: OutboxConfig{BatchSize: defaultOutboxBatchSize}, dialect: "postgres"}
+
+	require.ErrorIs(t, relay.relay(context.Background()), errOutboxPublish)
+	require.NoError(t, mocks.SQL.ExpectationsWereMet())
+	assert.Empty(t, mocks.PubSub.Messages("orders"))
+}
+
+func TestOutboxRelay_RecordPendingAndCleanup(t *testing.T) {
+	c, mocks := container.NewMockContainer(t)
+	c.Logger = logging.NewMockLogger(logging.FATAL)
+
+	mocks.SQL.ExpectQuery(countPendingOutboxMessages).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(5))
+	mocks.Metrics.EXPECT().SetGauge("app_outbox_pending_messages", float64(5))
+	mocks.SQL.ExpectExec(deletePublishedOutboxMessages).WithArgs(sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 2))
+
+	relay := &outboxRelay{container: c, config: OutboxConfig{Retention: time.Hour}, dialect: "mysql"}
+
+	relay.recordPending()
+	relay.cleanup(context.Background())
+
+	require.NoError(t, mocks.SQL.ExpectationsWereMet())
+}
+
+func TestApp_EnableOutboxRelay(t *testing.T) {
+	app := &App{container: &container.Container{}}
+
+	app.EnableOutboxRelay(OutboxConfig{MaxAttempts: 3, Retention: -1})
+
+	assert.Equal(t, OutboxConfig{PollInterval: defaultOutboxPollInterval, BatchSize: defaultOutboxBatchSize, MaxAttempts: 3,
+		Retention: -1, ClaimTimeout: defaultOutboxClaimTimeout, MaintenanceInterval: defaultOutboxMaintenanceInterval},
+		app.outbox.config)
+
+	app.EnableOutboxRelay(OutboxConfig{})
+
+	assert.Equal(t, defaultOutboxRetention, app.outbox.config.Retention)
+}
+
+func TestOutboxRelay_RunWithoutDatasources(t *testing.T) {
+	c := &container.Container{Logger: logging.NewMockLogger(logging.FATAL), PubSub: &container.MockPubSub{}}
+
+	relay := &outboxRelay{container: c, config: OutboxConfig{PollInterval: time.Millisecond}}
+
+	done := make(chan struct{})
+
+	go func() {
+		relay.run(context.Background())
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("outbox relay should not run without SQL")
+	}
+}
+
+func outboxRows() *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"id", "topic", "payload", "attempts", "created_at", "locked_until"})
+}
@@ -0,0 +1,433 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// CacheBackendMemory caches the responses in the memory of the application instance.
+	CacheBackendMemory = "memory"
+	// CacheBackendRedis caches the responses in the Redis configured in the container, so that they are shared
+	// across application instances.
+	CacheBackendRedis = "redis"
+
+	cacheKeyPrefix = "gofr:http-cache:"
+)
+
+// CacheConfig enables caching of the GET responses of the downstream HTTP Service.
+//
+// Upstream Cache-Control, Expires, ETag and Last-Modified headers are respected: responses marked no-store are never
+// cached, max-age and s-maxage define their freshness and expired responses carrying a validator are revalidated
+// using If-None-Match and If-Modified-Since. When the upstream does not define the freshness of a response, PathTTL
+// and TTL are used.
+//
+// CacheConfig should be added after CircuitBreakerConfig and RetryConfig so that stale responses can be served
+// when the circuit breaker is open.
+type CacheConfig struct {
+	// Backend is the store used for the cached responses, either CacheBackendMemory (default) or CacheBackendRedis.
+	Backend string
+	// Store overrides Backend with a custom store for the cached responses.
+	Store CacheStore
+	// TTL is the time for which a response is fresh when the upstream does not specify it.
+	TTL time.Duration
+	// PathTTL overrides TTL for the paths matching a pattern, patterns follow the syntax of path.Match,
+	// e.g. "countries/*". The longest matching pattern is used.
+	PathTTL map[string]time.Duration
+	// StaleIfError is the time after expiry during which a cached response is served when the downstream
+	// call fails, responds with a server error or the circuit breaker is open.
+	StaleIfError time.Duration
+}
+
+type pathTTL struct {
+	pattern string
+	ttl     time.Duration
+}
+
+func (c *CacheConfig) AddOption(h HTTP) HTTP {
+	store := c.Store
+	if store == nil {
+		store = NewMemoryCacheStore()
+	}
+
+	pathTTLs := make([]pathTTL, 0, len(c.PathTTL))
+	for pattern, ttl := range c.PathTTL {
+		pathTTLs = append(pathTTLs, pathTTL{pattern: strings.TrimPrefix(pattern, "/"), ttl: ttl})
+	}
+
+	// more specific patterns are matched first
+	sort.Slice(pathTTLs, func(i, j int) bool {
+		if len(pathTTLs[i].pattern) == len(pathTTLs[j].pattern) {
+			return pathTTLs[i].pattern < pathTTLs[j].pattern
+		}
+
+		return len(pathTTLs[i].pattern) > len(pathTTLs[j].pattern)
+	})
+
+	cp := &cacheProvider{
+		store:        store,
+		ttl:          c.TTL,
+		pathTTLs:     pathTTLs,
+		staleIfError: c.StaleIfError,
+		HTTP:         h,
+	}
+
+	if svc := extractHTTPService(h); svc != nil {
+		cp.url = svc.url
+
+		// the cache hits and misses are only counted when the metrics of the service support counters
+		cp.metrics, _ = svc.Metrics.(counter)
+	}
+
+	return cp
+}
+
+// counter is implemented by the metrics which can count the cache hits and misses, as the metrics manager of the
+// container does.
+type counter interface {
+	IncrementCounter(ctx context.Context, name string, labels ...string)
+}
+
+type cacheProvider struct {
+	store        CacheStore
+	ttl          time.Duration
+	pathTTLs     []pathTTL
+	staleIfError time.Duration
+
+	url     string
+	metrics counter
+
+	HTTP
+}
+
+// cacheEntry is a cached response along with the information required to decide whether it can be served.
+type cacheEntry struct {
+	StatusCode   int         `json:"statusCode"`
+	Header       http.Header `json:"header"`
+	Body         []byte      `json:"body"`
+	ETag         string      `json:"etag,omitempty"`
+	LastModified string      `json:"lastModified,omitempty"`
+	ExpiresAt    time.Time   `json:"expiresAt"`
+	StaleUntil   time.Time   `json:"staleUntil"`
+}
+
+func (e *cacheEntry) isFresh(now time.Time) bool {
+	return now.Before(e.ExpiresAt)
+}
+
+func (e *cacheEntry) canServeStale(now time.Time) bool {
+	return now.Before(e.StaleUntil)
+}
+
+func (e *cacheEntry) response() *http.Response {
+	return &http.Response{
+		Status:        strconv.Itoa(e.StatusCode) + " " + http.StatusText(e.StatusCode),
+		StatusCode:    e.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        e.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(e.Body)),
+		ContentLength: int64(len(e.Body)),
+	}
+}
+
+func (cp *cacheProvider) Get(ctx context.Context, path string, queryParams map[string]interface{}) (*http.Response, error) {
+	return cp.GetWithHeaders(ctx, path, queryParams, nil)
+}
+
+func (cp *cacheProvider) GetWithHeaders(ctx context.Context, path string, queryParams map[string]interface{},
+	headers map[string]string) (*http.Response, error) {
+	// the responses to the credentials of a caller are not to be served to other callers
+	if hasCredentials(headers) {
+		return cp.HTTP.GetWithHeaders(ctx, path, queryParams, headers)
+	}
+
+	key := cp.cacheKey(path, queryParams)
+	now := time.Now()
+
+	entry := cp.lookup(ctx, key)
+
+	if entry != nil && entry.isFresh(now) {
+		cp.recordHit(ctx, "fresh")
+
+		return entry.response(), nil
+	}
+
+	resp, err := cp.HTTP.GetWithHeaders(ctx, path, queryParams, conditionalHeaders(headers, entry))
+	if err != nil || resp == nil || resp.StatusCode >= http.StatusInternalServerError {
+		if entry != nil && entry.canServeStale(now) {
+			if resp != nil {
+				resp.Body.Close()
+			}
+
+			cp.recordHit(ctx, "stale")
+
+			return entry.response(), nil
+		}
+
+		cp.recordMiss(ctx)
+
+		return resp, err
+	}
+
+	if resp.StatusCode == http.StatusNotModified && entry != nil {
+		cp.revalidate(ctx, key, path, entry, resp.Header, now)
+		cp.recordHit(ctx, "revalidated")
+
+		// the 304 answers the validators of the caller, who already holds the response
+		if isConditional(headers) {
+			return resp, nil
+		}
+
+		resp.Body.Close()
+
+		return entry.response(), nil
+	}
+
+	cp.recordMiss(ctx)
+
+	return cp.save(ctx, key, path, resp, now)
+}
+
+// lookup returns the cached entry for the key, failures of the store are treated as a cache miss.
+func (cp *cacheProvider) lookup(ctx context.Context, key string) *cacheEntry {
+	data, ok, err := cp.store.Get(ctx, key)
+	if err != nil || !ok {
+		return nil
+	}
+
+	var entry cacheEntry
+
+	if err = json.Unmarshal(data, &entry); err != nil {
+		return nil
+	}
+
+	return &entry
+}
+
+// set stores the entry for the given duration, failures of the store are ignored as the response can still be served.
+func (cp *cacheProvider) set(ctx context.Context, key string, entry *cacheEntry, retention time.Duration) {
+	if retention <= 0 {
+		return
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+
+	_ = cp.store.Set(ctx, key, data, retention)
+}
+
+// save stores the response when it is cacheable and returns a response whose body can still be read by the caller.
+func (cp *cacheProvider) save(ctx context.Context, key, path string, resp *http.Response, now time.Time) (*http.Response, error) {
+	if resp.StatusCode != http.StatusOK {
+		return resp, nil
+	}
+
+	directives := parseCacheControl(resp.Header.Get("Cache-Control"))
+	if _, ok := directives["no-store"]; ok {
+		return resp, nil
+	}
+
+	// responses private to a caller, or varying with the request headers, are not shared across callers
+	if _, ok := directives["private"]; ok || resp.Header.Get("Vary") != "" {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	entry := &cacheEntry{
+		StatusCode:   resp.StatusCode,
+		Header:       resp.Header.Clone(),
+		Body:         body,
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+	}
+
+	cp.set(ctx, key, entry, cp.expiry(entry, path, resp.Header, directives, now))
+
+	return resp, nil
+}
+
+// revalidate refreshes an entry after the upstream responded with 304 Not Modified.
+func (cp *cacheProvider) revalidate(ctx context.Context, key, path string, entry *cacheEntry, header http.Header,
+	now time.Time) {
+	if etag := header.Get("ETag"); etag != "" {
+		entry.ETag = etag
+		entry.Header.Set("ETag", etag)
+	}
+
+	if cc := header.Get("Cache-Control"); cc != "" {
+		entry.Header.Set("Cache-Control", cc)
+	}
+
+	directives := parseCacheControl(entry.Header.Get("Cache-Control"))
+
+	cp.set(ctx, key, entry, cp.expiry(entry, path, header, directives, now))
+}
+
+// expiry sets the freshness and stale window of the entry and returns the duration for which it is to be retained.
+func (cp *cacheProvider) expiry(entry *cacheEntry, path string, header http.Header, directives map[string]string,
+	now time.Time) time.Duration {
+	freshness := cp.freshness(path, header, directives)
+
+	staleIfError := cp.staleIfError
+	if v, ok := directives["stale-if-error"]; ok {
+		if seconds, err := strconv.Atoi(v); err == nil && time.Duration(seconds)*time.Second > staleIfError {
+			staleIfError = time.Duration(seconds) * time.Second
+		}
+	}
+
+	entry.ExpiresAt = now.Add(freshness)
+	entry.StaleUntil = entry.ExpiresAt.Add(staleIfError)
+
+	// expired entries having a validator are retained for a while longer, so that they can be revalidated
+	// instead of being fetched again.
+	var revalidation time.Duration
+
+	if entry.ETag != "" || entry.LastModified != "" {
+		revalidation = max(freshness, cp.pathTTL(path))
+	}
+
+	return freshness + max(staleIfError, revalidation)
+}
+
+// freshness returns the time for which a response is fresh, as specified by the upstream or else by the configuration.
+func (cp *cacheProvider) freshness(path string, header http.Header, directives map[string]string) time.Duration {
+	if _, ok := directives["no-cache"]; ok {
+		return 0
+	}
+
+	for _, directive := range []string{"s-maxage", "max-age"} {
+		if v, ok := directives[directive]; ok {
+			if seconds, err := strconv.Atoi(v); err == nil {
+				return time.Duration(seconds) * time.Second
+			}
+		}
+	}
+
+	if expires := header.Get("Expires"); expires != "" {
+		t, err := http.ParseTime(expires)
+		if err != nil || !t.After(time.Now()) {
+			return 0
+		}
+
+		return time.Until(t)
+	}
+
+	return cp.pathTTL(path)
+}
+
+// pathTTL returns the configured TTL for the path.
+func (cp *cacheProvider) pathTTL(p string) time.Duration {
+	p = strings.TrimPrefix(p, "/")
+
+	for _, pt := range cp.pathTTLs {
+		if ok, _ := path.Match(pt.pattern, p); ok {
+			return pt.ttl
+		}
+	}
+
+	return cp.ttl
+}
+
+func (cp *cacheProvider) cacheKey(path string, queryParams map[string]interface{}) string {
+	key := cacheKeyPrefix + strings.TrimRight(cp.url+"/"+path, "/")
+
+	if query := queryValues(queryParams).Encode(); query != "" {
+		key += "?" + query
+	}
+
+	return key
+}
+
+func (cp *cacheProvider) recordHit(ctx context.Context, hitType string) {
+	if cp.metrics != nil {
+		cp.metrics.IncrementCounter(ctx, "app_http_service_cache_hit_count", "service", cp.url, "type", hitType)
+	}
+}
+
+func (cp *cacheProvider) recordMiss(ctx context.Context) {
+	if cp.metrics != nil {
+		cp.metrics.IncrementCounter(ctx, "app_http_service_cache_miss_count", "service", cp.url)
+	}
+}
+
+// conditionalHeaders adds the validators of an expired entry to the request headers, unless set by the caller.
+func conditionalHeaders(headers map[string]string, entry *cacheEntry) map[string]string {
+	if entry == nil || (entry.ETag == "" && entry.LastModified == "") {
+		return headers
+	}
+
+	h := make(map[string]string, len(headers)+2)
+
+	if entry.ETag != "" {
+		h["If-None-Match"] = entry.ETag
+	}
+
+	if entry.LastModified != "" {
+		h["If-Modified-Since"] = entry.LastModified
+	}
+
+	for k, v := range headers {
+		h[k] = v
+	}
+
+	return h
+}
+
+// hasCredentials reports whether the caller sent credentials of its own, whose responses are then not cached.
+func hasCredentials(headers map[string]string) bool {
+	for k := range headers {
+		if strings.EqualFold(k, "Authorization") || strings.EqualFold(k, "Cookie") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isConditional reports whether the caller sent validators of its own, in which case a 304 is passed on to it.
+func isConditional(headers map[string]string) bool {
+	for k := range headers {
+		if strings.EqualFold(k, "If-None-Match") || strings.EqualFold(k, "If-Modified-Since") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseCacheControl returns the directives of a Cache-Control header with their lowercase names as keys.
+func parseCacheControl(header string) map[string]string {
+	directives := make(map[string]string)
+
+	for _, part := range strings.Split(header, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		name, value, _ := strings.Cut(part, "=")
+		directives[strings.ToLower(strings.TrimSpace(name))] = strings.Trim(strings.TrimSpace(value), `"`)
+	}
+
+	return directives
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const memoryCacheCleanupInterval = time.Minute
+
+// CacheStore stores the responses cached by CacheConfig.
+type CacheStore interface {
+	// Get returns the value stored for the key and whether it was found.
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	// Set stores the value for the key, the value is removed once the ttl elapses.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+}
+
+type memoryCacheItem struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+type memoryCacheStore struct {
+	mu          sync.Mutex
+	items       map[string]memoryCacheItem
+	lastCleanup time.Time
+}
+
+// NewMemoryCacheStore returns a CacheStore which keeps the values in the memory of the application.
+func NewMemoryCacheStore() CacheStore {
+	return &memoryCacheStore{
+		items:       make(map[string]memoryCacheItem),
+		lastCleanup: time.Now(),
+	}
+}
+
+func (m *memoryCacheStore) Get(_ context.Context, key string) ([]byte, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	item, ok := m.items[key]
+	if !ok {
+		return nil, false, nil
+	}
+
+	if time.Now().After(item.expiresAt) {
+		delete(m.items, key)
+
+		return nil, false, nil
+	}
+
+	return item.value, true, nil
+}
+
+func (m *memoryCacheStore) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+
+	// expired items are removed periodically, so that values which are never read again do not pile up.
+	if now.Sub(m.lastCleanup) > memoryCacheCleanupInterval {
+		for k, item := range m.items {
+			if now.After(item.expiresAt) {
+				delete(m.items, k)
+			}
+		}
+
+		m.lastCleanup = now
+	}
+
+	m.items[key] = memoryCacheItem{value: value, expiresAt: now.Add(ttl)}
+
+	return nil
+}
+
+type redisCacheStore struct {
+	client redis.Cmdable
+}
+
+// NewRedisCacheStore returns a CacheStore which keeps the values in Redis, so that they are shared across
+// the instances of the application.
+func NewRedisCacheStore(client redis.Cmdable) CacheStore {
+	return &redisCacheStore{client: client}
+}
+
+func (r *redisCacheStore) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	value, err := r.client.Get(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, false, nil
+	}
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	return value, true, nil
+}
+
+func (r *redisCacheStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return r.client.Set(ctx, key, value, ttl).Err()
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryCacheStore(t *testing.T) {
+	store := NewMemoryCacheStore()
+	ctx := context.Background()
+
+	require.NoError(t, store.Set(ctx, "fresh", []byte("value"), time.Minute))
+	require.NoError(t, store.Set(ctx, "expired", []byte("value"), -time.Second))
+
+	value, ok, err := store.Get(ctx, "fresh")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, []byte("value"), value)
+
+	_, ok, err = store.Get(ctx, "expired")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	_, ok, err = store.Get(ctx, "missing")
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestMemoryCacheStore_RemovesExpiredItems(t *testing.T) {
+	store := &memoryCacheStore{
+		items:       map[string]memoryCacheItem{"expired": {value: []byte("value"), expiresAt: time.Now().Add(-time.Second)}},
+		lastCleanup: time.Now().Add(-2 * memoryCacheCleanupInterval),
+	}
+
+	require.NoError(t, store.Set(context.Background(), "fresh", []byte("value"), time.Minute))
+
+	assert.Len(t, store.items, 1)
+	assert.Contains(t, store.items, "fresh")
+}
+
+func TestRedisCacheStore(t *testing.T) {
+	s, err := miniredis.Run()
+	require.NoError(t, err)
+
+	defer s.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: s.Addr()})
+	store := NewRedisCacheStore(client)
+	ctx := context.Background()
+
+	require.NoError(t, store.Set(ctx, "key", []byte("value"), time.Minute))
+
+	value, ok, err := store.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, []byte("value"), value)
+
+	s.FastForward(2 * time.Minute)
+
+	_, ok, err = store.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	s.Close()
+
+	_, _, err = store.Get(ctx, "key")
+	require.Error(t, err)
+}
@@ -0,0 +1,354 @@
+package service
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"gofr.dev/pkg/gofr/logging"
+)
+
+func newCacheTestServer(t *testing.T, handler http.HandlerFunc) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var calls atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		handler(w, r)
+	}))
+
+	t.Cleanup(server.Close)
+
+	return server, &calls
+}
+
+func readBody(t *testing.T, resp *http.Response) string {
+	t.Helper()
+
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	return string(body)
+}
+
+func TestCacheProvider_CachesResponses(t *testing.T) {
+	tests := []struct {
+		desc          string
+		cacheControl  string
+		config        CacheConfig
+		path          string
+		expectedCalls int32
+	}{
+		{"max-age from upstream", "max-age=60", CacheConfig{}, "countries", 1},
+		{"no-store from upstream", "no-store", CacheConfig{TTL: time.Minute}, "countries", 2},
+		{"private from upstream", "private, max-age=60", CacheConfig{TTL: time.Minute}, "countries", 2},
+		{"configured TTL", "", CacheConfig{TTL: time.Minute}, "countries", 1},
+		{"no TTL configured", "", CacheConfig{}, "countries", 2},
+		{"path TTL matches", "", CacheConfig{PathTTL: map[string]time.Duration{"countries/*": time.Minute}},
+			"countries/in", 1},
+		{"path TTL does not match", "", CacheConfig{PathTTL: map[string]time.Duration{"countries/*": time.Minute}},
+			"currencies/inr", 2},
+	}
+
+	for i, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			server, calls := newCacheTestServer(t, func(w http.ResponseWriter, _ *http.Request) {
+				if tc.cacheControl != "" {
+					w.Header().Set("Cache-Control", tc.cacheControl)
+				}
+
+				_, _ = w.Write([]byte("reference data"))
+			})
+
+			svc := NewHTTPService(server.URL, logging.NewMockLogger(logging.DEBUG), nil, &tc.config)
+
+			for range 2 {
+				resp, err := svc.Get(context.Background(), tc.path, map[string]interface{}{"page": 1})
+				require.NoError(t, err)
+
+				assert.Equal(t, http.StatusOK, resp.StatusCode, "TEST[%d], Failed.\n%s", i, tc.desc)
+				assert.Equal(t, "reference data", readBody(t, resp), "TEST[%d], Failed.\n%s", i, tc.desc)
+			}
+
+			assert.Equal(t, tc.expectedCalls, calls.Load(), "TEST[%d], Failed.\n%s", i, tc.desc)
+		})
+	}
+}
+
+func TestCacheProvider_QueryParamsArePartOfKey(t *testing.T) {
+	server, calls := newCacheTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.URL.Query().Get("id")))
+	})
+
+	svc := NewHTTPService(server.URL, logging.NewMockLogger(logging.DEBUG), nil, &CacheConfig{TTL: time.Minute})
+
+	for _, id := range []string{"1", "2", "1"} {
+		resp, err := svc.Get(context.Background(), "users", map[string]interface{}{"id": id})
+		require.NoError(t, err)
+
+		assert.Equal(t, id, readBody(t, resp))
+	}
+
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestCacheProvider_CallerCredentialsAreNotShared(t *testing.T) {
+	server, calls := newCacheTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Header.Get("Authorization")))
+	})
+
+	svc := NewHTTPService(server.URL, logging.NewMockLogger(logging.DEBUG), nil, &CacheConfig{TTL: time.Minute})
+
+	for _, auth := range []string{"Bearer alice", "Bearer bob"} {
+		resp, err := svc.GetWithHeaders(context.Background(), "profile", nil, map[string]string{"Authorization": auth})
+		require.NoError(t, err)
+
+		assert.Equal(t, auth, readBody(t, resp))
+	}
+
+	// the response to a call without credentials is not served from the responses to the calls with credentials
+	resp, err := svc.Get(context.Background(), "profile", nil)
+	require.NoError(t, err)
+
+	assert.Empty(t, readBody(t, resp))
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestCacheProvider_VaryingResponsesAreNotCached(t *testing.T) {
+	server, calls := newCacheTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Vary", "Accept-Language")
+		_, _ = w.Write([]byte(r.Header.Get("Accept-Language")))
+	})
+
+	svc := NewHTTPService(server.URL, logging.NewMockLogger(logging.DEBUG), nil, &CacheConfig{TTL: time.Minute})
+
+	for _, language := range []string{"en", "fr"} {
+		resp, err := svc.GetWithHeaders(context.Background(), "countries", nil, map[string]string{"Accept-Language": language})
+		require.NoError(t, err)
+
+		assert.Equal(t, language, readBody(t, resp))
+	}
+
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+// countingMetrics adds the counters used by the cache to the mocked metrics of the service.
+type countingMetrics struct {
+	*MockMetrics
+	counts sync.Map
+}
+
+func (m *countingMetrics) IncrementCounter(_ context.Context, name string, labels ...string) {
+	key := strings.Join(append([]string{name}, labels...), " ")
+
+	count, _ := m.counts.LoadOrStore(key, new(atomic.Int32))
+	count.(*atomic.Int32).Add(1)
+}
+
+func (m *countingMetrics) count(name string, labels ...string) int32 {
+	count, ok := m.counts.Load(strings.Join(append([]string{name}, labels...), " "))
+	if !ok {
+		return 0
+	}
+
+	return count.(*atomic.Int32).Load()
+}
+
+func newCountingMetrics(t *testing.T) *countingMetrics {
+	t.Helper()
+
+	metrics := NewMockMetrics(gomock.NewController(t))
+	metrics.EXPECT().RecordHistogram(gomock.Any(), "app_http_service_response", gomock.Any(), gomock.Any()).AnyTimes()
+
+	return &countingMetrics{MockMetrics: metrics}
+}
+
+func TestCacheProvider_RevalidatesWithETag(t *testing.T) {
+	server, calls := newCacheTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("ETag", `"v1"`)
+
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+
+			return
+		}
+
+		_, _ = w.Write([]byte("reference data"))
+	})
+
+	metrics := newCountingMetrics(t)
+
+	svc := NewHTTPService(server.URL, logging.NewMockLogger(logging.DEBUG), metrics, &CacheConfig{TTL: time.Minute})
+
+	for range 2 {
+		resp, err := svc.Get(context.Background(), "countries", nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "reference data", readBody(t, resp))
+	}
+
+	assert.Equal(t, int32(2), calls.Load())
+	assert.Equal(t, int32(1), metrics.count("app_http_service_cache_miss_count", "service", server.URL))
+	assert.Equal(t, int32(1), metrics.count("app_http_service_cache_hit_count", "service", server.URL, "type", "revalidated"))
+}
+
+func TestCacheProvider_PassesNotModifiedToConditionalRequests(t *testing.T) {
+	server, calls := newCacheTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("ETag", `"v1"`)
+
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+
+			return
+		}
+
+		_, _ = w.Write([]byte("reference data"))
+	})
+
+	svc := NewHTTPService(server.URL, logging.NewMockLogger(logging.DEBUG), newCountingMetrics(t),
+		&CacheConfig{TTL: time.Minute})
+
+	resp, err := svc.Get(context.Background(), "countries", nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "reference data", readBody(t, resp))
+
+	resp, err = svc.GetWithHeaders(context.Background(), "countries", nil, map[string]string{"If-None-Match": `"v1"`})
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusNotModified, resp.StatusCode)
+	assert.Empty(t, readBody(t, resp))
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestCacheProvider_MetricsWithoutCounters(t *testing.T) {
+	server, calls := newCacheTestServer(t, func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("reference data"))
+	})
+
+	metrics := NewMockMetrics(gomock.NewController(t))
+	metrics.EXPECT().RecordHistogram(gomock.Any(), "app_http_service_response", gomock.Any(), gomock.Any()).AnyTimes()
+
+	svc := NewHTTPService(server.URL, logging.NewMockLogger(logging.DEBUG), metrics, &CacheConfig{TTL: time.Minute})
+
+	for range 2 {
+		resp, err := svc.Get(context.Background(), "countries", nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, "reference data", readBody(t, resp))
+	}
+
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestCacheProvider_StaleIfError(t *testing.T) {
+	var failing atomic.Bool
+
+	server, _ := newCacheTestServer(t, func(w http.ResponseWriter, _ *http.Request) {
+		if failing.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+
+			return
+		}
+
+		w.Header().Set("Cache-Control", "max-age=0")
+		_, _ = w.Write([]byte("reference data"))
+	})
+
+	tests := []struct {
+		desc           string
+		staleIfError   time.Duration
+		expectedStatus int
+	}{
+		{"stale response served", time.Minute, http.StatusOK},
+		{"stale responses disabled", 0, http.StatusServiceUnavailable},
+	}
+
+	for i, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			failing.Store(false)
+
+			svc := NewHTTPService(server.URL, logging.NewMockLogger(logging.DEBUG), nil,
+				&CacheConfig{StaleIfError: tc.staleIfError})
+
+			resp, err := svc.Get(context.Background(), "countries", nil)
+			require.NoError(t, err)
+			readBody(t, resp)
+
+			failing.Store(true)
+
+			resp, err = svc.Get(context.Background(), "countries", nil)
+			require.NoError(t, err)
+			readBody(t, resp)
+
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode, "TEST[%d], Failed.\n%s", i, tc.desc)
+		})
+	}
+}
+
+func TestCacheProvider_StaleIfErrorWhenCircuitOpen(t *testing.T) {
+	server, _ := newCacheTestServer(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Cache-Control", "max-age=0, stale-if-error=60")
+		_, _ = w.Write([]byte("reference data"))
+	})
+
+	svc := NewHTTPService(server.URL, logging.NewMockLogger(logging.DEBUG), nil,
+		&CircuitBreakerConfig{Threshold: 1, Interval: time.Hour}, &CacheConfig{})
+
+	resp, err := svc.Get(context.Background(), "countries", nil)
+	require.NoError(t, err)
+	readBody(t, resp)
+
+	server.Close()
+
+	for range 3 {
+		resp, err = svc.Get(context.Background(), "countries", nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, "reference data", readBody(t, resp))
+	}
+}
+
+func TestCacheProvider_OtherMethodsAreNotCached(t *testing.T) {
+	server, calls := newCacheTestServer(t, func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("created"))
+	})
+
+	svc := NewHTTPService(server.URL, logging.NewMockLogger(logging.DEBUG), nil, &CacheConfig{TTL: time.Minute})
+
+	for range 2 {
+		resp, err := svc.Post(context.Background(), "countries", nil, []byte(`{}`))
+		require.NoError(t, err)
+		readBody(t, resp)
+	}
+
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestParseCacheControl(t *testing.T) {
+	directives := parseCacheControl(`public, Max-Age=60, no-cache="Set-Cookie", ,stale-if-error=30`)
+
+	assert.Equal(t, map[string]string{
+		"public":         "",
+		"max-age":        "60",
+		"no-cache":       "Set-Cookie",
+		"stale-if-error": "30",
+	}, directives)
+}
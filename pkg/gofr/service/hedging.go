@@ -0,0 +1,224 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	defaultHedgingPercentile = 95
+	hedgingLatencyWindow     = 100
+	minHedgingSamples        = 20
+)
+
+// HedgingConfig enables hedged GET requests to the downstream HTTP Service: when a request is not answered within
+// the configured percentile of the observed latencies, another request is sent, and whichever is answered first is
+// returned while the others are cancelled. The hedged requests are sent to the same address, relying on the load
+// balancer of the service to route them to another instance.
+//
+// Only GET requests are hedged, as they are idempotent. HedgingConfig should be added before CircuitBreakerConfig,
+// so that a hedged call is counted once by the circuit breaker.
+type HedgingConfig struct {
+	// Percentile of the observed latencies after which a hedged request is sent, 95 by default.
+	Percentile float64
+	// Delay after which a hedged request is sent until enough latencies are observed. Requests are not
+	// hedged in the meantime when it is not set.
+	Delay time.Duration
+	// MaxHedgedRequests is the number of hedged requests sent in addition to the original one, 1 by default.
+	MaxHedgedRequests int
+}
+
+func (c *HedgingConfig) AddOption(h HTTP) HTTP {
+	percentile := c.Percentile
+	if percentile <= 0 || percentile > 100 {
+		percentile = defaultHedgingPercentile
+	}
+
+	maxHedged := c.MaxHedgedRequests
+	if maxHedged <= 0 {
+		maxHedged = 1
+	}
+
+	return &hedgingProvider{
+		percentile: percentile,
+		delay:      c.Delay,
+		maxHedged:  maxHedged,
+		latencies:  make([]time.Duration, 0, hedgingLatencyWindow),
+		tracer:     otel.Tracer("gofr-http-client"),
+		HTTP:       h,
+	}
+}
+
+type hedgingProvider struct {
+	percentile float64
+	delay      time.Duration
+	maxHedged  int
+
+	mu        sync.Mutex
+	latencies []time.Duration
+	next      int
+
+	tracer trace.Tracer
+
+	HTTP
+}
+
+type hedgedResult struct {
+	attempt int
+	resp    *http.Response
+	err     error
+	latency time.Duration
+	cancel  context.CancelFunc
+}
+
+func (hp *hedgingProvider) Get(ctx context.Context, path string, queryParams map[string]interface{}) (*http.Response, error) {
+	return hp.GetWithHeaders(ctx, path, queryParams, nil)
+}
+
+func (hp *hedgingProvider) GetWithHeaders(ctx context.Context, path string, queryParams map[string]interface{},
+	headers map[string]string) (*http.Response, error) {
+	delay := hp.hedgingDelay()
+	if delay <= 0 {
+		start := time.Now()
+
+		resp, err := hp.HTTP.GetWithHeaders(ctx, path, queryParams, headers)
+		if err == nil {
+			hp.recordLatency(time.Since(start))
+		}
+
+		return resp, err
+	}
+
+	ctx, span := hp.tracer.Start(ctx, "hedged-request")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("http.path", path), attribute.Int64("hedging.delay_ms", delay.Milliseconds()))
+
+	results := make(chan hedgedResult, hp.maxHedged+1)
+	cancels := make([]context.CancelFunc, 0, hp.maxHedged+1)
+
+	send := func(attempt int) {
+		attemptCtx, cancel := context.WithCancel(ctx)
+		cancels = append(cancels, cancel)
+		start := time.Now()
+
+		go func() {
+			resp, err := hp.HTTP.GetWithHeaders(attemptCtx, path, queryParams, headers)
+			results <- hedgedResult{attempt: attempt, resp: resp, err: err, latency: time.Since(start), cancel: cancel}
+		}()
+	}
+
+	send(0)
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	sent, pending := 1, 1
+
+	for {
+		select {
+		case <-timer.C:
+			if sent <= hp.maxHedged {
+				span.AddEvent("hedged request sent", trace.WithAttributes(attribute.Int("hedging.attempt", sent)))
+				send(sent)
+
+				sent++
+				pending++
+
+				timer.Reset(delay)
+			}
+		case r := <-results:
+			pending--
+
+			if r.err != nil && (pending > 0 || sent <= hp.maxHedged) {
+				r.cancel()
+
+				// another request is still in flight, or is sent right away
+				if pending == 0 {
+					send(sent)
+
+					sent++
+					pending++
+				}
+
+				continue
+			}
+
+			span.SetAttributes(attribute.Int("hedging.requests", sent), attribute.Int("hedging.winner", r.attempt))
+
+			return hp.complete(r, results, pending, cancels)
+		}
+	}
+}
+
+// complete returns the response of the winning request, while the pending requests are cancelled and their
+// responses discarded.
+func (hp *hedgingProvider) complete(winner hedgedResult, results <-chan hedgedResult, pending int,
+	cancels []context.CancelFunc) (*http.Response, error) {
+	for i, cancel := range cancels {
+		if i != winner.attempt {
+			cancel()
+		}
+	}
+
+	if winner.err != nil || winner.resp == nil {
+		winner.cancel()
+
+		return winner.resp, winner.err
+	}
+
+	hp.recordLatency(winner.latency)
+
+	winner.resp.Body = &cancelOnCloseBody{ReadCloser: winner.resp.Body, cancel: winner.cancel}
+
+	go func() {
+		for ; pending > 0; pending-- {
+			r := <-results
+			if r.resp != nil {
+				r.resp.Body.Close()
+			}
+		}
+	}()
+
+	return winner.resp, nil
+}
+
+// hedgingDelay returns the configured percentile of the observed latencies.
+func (hp *hedgingProvider) hedgingDelay() time.Duration {
+	hp.mu.Lock()
+	defer hp.mu.Unlock()
+
+	if len(hp.latencies) < minHedgingSamples {
+		return hp.delay
+	}
+
+	sorted := make([]time.Duration, len(hp.latencies))
+	copy(sorted, hp.latencies)
+
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	index := int(float64(len(sorted)-1) * hp.percentile / 100)
+
+	return sorted[index]
+}
+
+func (hp *hedgingProvider) recordLatency(latency time.Duration) {
+	hp.mu.Lock()
+	defer hp.mu.Unlock()
+
+	if len(hp.latencies) < hedgingLatencyWindow {
+		hp.latencies = append(hp.latencies, latency)
+
+		return
+	}
+
+	hp.latencies[hp.next] = latency
+	hp.next = (hp.next + 1) % hedgingLatencyWindow
+}
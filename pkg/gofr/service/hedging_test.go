@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gofr.dev/pkg/gofr/logging"
+	"gofr.dev/pkg/gofr/testutil"
+)
+
+func TestHedgingConfig_HedgesSlowRequests(t *testing.T) {
+	var requests atomic.Int32
+
+	// the first request is held until the test is over, so it can only be answered by the hedged request
+	release := make(chan struct{})
+	defer close(release)
+
+	server, calls := newCacheTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			select {
+			case <-release:
+			case <-r.Context().Done():
+			}
+
+			return
+		}
+
+		_, _ = w.Write([]byte("reference data"))
+	})
+
+	svc := NewHTTPService(server.URL, logging.NewMockLogger(logging.DEBUG), nil,
+		&HedgingConfig{Delay: 20 * time.Millisecond})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	resp, err := svc.Get(ctx, "countries", nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "reference data", readBody(t, resp))
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestHedgingConfig_FastRequestsAreNotHedged(t *testing.T) {
+	server, calls := newCacheTestServer(t, func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("reference data"))
+	})
+
+	tests := []struct {
+		desc   string
+		config HedgingConfig
+	}{
+		{"hedging delay not exceeded", HedgingConfig{Delay: time.Minute}},
+		{"no delay until latencies are observed", HedgingConfig{}},
+	}
+
+	for i, tc := range tests {
+		calls.Store(0)
+
+		svc := NewHTTPService(server.URL, logging.NewMockLogger(logging.DEBUG), nil, &tc.config)
+
+		resp, err := svc.Get(context.Background(), "countries", nil)
+		require.NoError(t, err, "TEST[%d], Failed.\n%s", i, tc.desc)
+
+		assert.Equal(t, "reference data", readBody(t, resp), "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, int32(1), calls.Load(), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestHedgingConfig_RetriesFailedRequestRightAway(t *testing.T) {
+	mockSvc := &hedgingTestService{failures: 1}
+
+	// the retry is not held back by the hedging delay, which would otherwise hold the test for a minute
+	svc := (&HedgingConfig{Delay: time.Minute, MaxHedgedRequests: 2}).AddOption(mockSvc)
+
+	resp, err := svc.Get(context.Background(), "countries", nil)
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(2), mockSvc.calls.Load())
+}
+
+func TestHedgingConfig_ReturnsErrorWhenAllRequestsFail(t *testing.T) {
+	mockSvc := &hedgingTestService{failures: 3}
+
+	svc := (&HedgingConfig{Delay: time.Second}).AddOption(mockSvc)
+
+	resp, err := svc.Get(context.Background(), "countries", nil)
+
+	require.ErrorIs(t, err, errHedgingTest)
+	assert.Nil(t, resp)
+	assert.Equal(t, int32(2), mockSvc.calls.Load())
+}
+
+func TestHedgingProvider_hedgingDelay(t *testing.T) {
+	hp := (&HedgingConfig{Delay: time.Second}).AddOption(nil).(*hedgingProvider)
+
+	for i := 1; i < minHedgingSamples; i++ {
+		hp.recordLatency(time.Duration(i) * time.Millisecond)
+	}
+
+	assert.Equal(t, time.Second, hp.hedgingDelay())
+
+	for i := minHedgingSamples; i <= 2*hedgingLatencyWindow; i++ {
+		hp.recordLatency(time.Duration(i) * time.Millisecond)
+	}
+
+	// only the latest latencies, 101ms to 200ms, are considered
+	assert.Len(t, hp.latencies, hedgingLatencyWindow)
+	assert.Equal(t, 195*time.Millisecond, hp.hedgingDelay())
+}
+
+var errHedgingTest = testutil.CustomError{ErrorMessage: "hedging test error"}
+
+// hedgingTestService fails the given number of requests before succeeding.
+type hedgingTestService struct {
+	failures int32
+	calls    atomic.Int32
+
+	HTTP
+}
+
+func (s *hedgingTestService) GetWithHeaders(context.Context, string, map[string]interface{},
+	map[string]string) (*http.Response, error) {
+	if s.calls.Add(1) <= s.failures {
+		return nil, errHedgingTest
+	}
+
+	return (&cacheEntry{StatusCode: http.StatusOK}).response(), nil
+}
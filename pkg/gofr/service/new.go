@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptrace"
+	"net/url"
 	"strings"
 	"time"
 
@@ -18,7 +19,8 @@ import (
 type httpService struct {
 	*http.Client
 	trace.Tracer
-	url string
+	url     string
+	timeout time.Duration
 	Logger
 	Metrics
 }
@@ -133,6 +135,32 @@ func (h *httpService) DeleteWithHeaders(ctx context.Context, path string, body [
 }
 
 func (h *httpService) createAndSendRequest(ctx context.Context, method string, path string,
+	queryParams map[string]interface{}, body []byte, headers map[string]string) (*http.Response, error) {
+	timeout := h.timeout
+	if t, ok := ctx.Value(requestTimeoutKey{}).(time.Duration); ok {
+		timeout = t
+	}
+
+	if timeout <= 0 {
+		return h.sendRequest(ctx, method, path, queryParams, body, headers)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+
+	resp, err := h.sendRequest(ctx, method, path, queryParams, body, headers)
+	if err != nil || resp == nil {
+		cancel()
+
+		return resp, err
+	}
+
+	// the deadline applies until the response body is read, hence the context is cancelled only when the body is closed
+	resp.Body = &cancelOnCloseBody{ReadCloser: resp.Body, cancel: cancel}
+
+	return resp, nil
+}
+
+func (h *httpService) sendRequest(ctx context.Context, method string, path string,
 	queryParams map[string]interface{}, body []byte, headers map[string]string) (*http.Response, error) {
 	uri := h.url + "/" + path
 	uri = strings.TrimRight(uri, "/")
@@ -209,6 +237,17 @@ func (h *httpService) updateMetrics(ctx context.Context, method string, timeTake
 func encodeQueryParameters(req *http.Request, queryParams map[string]interface{}) {
 	q := req.URL.Query()
 
+	for k, v := range queryValues(queryParams) {
+		q[k] = v
+	}
+
+	req.URL.RawQuery = q.Encode()
+}
+
+// queryValues converts the query parameters of a request to url.Values.
+func queryValues(queryParams map[string]interface{}) url.Values {
+	q := url.Values{}
+
 	for k, v := range queryParams {
 		switch vt := v.(type) {
 		case []string:
@@ -220,5 +259,5 @@ func encodeQueryParameters(req *http.Request, queryParams map[string]interface{}
 		}
 	}
 
-	req.URL.RawQuery = q.Encode()
+	return q
 }
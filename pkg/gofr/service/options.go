@@ -3,3 +3,37 @@ package service
 type Options interface {
 	AddOption(h HTTP) HTTP
 }
+
+// extractHTTPService unwraps the options applied on top of the base service and returns the underlying
+// httpService, so that options can make use of its client, logger and metrics. It returns nil when the
+// base service cannot be reached, e.g. when it is wrapped by an option defined outside this package.
+func extractHTTPService(h HTTP) *httpService {
+	for {
+		switch svc := h.(type) {
+		case *httpService:
+			return svc
+		case *circuitBreaker:
+			h = svc.HTTP
+		case *retryProvider:
+			h = svc.HTTP
+		case *customHeader:
+			h = svc.HTTP
+		case *customHealthService:
+			h = svc.HTTP
+		case *oAuth:
+			h = svc.HTTP
+		case *basicAuthProvider:
+			h = svc.HTTP
+		case *apiKeyAuthProvider:
+			h = svc.HTTP
+		case *cacheProvider:
+			h = svc.HTTP
+		case *hedgingProvider:
+			h = svc.HTTP
+		case *timeoutProvider:
+			h = svc.HTTP
+		default:
+			return nil
+		}
+	}
+}
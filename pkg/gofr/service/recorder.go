@@ -0,0 +1,354 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// RecorderMode decides whether a Recorder records the interactions with the real service or replays them.
+type RecorderMode int
+
+const (
+	// ReplayMode serves the responses from the interactions recorded in the fixture file.
+	ReplayMode RecorderMode = iota
+	// RecordMode calls the real service and writes the interactions to the fixture file.
+	RecordMode
+)
+
+var (
+	// ErrNoRecordedInteraction is returned in replay mode when no recorded interaction matches the request.
+	ErrNoRecordedInteraction = errors.New("no recorded interaction matches the request")
+	errNoRecordingTarget     = errors.New("recorder requires a target service in record mode")
+)
+
+// RecorderConfig configures a Recorder.
+type RecorderConfig struct {
+	// FixturePath is the golden file the interactions are written to and replayed from, e.g. "testdata/payments.json".
+	FixturePath string
+	// Mode is ReplayMode by default.
+	Mode RecorderMode
+	// Target is the real service used to record the interactions, it is only required in RecordMode.
+	Target HTTP
+	// IgnoreQueryParams are the query parameters not considered while matching a request, e.g. timestamps.
+	IgnoreQueryParams []string
+	// IgnoreBodyFields are the fields of JSON request bodies not considered while matching a request.
+	// Nested fields are separated by a dot, e.g. "meta.requestId".
+	IgnoreBodyFields []string
+}
+
+// Interaction is a request made to a service along with the response it returned.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+type RecordedRequest struct {
+	Method string              `json:"method"`
+	Path   string              `json:"path"`
+	Query  map[string][]string `json:"query,omitempty"`
+	Body   string              `json:"body,omitempty"`
+}
+
+type RecordedResponse struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Recorder is an HTTP service for tests, which records the interactions with a real service to a golden file once
+// and then replays them deterministically. Requests are matched on method, path, query parameters and body.
+type Recorder struct {
+	config RecorderConfig
+
+	mu           sync.Mutex
+	interactions []Interaction
+	replayed     map[int]bool
+	loadErr      error
+}
+
+// NewRecorder returns a Recorder for the given configuration. Failures to read the fixture file are returned
+// by the requests made through the Recorder.
+func NewRecorder(config RecorderConfig) *Recorder {
+	r := &Recorder{
+		config:   config,
+		replayed: make(map[int]bool),
+	}
+
+	if config.Mode == ReplayMode {
+		r.loadErr = r.load()
+	}
+
+	return r
+}
+
+func (r *Recorder) load() error {
+	data, err := os.ReadFile(r.config.FixturePath)
+	if err != nil {
+		return fmt.Errorf("unable to read fixture file %v: %w", r.config.FixturePath, err)
+	}
+
+	if err = json.Unmarshal(data, &r.interactions); err != nil {
+		return fmt.Errorf("unable to parse fixture file %v: %w", r.config.FixturePath, err)
+	}
+
+	return nil
+}
+
+func (r *Recorder) save() error {
+	data, err := json.MarshalIndent(r.interactions, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(r.config.FixturePath), os.ModePerm); err != nil {
+		return err
+	}
+
+	return os.WriteFile(r.config.FixturePath, append(data, '\n'), 0o600)
+}
+
+func (r *Recorder) do(ctx context.Context, method, path string, queryParams map[string]interface{}, body []byte,
+	headers map[string]string) (*http.Response, error) {
+	req := RecordedRequest{
+		Method: method,
+		Path:   strings.Trim(path, "/"),
+		Query:  queryValues(queryParams),
+		Body:   string(body),
+	}
+
+	if r.config.Mode == RecordMode {
+		return r.record(ctx, req, path, queryParams, body, headers)
+	}
+
+	return r.replay(req)
+}
+
+func (r *Recorder) record(ctx context.Context, req RecordedRequest, path string, queryParams map[string]interface{},
+	body []byte, headers map[string]string) (*http.Response, error) {
+	if r.config.Target == nil {
+		return nil, errNoRecordingTarget
+	}
+
+	var (
+		resp *http.Response
+		err  error
+	)
+
+	switch req.Method {
+	case http.MethodGet:
+		resp, err = r.config.Target.GetWithHeaders(ctx, path, queryParams, headers)
+	case http.MethodPost:
+		resp, err = r.config.Target.PostWithHeaders(ctx, path, queryParams, body, headers)
+	case http.MethodPut:
+		resp, err = r.config.Target.PutWithHeaders(ctx, path, queryParams, body, headers)
+	case http.MethodPatch:
+		resp, err = r.config.Target.PatchWithHeaders(ctx, path, queryParams, body, headers)
+	case http.MethodDelete:
+		resp, err = r.config.Target.DeleteWithHeaders(ctx, path, body, headers)
+	}
+
+	if err != nil {
+		return resp, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.interactions = append(r.interactions, Interaction{
+		Request:  req,
+		Response: RecordedResponse{StatusCode: resp.StatusCode, Header: resp.Header, Body: string(respBody)},
+	})
+
+	if err = r.save(); err != nil {
+		return nil, fmt.Errorf("unable to write fixture file %v: %w", r.config.FixturePath, err)
+	}
+
+	return resp, nil
+}
+
+// replay returns the response of the first matching interaction which has not been replayed yet, once all the
+// matching interactions are replayed the last one keeps being returned.
+func (r *Recorder) replay(req RecordedRequest) (*http.Response, error) {
+	if r.loadErr != nil {
+		return nil, r.loadErr
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := r.matchKey(req)
+	matched := -1
+
+	for i := range r.interactions {
+		if r.matchKey(r.interactions[i].Request) != key {
+			continue
+		}
+
+		matched = i
+
+		if !r.replayed[i] {
+			break
+		}
+	}
+
+	if matched == -1 {
+		return nil, fmt.Errorf("%w: %v %v", ErrNoRecordedInteraction, req.Method, key)
+	}
+
+	r.replayed[matched] = true
+	recorded := r.interactions[matched].Response
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+		StatusCode:    recorded.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        recorded.Header.Clone(),
+		Body:          io.NopCloser(strings.NewReader(recorded.Body)),
+		ContentLength: int64(len(recorded.Body)),
+	}, nil
+}
+
+// matchKey returns the representation of a request used for matching, leaving out the ignored query parameters
+// and body fields.
+func (r *Recorder) matchKey(req RecordedRequest) string {
+	query := make(map[string][]string, len(req.Query))
+
+	for k, v := range req.Query {
+		query[k] = v
+	}
+
+	for _, k := range r.config.IgnoreQueryParams {
+		delete(query, k)
+	}
+
+	encodedQuery, _ := json.Marshal(query)
+
+	return req.Method + " " + req.Path + " " + string(encodedQuery) + " " + r.normalizeBody(req.Body)
+}
+
+// normalizeBody removes the ignored fields from a JSON body and encodes it with sorted keys, so that bodies differing
+// only in formatting or key order match. Bodies which are not JSON are compared as they are.
+func (r *Recorder) normalizeBody(body string) string {
+	var decoded interface{}
+
+	if err := json.Unmarshal([]byte(body), &decoded); err != nil {
+		return body
+	}
+
+	for _, field := range r.config.IgnoreBodyFields {
+		deleteField(decoded, strings.Split(field, "."))
+	}
+
+	normalized, err := json.Marshal(decoded)
+	if err != nil {
+		return body
+	}
+
+	return string(normalized)
+}
+
+func deleteField(value interface{}, path []string) {
+	obj, ok := value.(map[string]interface{})
+	if !ok {
+		if arr, isArray := value.([]interface{}); isArray {
+			for _, v := range arr {
+				deleteField(v, path)
+			}
+		}
+
+		return
+	}
+
+	if len(path) == 1 {
+		delete(obj, path[0])
+
+		return
+	}
+
+	deleteField(obj[path[0]], path[1:])
+}
+
+func (r *Recorder) Get(ctx context.Context, path string, queryParams map[string]interface{}) (*http.Response, error) {
+	return r.do(ctx, http.MethodGet, path, queryParams, nil, nil)
+}
+
+func (r *Recorder) GetWithHeaders(ctx context.Context, path string, queryParams map[string]interface{},
+	headers map[string]string) (*http.Response, error) {
+	return r.do(ctx, http.MethodGet, path, queryParams, nil, headers)
+}
+
+func (r *Recorder) Post(ctx context.Context, path string, queryParams map[string]interface{},
+	body []byte) (*http.Response, error) {
+	return r.do(ctx, http.MethodPost, path, queryParams, body, nil)
+}
+
+func (r *Recorder) PostWithHeaders(ctx context.Context, path string, queryParams map[string]interface{}, body []byte,
+	headers map[string]string) (*http.Response, error) {
+	return r.do(ctx, http.MethodPost, path, queryParams, body, headers)
+}
+
+func (r *Recorder) Put(ctx context.Context, path string, queryParams map[string]interface{},
+	body []byte) (*http.Response, error) {
+	return r.do(ctx, http.MethodPut, path, queryParams, body, nil)
+}
+
+func (r *Recorder) PutWithHeaders(ctx context.Context, path string, queryParams map[string]interface{}, body []byte,
+	headers map[string]string) (*http.Response, error) {
+	return r.do(ctx, http.MethodPut, path, queryParams, body, headers)
+}
+
+func (r *Recorder) Patch(ctx context.Context, path string, queryParams map[string]interface{},
+	body []byte) (*http.Response, error) {
+	return r.do(ctx, http.MethodPatch, path, queryParams, body, nil)
+}
+
+func (r *Recorder) PatchWithHeaders(ctx context.Context, path string, queryParams map[string]interface{}, body []byte,
+	headers map[string]string) (*http.Response, error) {
+	return r.do(ctx, http.MethodPatch, path, queryParams, body, headers)
+}
+
+func (r *Recorder) Delete(ctx context.Context, path string, body []byte) (*http.Response, error) {
+	return r.do(ctx, http.MethodDelete, path, nil, body, nil)
+}
+
+func (r *Recorder) DeleteWithHeaders(ctx context.Context, path string, body []byte,
+	headers map[string]string) (*http.Response, error) {
+	return r.do(ctx, http.MethodDelete, path, nil, body, headers)
+}
+
+// HealthCheck reports the target service health while recording, and the service as up while replaying.
+func (r *Recorder) HealthCheck(ctx context.Context) *Health {
+	if r.config.Mode == RecordMode && r.config.Target != nil {
+		return r.config.Target.HealthCheck(ctx)
+	}
+
+	return &Health{Status: serviceUp, Details: map[string]interface{}{"fixture": r.config.FixturePath}}
+}
+
+func (r *Recorder) getHealthResponseForEndpoint(ctx context.Context, endpoint string, timeout int) *Health {
+	if r.config.Mode == RecordMode && r.config.Target != nil {
+		return r.config.Target.getHealthResponseForEndpoint(ctx, endpoint, timeout)
+	}
+
+	return r.HealthCheck(ctx)
+}
@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gofr.dev/pkg/gofr/logging"
+)
+
+func recordFixture(t *testing.T, config RecorderConfig) {
+	t.Helper()
+
+	server, _ := newCacheTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.Method {
+		case http.MethodPost:
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":"order-1"}`))
+		default:
+			_, _ = w.Write([]byte(`{"page":"` + r.URL.Query().Get("page") + `"}`))
+		}
+	})
+
+	config.Mode = RecordMode
+	config.Target = NewHTTPService(server.URL, logging.NewMockLogger(logging.DEBUG), nil)
+	recorder := NewRecorder(config)
+
+	resp, err := recorder.Get(context.Background(), "orders", map[string]interface{}{"page": 1, "ts": 100})
+	require.NoError(t, err)
+	assert.Equal(t, `{"page":"1"}`, readBody(t, resp))
+
+	resp, err = recorder.PostWithHeaders(context.Background(), "/orders", nil,
+		[]byte(`{"item":"book","meta":{"requestId":"a1"}}`), map[string]string{"X-Request-ID": "a1"})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, `{"id":"order-1"}`, readBody(t, resp))
+}
+
+func TestRecorder_RecordAndReplay(t *testing.T) {
+	config := RecorderConfig{
+		FixturePath:       filepath.Join(t.TempDir(), "testdata", "orders.json"),
+		IgnoreQueryParams: []string{"ts"},
+		IgnoreBodyFields:  []string{"meta.requestId"},
+	}
+
+	recordFixture(t, config)
+
+	data, err := os.ReadFile(config.FixturePath)
+	require.NoError(t, err)
+
+	var interactions []Interaction
+
+	require.NoError(t, json.Unmarshal(data, &interactions))
+	require.Len(t, interactions, 2)
+	assert.Equal(t, RecordedRequest{Method: http.MethodGet, Path: "orders",
+		Query: map[string][]string{"page": {"1"}, "ts": {"100"}}}, interactions[0].Request)
+
+	recorder := NewRecorder(config)
+
+	resp, err := recorder.Get(context.Background(), "orders", map[string]interface{}{"page": 1, "ts": 200})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	assert.Equal(t, `{"page":"1"}`, readBody(t, resp))
+
+	resp, err = recorder.Post(context.Background(), "orders", nil, []byte(`{"meta": {"requestId": "b2"}, "item": "book"}`))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, `{"id":"order-1"}`, readBody(t, resp))
+}
+
+func TestRecorder_ReplayErrors(t *testing.T) {
+	config := RecorderConfig{FixturePath: filepath.Join(t.TempDir(), "orders.json")}
+
+	_, err := NewRecorder(config).Get(context.Background(), "orders", nil)
+	require.ErrorContains(t, err, "unable to read fixture file")
+
+	recordFixture(t, config)
+
+	recorder := NewRecorder(config)
+
+	tests := []struct {
+		desc  string
+		call  func() error
+		errIs error
+	}{
+		{"query param not ignored", func() error {
+			_, err := recorder.Get(context.Background(), "orders", map[string]interface{}{"page": 1, "ts": 200})
+			return err
+		}, ErrNoRecordedInteraction},
+		{"different method", func() error {
+			_, err := recorder.Delete(context.Background(), "orders", nil)
+			return err
+		}, ErrNoRecordedInteraction},
+		{"body field not ignored", func() error {
+			_, err := recorder.Post(context.Background(), "orders", nil, []byte(`{"item":"book","meta":{"requestId":"b2"}}`))
+			return err
+		}, ErrNoRecordedInteraction},
+	}
+
+	for i, tc := range tests {
+		err := tc.call()
+
+		require.ErrorIs(t, err, tc.errIs, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestRecorder_ReplaysMatchingInteractionsInOrder(t *testing.T) {
+	fixture := filepath.Join(t.TempDir(), "orders.json")
+	interactions := `[
+		{"request":{"method":"GET","path":"orders/1"},"response":{"statusCode":200,"body":"pending"}},
+		{"request":{"method":"GET","path":"orders/1"},"response":{"statusCode":200,"body":"shipped"}}
+	]`
+
+	require.NoError(t, os.WriteFile(fixture, []byte(interactions), 0o600))
+
+	recorder := NewRecorder(RecorderConfig{FixturePath: fixture})
+
+	for _, expected := range []string{"pending", "shipped", "shipped"} {
+		resp, err := recorder.Get(context.Background(), "orders/1", nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, expected, readBody(t, resp))
+	}
+
+	assert.Equal(t, serviceUp, recorder.HealthCheck(context.Background()).Status)
+}
+
+func TestRecorder_RecordWithoutTarget(t *testing.T) {
+	recorder := NewRecorder(RecorderConfig{FixturePath: filepath.Join(t.TempDir(), "orders.json"), Mode: RecordMode})
+
+	_, err := recorder.Get(context.Background(), "orders", nil)
+
+	require.ErrorIs(t, err, errNoRecordingTarget)
+}
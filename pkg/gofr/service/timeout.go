@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// TimeoutConfig sets the time after which the requests to the downstream HTTP Service are cancelled.
+// The timeout covers the whole request, including reading the response body.
+type TimeoutConfig struct {
+	Timeout time.Duration
+}
+
+func (t *TimeoutConfig) AddOption(h HTTP) HTTP {
+	if svc := extractHTTPService(h); svc != nil {
+		svc.timeout = t.Timeout
+
+		return h
+	}
+
+	if t.Timeout <= 0 {
+		return h
+	}
+
+	// the service is wrapped by an option unknown to the package, the timeout is then set on the requests instead
+	return &timeoutProvider{timeout: t.Timeout, HTTP: h}
+}
+
+// timeoutProvider sets the timeout on the requests made through options which do not expose the service they wrap.
+type timeoutProvider struct {
+	timeout time.Duration
+
+	HTTP
+}
+
+// withTimeout sets the timeout of the service on the context, unless a timeout was already set with WithRequestTimeout.
+func (t *timeoutProvider) withTimeout(ctx context.Context) context.Context {
+	if _, ok := ctx.Value(requestTimeoutKey{}).(time.Duration); ok {
+		return ctx
+	}
+
+	return WithRequestTimeout(ctx, t.timeout)
+}
+
+func (t *timeoutProvider) Get(ctx context.Context, path string, queryParams map[string]interface{}) (*http.Response, error) {
+	return t.HTTP.Get(t.withTimeout(ctx), path, queryParams)
+}
+
+func (t *timeoutProvider) GetWithHeaders(ctx context.Context, path string, queryParams map[string]interface{},
+	headers map[string]string) (*http.Response, error) {
+	return t.HTTP.GetWithHeaders(t.withTimeout(ctx), path, queryParams, headers)
+}
+
+func (t *timeoutProvider) Post(ctx context.Context, path string, queryParams map[string]interface{},
+	body []byte) (*http.Response, error) {
+	return t.HTTP.Post(t.withTimeout(ctx), path, queryParams, body)
+}
+
+func (t *timeoutProvider) PostWithHeaders(ctx context.Context, path string, queryParams map[string]interface{},
+	body []byte, headers map[string]string) (*http.Response, error) {
+	return t.HTTP.PostWithHeaders(t.withTimeout(ctx), path, queryParams, body, headers)
+}
+
+func (t *timeoutProvider) Put(ctx context.Context, path string, queryParams map[string]interface{},
+	body []byte) (*http.Response, error) {
+	return t.HTTP.Put(t.withTimeout(ctx), path, queryParams, body)
+}
+
+func (t *timeoutProvider) PutWithHeaders(ctx context.Context, path string, queryParams map[string]interface{},
+	body []byte, headers map[string]string) (*http.Response, error) {
+	return t.HTTP.PutWithHeaders(t.withTimeout(ctx), path, queryParams, body, headers)
+}
+
+func (t *timeoutProvider) Patch(ctx context.Context, path string, queryParams map[string]interface{},
+	body []byte) (*http.Response, error) {
+	return t.HTTP.Patch(t.withTimeout(ctx), path, queryParams, body)
+}
+
+func (t *timeoutProvider) PatchWithHeaders(ctx context.Context, path string, queryParams map[string]interface{},
+	body []byte, headers map[string]string) (*http.Response, error) {
+	return t.HTTP.PatchWithHeaders(t.withTimeout(ctx), path, queryParams, body, headers)
+}
+
+func (t *timeoutProvider) Delete(ctx context.Context, path string, body []byte) (*http.Response, error) {
+	return t.HTTP.Delete(t.withTimeout(ctx), path, body)
+}
+
+func (t *timeoutProvider) DeleteWithHeaders(ctx context.Context, path string, body []byte,
+	headers map[string]string) (*http.Response, error) {
+	return t.HTTP.DeleteWithHeaders(t.withTimeout(ctx), path, body, headers)
+}
+
+type requestTimeoutKey struct{}
+
+// WithRequestTimeout returns a context which sets the timeout of the requests made with it, overriding the
+// timeout set for the service using TimeoutConfig.
+//
+//	resp, err := svc.Get(service.WithRequestTimeout(ctx, 200*time.Millisecond), "orders", nil)
+func WithRequestTimeout(ctx context.Context, timeout time.Duration) context.Context {
+	return context.WithValue(ctx, requestTimeoutKey{}, timeout)
+}
+
+// cancelOnCloseBody cancels the context of a request once its response body is closed.
+type cancelOnCloseBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+	once   sync.Once
+}
+
+func (b *cancelOnCloseBody) Close() error {
+	err := b.ReadCloser.Close()
+
+	b.once.Do(b.cancel)
+
+	return err
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gofr.dev/pkg/gofr/logging"
+)
+
+func TestTimeoutConfig(t *testing.T) {
+	server, _ := newCacheTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		// the slow requests are only answered once cancelled by the client
+		if r.URL.Path == "/orders/slow" {
+			<-r.Context().Done()
+
+			return
+		}
+
+		time.Sleep(50 * time.Millisecond)
+
+		_, _ = w.Write([]byte("slow response"))
+	})
+
+	svc := NewHTTPService(server.URL, logging.NewMockLogger(logging.DEBUG), nil,
+		&TimeoutConfig{Timeout: 20 * time.Millisecond})
+
+	tests := []struct {
+		desc    string
+		ctx     context.Context
+		path    string
+		wantErr bool
+	}{
+		{"service timeout exceeded", context.Background(), "orders/slow", true},
+		{"call timeout overrides service timeout", WithRequestTimeout(context.Background(), time.Minute), "orders", false},
+		{"call timeout exceeded", WithRequestTimeout(context.Background(), 10*time.Millisecond), "orders/slow", true},
+	}
+
+	for i, tc := range tests {
+		resp, err := svc.Get(tc.ctx, tc.path, nil)
+
+		if tc.wantErr {
+			require.ErrorIs(t, err, context.DeadlineExceeded, "TEST[%d], Failed.\n%s", i, tc.desc)
+
+			continue
+		}
+
+		require.NoError(t, err, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, "slow response", readBody(t, resp), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+// unknownOption wraps the service in a way the options of the package cannot see through.
+type unknownOption struct {
+	HTTP
+}
+
+func (*unknownOption) AddOption(h HTTP) HTTP {
+	return &unknownOption{HTTP: h}
+}
+
+func TestTimeoutConfig_WrappedByUnknownOption(t *testing.T) {
+	server, _ := newCacheTestServer(t, func(_ http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+
+	svc := NewHTTPService(server.URL, logging.NewMockLogger(logging.DEBUG), nil,
+		&unknownOption{}, &TimeoutConfig{Timeout: 20 * time.Millisecond})
+
+	_, err := svc.Get(context.Background(), "orders", nil)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	_, err = svc.PostWithHeaders(context.Background(), "orders", nil, nil, nil)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestTimeoutConfig_WithoutTimeout(t *testing.T) {
+	server, _ := newCacheTestServer(t, func(w http.ResponseWriter, _ *http.Request) {
+		time.Sleep(20 * time.Millisecond)
+
+		_, _ = w.Write([]byte("slow response"))
+	})
+
+	svc := NewHTTPService(server.URL, logging.NewMockLogger(logging.DEBUG), nil, &TimeoutConfig{})
+
+	resp, err := svc.Get(context.Background(), "orders", nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "slow response", readBody(t, resp))
+}
@@ -2,28 +2,127 @@ package gofr
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"runtime/debug"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
 
 	"gofr.dev/pkg/gofr/container"
+	"gofr.dev/pkg/gofr/datasource/pubsub"
 	"gofr.dev/pkg/gofr/logging"
+	"gofr.dev/pkg/gofr/version"
 )
 
 type SubscribeFunc func(c *Context) error
 
+const (
+	defaultRetryBackoff    = 100 * time.Millisecond
+	defaultMaxRetryBackoff = 5 * time.Second
+)
+
+var errHandlerPanic = errors.New("panic in handler")
+
+// SubscribeOption configures how the messages of a subscription are handled when its handler fails.
+type SubscribeOption interface {
+	apply(o *subscribeOptions)
+}
+
+type subscribeOptions struct {
+	retry      *RetryPolicy
+	deadLetter *DeadLetter
+	workers    *Workers
+}
+
+// RetryPolicy retries a message when the handler returns an error or panics, waiting with an exponential backoff
+// between the attempts. Messages are handled once when no RetryPolicy is given, and the errors wrapping
+// pubsub.ErrInvalidMessage are not retried.
+type RetryPolicy struct {
+	// MaxAttempts including the first one.
+	MaxAttempts int
+	// InitialBackoff is 100ms and MaxBackoff is 5s by default.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+func (r *RetryPolicy) apply(o *subscribeOptions) {
+	o.retry = r
+}
+
+// DeadLetter publishes the messages which could not be handled, once the attempts are exhausted, to the given topic
+// as a DeadLetterMessage. The original message is committed once it is published to the dead-letter topic.
+type DeadLetter struct {
+	Topic string
+}
+
+func (d *DeadLetter) apply(o *subscribeOptions) {
+	o.deadLetter = d
+}
+
+// Workers handles the messages of a subscription concurrently. The messages with the same key within a partition are
+// handled by the same worker in the order they are received, as are the messages of a partition without a key, e.g.
+// on Kafka. The messages without a partition or a key are spread across the workers. The messages of each partition
+// are committed in the order they are received, so the commits of a partition only advance once all of its earlier
+// messages are handled, while the other partitions are committed independently. The messages without a partition are
+// committed as soon as they are handled.
+type Workers struct {
+	Count int
+	// QueueSize is the number of messages queued for each worker, 1 by default.
+	QueueSize int
+}
+
+func (w *Workers) apply(o *subscribeOptions) {
+	o.workers = w
+}
+
+// DeadLetterMessage is published to the dead-letter topic of a subscription, along with the error of the last attempt.
+// Value holds the original bytes of the message, encoded in base64 in JSON, so that binary messages can be replayed.
+type DeadLetterMessage struct {
+	Topic    string    `json:"topic"`
+	Value    []byte    `json:"value"`
+	Error    string    `json:"error"`
+	Attempts int       `json:"attempts"`
+	FailedAt time.Time `json:"failed_at"`
+}
+
 type SubscriptionManager struct {
-	container     *container.Container
-	subscriptions map[string]SubscribeFunc
+	container          *container.Container
+	subscriptions      map[string]SubscribeFunc
+	batchSubscriptions map[string]batchSubscription
+	options            map[string]subscribeOptions
+	health             *subscriptionHealth
+	controls           *subscriptionControls
 }
 
 func newSubscriptionManager(c *container.Container) SubscriptionManager {
+	health := newSubscriptionHealth()
+
+	// the health of the subscriptions is reported along with the health of the pub/sub
+	if c != nil {
+		c.Subscriptions = health
+	}
+
 	return SubscriptionManager{
-		container:     c,
-		subscriptions: make(map[string]SubscribeFunc),
+		container:          c,
+		subscriptions:      make(map[string]SubscribeFunc),
+		batchSubscriptions: make(map[string]batchSubscription),
+		options:            make(map[string]subscribeOptions),
+		health:             health,
+		controls:           &subscriptionControls{topics: make(map[string]*subscriptionControl)},
 	}
 }
 
 // startSubscriber continuously subscribes to a topic and handles messages using the provided handler.
 func (s *SubscriptionManager) startSubscriber(ctx context.Context, topic string, handler SubscribeFunc) error {
+	s.health.add(topic)
+
+	if workers := s.options[topic].workers; workers != nil && workers.Count > 1 {
+		return s.startConcurrentSubscriber(ctx, topic, handler, workers)
+	}
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -38,41 +137,183 @@ func (s *SubscriptionManager) startSubscriber(ctx context.Context, topic string,
 	}
 }
 
+// handleSubscription handles a message of the topic, completing it once it is handled. The message is read once the
+// subscription is running, and is not committed while it is paused.
 func (s *SubscriptionManager) handleSubscription(ctx context.Context, topic string, handler SubscribeFunc) error {
+	control := s.controls.get(topic)
+	if !control.waitRunning(ctx) {
+		return nil
+	}
+
 	msg, err := s.container.GetSubscriber().Subscribe(ctx, topic)
 
 	if err != nil {
 		s.container.Logger.Errorf("error while reading from topic %v, err: %v", topic, err.Error())
+		s.health.readFailed(topic, err)
 
 		return err
 	}
 
-	if msg == nil {
+	if msg == nil || !control.acquire(ctx, 1) {
 		return nil
 	}
 
-	// newContext creates a new context from the msg.Context()
-	msgCtx := newContext(nil, msg, s.container)
-	err = func(ctx *Context) error {
-		// TODO : Move panic recovery at central location which will manage for all the different cases.
-		defer func() {
-			panicRecovery(recover(), ctx.Logger)
-		}()
+	defer control.release(1)
+
+	err = s.handleMessage(ctx, topic, msg, handler)
+
+	if control.waitUnpaused(ctx) {
+		completeMessage(msg, err, s.options[topic].nacksFailures())
+	}
 
-		return handler(ctx)
-	}(msgCtx)
+	return nil
+}
+
+// handleMessage calls the handler for the message as per the retry policy of the subscription, publishing it to the
+// dead-letter topic once the attempts are exhausted. It returns the error of the message when it could neither be
+// handled nor published to the dead-letter topic.
+func (s *SubscriptionManager) handleMessage(ctx context.Context, topic string, msg *pubsub.Message,
+	handler SubscribeFunc) error {
+	options := s.options[topic]
+
+	if codec, ok := s.container.Codecs[topic]; ok {
+		msg.Codec = codec
+	}
+
+	s.recordReceived(topic, msg)
+
+	// the span of the message continues the trace of the publisher when it is propagated through the headers
+	msgCtx, span := otel.GetTracerProvider().Tracer("gofr-"+version.Framework).
+		Start(pubsub.ExtractTraceContext(msg.Context(), msg.Headers), "process "+topic,
+			trace.WithSpanKind(trace.SpanKindConsumer))
+	defer span.End()
+
+	attempts, err := s.handleWithRetry(ctx, topic, options.retry, func() error {
+		defer s.recordHandlerDuration(ctx, topic, time.Now())
+
+		return s.callHandler(msgCtx, msg, handler)
+	})
+
+	s.recordHandled(ctx, topic, err)
+
+	if err != nil && options.deadLetter != nil {
+		err = s.publishDeadLetter(msgCtx, msg, options.deadLetter.Topic, attempts, err)
+	}
+
+	return err
+}
+
+// nacksFailures reports whether the messages which could not be handled are nacked. They are only nacked when the
+// subscription has a retry policy or a dead-letter topic, as the backends redeliver the nacked messages right away,
+// which would otherwise handle a failing message over and over. The messages are left uncommitted otherwise.
+func (o subscribeOptions) nacksFailures() bool {
+	return o.retry != nil || o.deadLetter != nil
+}
+
+// completeMessage commits the message when it is handled or published to the dead-letter topic. A message which
+// could not be handled is nacked when nack is set, so that it is redelivered as per the backend, and is left
+// uncommitted otherwise.
+//
+// Kafka commits offsets rather than messages, so it cannot nack: a message which could not be handled is skipped
+// once a later message of its partition is committed. A DeadLetter keeps these messages on Kafka.
+func completeMessage(msg *pubsub.Message, err error, nack bool) {
+	if msg.Committer == nil {
+		return
+	}
 
 	if err != nil {
+		if nack {
+			msg.Nack()
+		}
+
+		return
+	}
+
+	msg.Commit()
+}
+
+// handleWithRetry calls handle until it succeeds or the attempts of the retry policy are exhausted, returning the
+// number of attempts made along with the error of the last one.
+func (s *SubscriptionManager) handleWithRetry(ctx context.Context, topic string, retry *RetryPolicy,
+	handle func() error) (int, error) {
+	maxAttempts, backoff, maxBackoff := 1, defaultRetryBackoff, defaultMaxRetryBackoff
+
+	if retry != nil {
+		maxAttempts = max(retry.MaxAttempts, 1)
+
+		if retry.InitialBackoff > 0 {
+			backoff = retry.InitialBackoff
+		}
+
+		if retry.MaxBackoff > 0 {
+			maxBackoff = retry.MaxBackoff
+		}
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := handle()
+		if err == nil {
+			return attempt, nil
+		}
+
 		s.container.Logger.Errorf("error in handler for topic %s: %v", topic, err)
 
-		return nil
+		// the messages which do not conform to the schema of the topic would fail again
+		if attempt >= maxAttempts || errors.Is(err, pubsub.ErrInvalidMessage) {
+			return attempt, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return attempt, err
+		case <-time.After(backoff):
+		}
+
+		backoff = min(2*backoff, maxBackoff)
 	}
+}
+
+// callHandler calls the handler with a new context for the message, returning the panics as errors.
+func (s *SubscriptionManager) callHandler(msgCtx context.Context, msg *pubsub.Message, handler SubscribeFunc) (err error) {
+	ctx := newContext(nil, msg, s.container)
+	ctx.Context = msgCtx
+
+	defer func() {
+		if re := recover(); re != nil {
+			// TODO : Move panic recovery at central location which will manage for all the different cases.
+			panicRecovery(re, ctx.Logger)
+
+			err = fmt.Errorf("%w: %v", errHandlerPanic, re)
+		}
+	}()
 
-	if msg.Committer != nil {
-		// commit the message if the subscription function does not return error
-		msg.Commit()
+	return handler(ctx)
+}
+
+func (s *SubscriptionManager) publishDeadLetter(ctx context.Context, msg *pubsub.Message, deadLetterTopic string,
+	attempts int, handlerErr error) error {
+	message, err := json.Marshal(DeadLetterMessage{
+		Topic:    msg.Topic,
+		Value:    msg.Value,
+		Error:    handlerErr.Error(),
+		Attempts: attempts,
+		FailedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		return err
 	}
 
+	err = s.container.GetPublisher().Publish(ctx, deadLetterTopic, message)
+	if err != nil {
+		s.container.Logger.Errorf("error publishing message of topic %s to dead-letter topic %s: %v", msg.Topic,
+			deadLetterTopic, err)
+
+		return err
+	}
+
+	s.container.Logger.Infof("published message of topic %s to dead-letter topic %s after %d attempts", msg.Topic,
+		deadLetterTopic, attempts)
+
 	return nil
 }
 
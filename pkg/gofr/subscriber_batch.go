@@ -0,0 +1,267 @@
+package gofr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+
+	"gofr.dev/pkg/gofr/datasource/pubsub"
+	"gofr.dev/pkg/gofr/version"
+)
+
+const (
+	defaultBatchSize = 100
+	defaultBatchWait = time.Second
+)
+
+// SubscribeBatchFunc handles a batch of messages of a topic, the messages are committed together once it succeeds.
+type SubscribeBatchFunc func(c *Context, messages []*pubsub.Message) error
+
+// PartialBatchError is returned by a SubscribeBatchFunc when only some of the messages of the batch have failed, so
+// that the other messages are committed, while just the failed ones are retried or published to the dead-letter
+// topic as per the options of the subscription.
+type PartialBatchError struct {
+	// Failed are the errors of the failed messages, by their index in the batch given to the handler.
+	Failed map[int]error
+}
+
+func (e *PartialBatchError) Error() string {
+	indexes := make([]int, 0, len(e.Failed))
+	for i := range e.Failed {
+		indexes = append(indexes, i)
+	}
+
+	sort.Ints(indexes)
+
+	errs := make([]string, 0, len(indexes))
+	for _, i := range indexes {
+		errs = append(errs, fmt.Sprintf("message %d: %v", i, e.Failed[i]))
+	}
+
+	return fmt.Sprintf("%d messages of the batch failed: %s", len(e.Failed), strings.Join(errs, ", "))
+}
+
+type batchSubscription struct {
+	handler SubscribeBatchFunc
+	maxSize int
+	maxWait time.Duration
+}
+
+// batchConfig returns the size and wait of the batches of the subscription, using the batch configs of the backend,
+// or the defaults, for the ones which are not set.
+func (s *SubscriptionManager) batchConfig(sub batchSubscription) (size int, wait time.Duration) {
+	size, wait = defaultBatchSize, defaultBatchWait
+
+	if configurer, ok := s.container.GetSubscriber().(pubsub.BatchConfigurer); ok {
+		if configuredSize, configuredWait := configurer.BatchConfig(); configuredSize > 0 {
+			size = configuredSize
+
+			if configuredWait > 0 {
+				wait = configuredWait
+			}
+		}
+	}
+
+	if sub.maxSize > 0 {
+		size = sub.maxSize
+	}
+
+	if sub.maxWait > 0 {
+		wait = sub.maxWait
+	}
+
+	return size, wait
+}
+
+// startBatchSubscriber continuously subscribes to a topic and handles its messages in batches.
+func (s *SubscriptionManager) startBatchSubscriber(ctx context.Context, topic string, sub batchSubscription) error {
+	size, wait := s.batchConfig(sub)
+
+	s.health.add(topic)
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.container.Logger.Infof("shutting down subscriber for topic %s", topic)
+			return nil
+		default:
+			err := s.handleBatch(ctx, topic, sub.handler, size, wait)
+			if err != nil {
+				s.container.Logger.Errorf("error in subscription for topic %s: %v", topic, err)
+			}
+		}
+	}
+}
+
+// receiveBatch subscribes to the topic until size messages are received, or wait has passed since the first one.
+func (s *SubscriptionManager) receiveBatch(ctx context.Context, topic string, size int,
+	wait time.Duration) ([]*pubsub.Message, error) {
+	msg, err := s.container.GetSubscriber().Subscribe(ctx, topic)
+	if err != nil {
+		s.container.Logger.Errorf("error while reading from topic %v, err: %v", topic, err.Error())
+		s.health.readFailed(topic, err)
+
+		return nil, err
+	}
+
+	if msg == nil {
+		return nil, nil
+	}
+
+	messages := []*pubsub.Message{msg}
+
+	waitCtx, cancel := context.WithTimeout(ctx, wait)
+	defer cancel()
+
+	for len(messages) < size && waitCtx.Err() == nil {
+		msg, err = s.container.GetSubscriber().Subscribe(waitCtx, topic)
+
+		switch {
+		case err != nil && waitCtx.Err() == nil:
+			// the messages received so far are handled, and the error is returned by the next subscription
+			s.container.Logger.Errorf("error while reading from topic %v, err: %v", topic, err.Error())
+
+			return messages, nil
+		case err == nil && msg != nil:
+			messages = append(messages, msg)
+		}
+	}
+
+	return messages, nil
+}
+
+// handleBatch handles a batch of messages of the topic. The messages which are handled, or published to the
+// dead-letter topic, are committed, while the others are completed as per completeMessage.
+// The batch is received once the subscription is running, and is not completed while it is paused.
+func (s *SubscriptionManager) handleBatch(ctx context.Context, topic string, handler SubscribeBatchFunc, size int,
+	wait time.Duration) error {
+	control := s.controls.get(topic)
+	if !control.waitRunning(ctx) {
+		return nil
+	}
+
+	messages, err := s.receiveBatch(ctx, topic, size, wait)
+	if err != nil || len(messages) == 0 || !control.acquire(ctx, len(messages)) {
+		return err
+	}
+
+	defer control.release(len(messages))
+
+	options := s.options[topic]
+
+	codec := s.container.Codecs[topic]
+
+	for _, msg := range messages {
+		msg.Codec = codec
+
+		s.recordReceived(topic, msg)
+	}
+
+	// the span of a batch is linked to the spans which published its messages, as it can have only one parent
+	links := make([]trace.Link, 0, len(messages))
+
+	for _, msg := range messages {
+		publisher := trace.SpanContextFromContext(pubsub.ExtractTraceContext(context.Background(), msg.Headers))
+		if publisher.IsValid() {
+			links = append(links, trace.Link{SpanContext: publisher})
+		}
+	}
+
+	batchCtx, span := otel.GetTracerProvider().Tracer("gofr-"+version.Framework).
+		Start(ctx, "process "+topic, trace.WithSpanKind(trace.SpanKindConsumer), trace.WithLinks(links...))
+	defer span.End()
+
+	pending, failures := messages, make(map[*pubsub.Message]error)
+
+	attempts, err := s.handleWithRetry(ctx, topic, options.retry, func() error {
+		start := time.Now()
+		handlerErr := s.callBatchHandler(batchCtx, pending, handler)
+		s.recordHandlerDuration(ctx, topic, start)
+
+		pending = failedMessages(pending, handlerErr, failures)
+		if len(pending) == 0 {
+			return nil
+		}
+
+		return handlerErr
+	})
+
+	// the messages are left uncommitted when ctx is done while the subscription is paused
+	if !control.waitUnpaused(ctx) {
+		return nil
+	}
+
+	for _, msg := range messages {
+		// the failures of the messages which have succeeded in a later attempt are removed by failedMessages
+		var msgErr error
+		if err != nil {
+			msgErr = failures[msg]
+		}
+
+		s.recordHandled(ctx, topic, msgErr)
+
+		if msgErr != nil && options.deadLetter != nil {
+			msgErr = s.publishDeadLetter(batchCtx, msg, options.deadLetter.Topic, attempts, msgErr)
+		}
+
+		completeMessage(msg, msgErr, options.nacksFailures())
+	}
+
+	return nil
+}
+
+// failedMessages returns the messages which have failed as per the error of the batch handler, recording their
+// errors in failures. All the messages have failed unless the error is a PartialBatchError.
+func failedMessages(messages []*pubsub.Message, err error, failures map[*pubsub.Message]error) []*pubsub.Message {
+	if err == nil {
+		return nil
+	}
+
+	var partial *PartialBatchError
+	if !errors.As(err, &partial) {
+		for _, msg := range messages {
+			failures[msg] = err
+		}
+
+		return messages
+	}
+
+	failed := make([]*pubsub.Message, 0, len(partial.Failed))
+
+	for i, msg := range messages {
+		if msgErr, ok := partial.Failed[i]; ok {
+			failures[msg] = msgErr
+			failed = append(failed, msg)
+		} else {
+			delete(failures, msg)
+		}
+	}
+
+	return failed
+}
+
+// callBatchHandler calls the handler with a new context for the batch, returning the panics as errors.
+func (s *SubscriptionManager) callBatchHandler(batchCtx context.Context, messages []*pubsub.Message,
+	handler SubscribeBatchFunc) (err error) {
+	ctx := &Context{
+		Context:   batchCtx,
+		Request:   noopRequest{},
+		Container: s.container,
+	}
+
+	defer func() {
+		if re := recover(); re != nil {
+			panicRecovery(re, ctx.Logger)
+
+			err = fmt.Errorf("%w: %v", errHandlerPanic, re)
+		}
+	}()
+
+	return handler(ctx, messages)
+}
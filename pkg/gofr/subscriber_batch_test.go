@@ -0,0 +1,245 @@
+package gofr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gofr.dev/pkg/gofr/container"
+	"gofr.dev/pkg/gofr/datasource/pubsub"
+	"gofr.dev/pkg/gofr/logging"
+)
+
+// batchSubscriber returns the queued messages one at a time, blocking once they are consumed.
+type batchSubscriber struct {
+	retrySubscriber
+
+	messages   chan *pubsub.Message
+	committers []*testCommitter
+	batchSize  int
+	batchWait  time.Duration
+}
+
+func newBatchSubscriber(count int) *batchSubscriber {
+	b := &batchSubscriber{
+		retrySubscriber: retrySubscriber{published: map[string][]byte{}},
+		messages:        make(chan *pubsub.Message, count),
+	}
+
+	for i := 0; i < count; i++ {
+		committer := &testCommitter{}
+
+		msg := pubsub.NewMessage(context.Background())
+		msg.Topic = "orders"
+		msg.Value = []byte(fmt.Sprintf(`{"orderId":"%d"}`, i))
+		msg.Committer = committer
+
+		b.messages <- msg
+		b.committers = append(b.committers, committer)
+	}
+
+	return b
+}
+
+func (b *batchSubscriber) Subscribe(ctx context.Context, _ string) (*pubsub.Message, error) {
+	select {
+	case msg := <-b.messages:
+		return msg, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (b *batchSubscriber) BatchConfig() (size int, wait time.Duration) {
+	return b.batchSize, b.batchWait
+}
+
+func (b *batchSubscriber) commits() (committed, nacked []int) {
+	for _, c := range b.committers {
+		committed = append(committed, c.committed)
+		nacked = append(nacked, c.nacked)
+	}
+
+	return committed, nacked
+}
+
+func TestSubscriptionManager_HandleBatch(t *testing.T) {
+	retry := &RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond}
+	deadLetter := &DeadLetter{Topic: "orders-dlq"}
+
+	testCases := []struct {
+		desc         string
+		options      []SubscribeOption
+		results      []error
+		expBatches   []int
+		expCommitted []int
+		expNacked    []int
+		expDLQ       bool
+	}{
+		{"batch is committed once handled", nil, []error{nil}, []int{3}, []int{1, 1, 1}, []int{0, 0, 0}, false},
+		{"batch is left uncommitted when the handler fails", nil, []error{errHandler}, []int{3},
+			[]int{0, 0, 0}, []int{0, 0, 0}, false},
+		{"batch is nacked once the retries are exhausted", []SubscribeOption{retry}, []error{errHandler, errHandler},
+			[]int{3, 3}, []int{0, 0, 0}, []int{1, 1, 1}, false},
+		{"failed messages of partial failure are left uncommitted", nil,
+			[]error{&PartialBatchError{Failed: map[int]error{1: errHandler}}}, []int{3},
+			[]int{1, 0, 1}, []int{0, 0, 0}, false},
+		{"failed messages of partial failure are retried", []SubscribeOption{retry},
+			[]error{&PartialBatchError{Failed: map[int]error{1: errHandler}}, nil}, []int{3, 1},
+			[]int{1, 1, 1}, []int{0, 0, 0}, false},
+		{"failed messages of partial failure are moved to dead-letter topic", []SubscribeOption{retry, deadLetter},
+			[]error{&PartialBatchError{Failed: map[int]error{0: errHandler, 2: errHandler}},
+				&PartialBatchError{Failed: map[int]error{0: errHandler}}}, []int{3, 2},
+			[]int{1, 1, 1}, []int{0, 0, 0}, true},
+	}
+
+	for i, tc := range testCases {
+		subscriber := newBatchSubscriber(3)
+
+		c := &container.Container{Logger: logging.NewMockLogger(logging.FATAL), PubSub: subscriber}
+		app := &App{container: c, subscriptionManager: newSubscriptionManager(c)}
+
+		var batches []int
+
+		app.SubscribeBatch("orders", func(_ *Context, messages []*pubsub.Message) error {
+			batches = append(batches, len(messages))
+
+			return tc.results[len(batches)-1]
+		}, 3, time.Second, tc.options...)
+
+		err := app.subscriptionManager.handleBatch(context.Background(), "orders",
+			app.subscriptionManager.batchSubscriptions["orders"].handler, 3, time.Second)
+		require.NoError(t, err, "TEST[%d], Failed.\n%s", i, tc.desc)
+
+		committed, nacked := subscriber.commits()
+
+		assert.Equal(t, tc.expBatches, batches, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.expCommitted, committed, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.expNacked, nacked, "TEST[%d], Failed.\n%s", i, tc.desc)
+
+		if !tc.expDLQ {
+			assert.Empty(t, subscriber.published, "TEST[%d], Failed.\n%s", i, tc.desc)
+
+			continue
+		}
+
+		var deadLetter DeadLetterMessage
+
+		require.NoError(t, json.Unmarshal(subscriber.published["orders-dlq"], &deadLetter), "TEST[%d], Failed.\n%s", i, tc.desc)
+
+		assert.JSONEq(t, `{"orderId":"0"}`, string(deadLetter.Value), "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, 2, deadLetter.Attempts, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestSubscriptionManager_BatchPanicIsRetried(t *testing.T) {
+	subscriber := newBatchSubscriber(2)
+
+	s := newSubscriptionManager(&container.Container{Logger: logging.NewMockLogger(logging.FATAL), PubSub: subscriber})
+	s.options["orders"] = subscribeOptions{retry: &RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond}}
+
+	attempts := 0
+
+	err := s.handleBatch(context.Background(), "orders", func(*Context, []*pubsub.Message) error {
+		attempts++
+
+		panic("test panic")
+	}, 2, time.Second)
+	require.NoError(t, err)
+
+	committed, nacked := subscriber.commits()
+
+	assert.Equal(t, 2, attempts)
+	assert.Equal(t, []int{0, 0}, committed)
+	assert.Equal(t, []int{1, 1}, nacked)
+}
+
+func TestSubscriptionManager_ReceiveBatch(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		queued   int
+		size     int
+		expBatch int
+	}{
+		{"batch is full", 5, 3, 3},
+		{"wait has passed before the batch is full", 2, 3, 2},
+		{"no messages", 0, 3, 0},
+	}
+
+	for i, tc := range testCases {
+		subscriber := newBatchSubscriber(tc.queued)
+
+		s := newSubscriptionManager(&container.Container{Logger: logging.NewMockLogger(logging.FATAL), PubSub: subscriber})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+
+		messages, err := s.receiveBatch(ctx, "orders", tc.size, 10*time.Millisecond)
+
+		cancel()
+
+		assert.Len(t, messages, tc.expBatch, "TEST[%d], Failed.\n%s", i, tc.desc)
+
+		if tc.expBatch == 0 {
+			require.ErrorIs(t, err, context.DeadlineExceeded, "TEST[%d], Failed.\n%s", i, tc.desc)
+		} else {
+			require.NoError(t, err, "TEST[%d], Failed.\n%s", i, tc.desc)
+		}
+	}
+}
+
+func TestSubscriptionManager_BatchConfig(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		backendSize int
+		backendWait time.Duration
+		sub         batchSubscription
+		expSize     int
+		expWait     time.Duration
+	}{
+		{"defaults", 0, 0, batchSubscription{}, defaultBatchSize, defaultBatchWait},
+		{"configs of the backend", 500, 2 * time.Second, batchSubscription{}, 500, 2 * time.Second},
+		{"default wait with backend size", 500, 0, batchSubscription{}, 500, defaultBatchWait},
+		{"size and wait of the subscription", 500, 2 * time.Second,
+			batchSubscription{maxSize: 10, maxWait: time.Millisecond}, 10, time.Millisecond},
+	}
+
+	for i, tc := range testCases {
+		subscriber := newBatchSubscriber(0)
+		subscriber.batchSize, subscriber.batchWait = tc.backendSize, tc.backendWait
+
+		s := newSubscriptionManager(&container.Container{PubSub: subscriber})
+
+		size, wait := s.batchConfig(tc.sub)
+
+		assert.Equal(t, tc.expSize, size, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.expWait, wait, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestApp_SubscribeBatch(t *testing.T) {
+	c := &container.Container{Logger: logging.NewMockLogger(logging.FATAL), PubSub: newBatchSubscriber(0)}
+	app := &App{container: c, subscriptionManager: newSubscriptionManager(c)}
+
+	app.Subscribe("orders", func(*Context) error { return nil })
+	app.SubscribeBatch("orders", func(*Context, []*pubsub.Message) error { return nil }, 10, time.Second)
+
+	assert.Empty(t, app.subscriptionManager.subscriptions)
+	assert.Equal(t, 10, app.subscriptionManager.batchSubscriptions["orders"].maxSize)
+
+	app.Subscribe("orders", func(*Context) error { return nil })
+
+	assert.Empty(t, app.subscriptionManager.batchSubscriptions)
+	assert.Len(t, app.subscriptionManager.subscriptions, 1)
+}
+
+func TestPartialBatchError_Error(t *testing.T) {
+	err := &PartialBatchError{Failed: map[int]error{3: errHandler, 1: errSubscription}}
+
+	assert.Equal(t, "2 messages of the batch failed: message 1: subscription error, message 3: error in subscribing",
+		err.Error())
+}
@@ -0,0 +1,292 @@
+package gofr
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+	"sync"
+
+	gofrHTTP "gofr.dev/pkg/gofr/http"
+)
+
+// The statuses of the subscriptions, as listed by App.Subscriptions.
+const (
+	SubscriptionRunning  = "RUNNING"
+	SubscriptionPaused   = "PAUSED"
+	SubscriptionDraining = "DRAINING"
+	SubscriptionDrained  = "DRAINED"
+)
+
+// ErrSubscriptionNotFound is returned when controlling a topic without a subscription.
+var ErrSubscriptionNotFound = errors.New("subscription not found")
+
+// SubscriptionInfo is the status of a subscription, along with the number of its messages being handled.
+type SubscriptionInfo struct {
+	Topic    string `json:"topic"`
+	Status   string `json:"status"`
+	Batch    bool   `json:"batch"`
+	InFlight int    `json:"in_flight"`
+}
+
+// subscriptionControls holds the controls of the subscriptions by their topics, they are created on their first use
+// so that the subscriptions can be paused before they are started.
+type subscriptionControls struct {
+	mu     sync.Mutex
+	topics map[string]*subscriptionControl
+}
+
+func (c *subscriptionControls) get(topic string) *subscriptionControl {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	control, ok := c.topics[topic]
+	if !ok {
+		control = newSubscriptionControl()
+		c.topics[topic] = control
+	}
+
+	return control
+}
+
+// subscriptionControl pauses, resumes and drains a subscription. The messages are read while it is running, and the
+// messages read are counted as in flight until they are completed.
+//   - While paused, no more messages are handled, and the messages in flight are not committed until the subscription
+//     is resumed.
+//   - While draining, the messages in flight are handled and committed, and the subscription is drained once there
+//     are none left. A drained subscription stays stopped until it is resumed.
+type subscriptionControl struct {
+	mu       sync.Mutex
+	status   string
+	inFlight int
+	// changed is closed, and replaced, whenever the status or the messages in flight change.
+	changed chan struct{}
+}
+
+func newSubscriptionControl() *subscriptionControl {
+	return &subscriptionControl{status: SubscriptionRunning, changed: make(chan struct{})}
+}
+
+// waitUntil waits until ready returns true, returning false once ctx is done. ready is called with mu held.
+func (c *subscriptionControl) waitUntil(ctx context.Context, ready func() bool) bool {
+	for {
+		if ctx.Err() != nil {
+			return false
+		}
+
+		c.mu.Lock()
+		if ready() {
+			c.mu.Unlock()
+
+			return true
+		}
+
+		changed := c.changed
+		c.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return false
+		case <-changed:
+		}
+	}
+}
+
+// waitRunning waits until the subscription is running, before reading the next message.
+func (c *subscriptionControl) waitRunning(ctx context.Context) bool {
+	return c.waitUntil(ctx, func() bool { return c.status == SubscriptionRunning })
+}
+
+// acquire waits until the subscription is running, and adds the messages read to the messages in flight. The messages
+// read while the subscription is not running are held until it is resumed.
+func (c *subscriptionControl) acquire(ctx context.Context, messages int) bool {
+	return c.waitUntil(ctx, func() bool {
+		if c.status != SubscriptionRunning {
+			return false
+		}
+
+		c.inFlight += messages
+
+		return true
+	})
+}
+
+// waitUnpaused waits until the subscription is not paused, before handling or committing the messages in flight.
+func (c *subscriptionControl) waitUnpaused(ctx context.Context) bool {
+	return c.waitUntil(ctx, func() bool { return c.status != SubscriptionPaused })
+}
+
+// release removes the completed messages from the messages in flight, the subscription is drained once there are
+// none left while draining.
+func (c *subscriptionControl) release(messages int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.inFlight -= messages
+
+	if c.inFlight == 0 && c.status == SubscriptionDraining {
+		c.status = SubscriptionDrained
+	}
+
+	c.notify()
+}
+
+func (c *subscriptionControl) setStatus(status string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.status = status
+
+	if status == SubscriptionDraining && c.inFlight == 0 {
+		c.status = SubscriptionDrained
+	}
+
+	c.notify()
+}
+
+// notify wakes up the waits on the subscription, it must be called with mu held.
+func (c *subscriptionControl) notify() {
+	close(c.changed)
+	c.changed = make(chan struct{})
+}
+
+// drain stops reading the messages and waits until the messages in flight are completed.
+func (c *subscriptionControl) drain(ctx context.Context) error {
+	c.setStatus(SubscriptionDraining)
+
+	if !c.waitUntil(ctx, func() bool { return c.status != SubscriptionDraining }) {
+		return ctx.Err()
+	}
+
+	return nil
+}
+
+func (c *subscriptionControl) info(topic string, batch bool) SubscriptionInfo {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return SubscriptionInfo{Topic: topic, Status: c.status, Batch: batch, InFlight: c.inFlight}
+}
+
+// subscription returns the control of the subscription of the topic, along with whether it is a batch subscription.
+func (s *SubscriptionManager) subscription(topic string) (control *subscriptionControl, batch bool, err error) {
+	if _, ok := s.subscriptions[topic]; ok {
+		return s.controls.get(topic), false, nil
+	}
+
+	if _, ok := s.batchSubscriptions[topic]; ok {
+		return s.controls.get(topic), true, nil
+	}
+
+	return nil, false, ErrSubscriptionNotFound
+}
+
+// Subscriptions lists the subscriptions of the app by their topics, along with their status.
+func (a *App) Subscriptions() []SubscriptionInfo {
+	s := &a.subscriptionManager
+
+	topics := make([]string, 0, len(s.subscriptions)+len(s.batchSubscriptions))
+
+	for topic := range s.subscriptions {
+		topics = append(topics, topic)
+	}
+
+	for topic := range s.batchSubscriptions {
+		topics = append(topics, topic)
+	}
+
+	sort.Strings(topics)
+
+	subscriptions := make([]SubscriptionInfo, 0, len(topics))
+
+	for _, topic := range topics {
+		control, batch, _ := s.subscription(topic)
+
+		subscriptions = append(subscriptions, control.info(topic, batch))
+	}
+
+	return subscriptions
+}
+
+// PauseSubscription stops reading the messages of the topic until it is resumed. The messages being handled are not
+// committed while the subscription is paused, so that they are committed, or redelivered, once it is resumed.
+func (a *App) PauseSubscription(topic string) error {
+	control, _, err := a.subscriptionManager.subscription(topic)
+	if err != nil {
+		return err
+	}
+
+	control.setStatus(SubscriptionPaused)
+
+	a.container.Logger.Infof("paused subscription of topic %s", topic)
+
+	return nil
+}
+
+// ResumeSubscription resumes reading the messages of the paused, or drained, subscription of the topic.
+func (a *App) ResumeSubscription(topic string) error {
+	control, _, err := a.subscriptionManager.subscription(topic)
+	if err != nil {
+		return err
+	}
+
+	control.setStatus(SubscriptionRunning)
+
+	a.container.Logger.Infof("resumed subscription of topic %s", topic)
+
+	return nil
+}
+
+// DrainSubscription stops reading the messages of the topic, and waits until the messages being handled are handled
+// and committed. The subscription stays stopped until it is resumed, even when ctx is done before it is drained.
+func (a *App) DrainSubscription(ctx context.Context, topic string) error {
+	control, _, err := a.subscriptionManager.subscription(topic)
+	if err != nil {
+		return err
+	}
+
+	a.container.Logger.Infof("draining subscription of topic %s", topic)
+
+	return control.drain(ctx)
+}
+
+// addSubscriptionRoutes adds the endpoints listing, pausing, resuming and draining the subscriptions. The list is
+// read-only, so like the other endpoints under /.well-known it is not authenticated by the auth middlewares, while the
+// endpoints controlling the subscriptions are added under /pubsub, where the auth middlewares of the app apply.
+func (a *App) addSubscriptionRoutes() {
+	a.add(http.MethodGet, "/.well-known/subscriptions", func(*Context) (any, error) {
+		return a.Subscriptions(), nil
+	})
+
+	a.add(http.MethodPost, "/pubsub/subscriptions/{topic}/pause", a.subscriptionHandler(func(_ *Context, topic string) error {
+		return a.PauseSubscription(topic)
+	}))
+
+	a.add(http.MethodPost, "/pubsub/subscriptions/{topic}/resume", a.subscriptionHandler(func(_ *Context, topic string) error {
+		return a.ResumeSubscription(topic)
+	}))
+
+	a.add(http.MethodPost, "/pubsub/subscriptions/{topic}/drain", a.subscriptionHandler(func(c *Context, topic string) error {
+		return a.DrainSubscription(c, topic)
+	}))
+}
+
+// subscriptionHandler controls the subscription of the topic of the request, responding with its status.
+func (a *App) subscriptionHandler(control func(c *Context, topic string) error) Handler {
+	return func(c *Context) (any, error) {
+		topic := c.PathParam("topic")
+
+		err := control(c, topic)
+		if errors.Is(err, ErrSubscriptionNotFound) {
+			return nil, gofrHTTP.ErrorEntityNotFound{Name: "topic", Value: topic}
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		subscription, batch, _ := a.subscriptionManager.subscription(topic)
+
+		return subscription.info(topic, batch), nil
+	}
+}
@@ -0,0 +1,266 @@
+package gofr
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"gofr.dev/pkg/gofr/config"
+	"gofr.dev/pkg/gofr/container"
+	"gofr.dev/pkg/gofr/datasource/pubsub"
+	gofrHTTP "gofr.dev/pkg/gofr/http"
+	"gofr.dev/pkg/gofr/logging"
+)
+
+// newControlledApp creates an app subscribed to the orders topic of an in-memory pub/sub, whose handler sends the
+// values of the messages to handled, and returns once release is closed.
+func newControlledApp(t *testing.T) (app *App, mocks *container.Mocks, handled chan string, release chan struct{}) {
+	t.Helper()
+
+	c, mocks := container.NewMockContainer(t, container.WithMemoryPubSub())
+	c.Logger = logging.NewMockLogger(logging.FATAL)
+
+	mocks.Metrics.EXPECT().SetGauge("app_pubsub_consumer_lag_seconds", gomock.Any(), "topic", "orders").AnyTimes()
+	mocks.Metrics.EXPECT().RecordHistogram(gomock.Any(), "app_pubsub_subscriber_handler_duration", gomock.Any(),
+		"topic", "orders").AnyTimes()
+
+	app = &App{container: c, subscriptionManager: newSubscriptionManager(c)}
+	handled, release = make(chan string, 10), make(chan struct{})
+
+	app.Subscribe("orders", func(ctx *Context) error {
+		handled <- string(ctx.Request.(*pubsub.Message).Value)
+
+		<-release
+
+		return nil
+	})
+
+	return app, mocks, handled, release
+}
+
+// startSubscriptions starts the subscriptions of the app until the test ends.
+func startSubscriptions(t *testing.T, app *App) {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		_ = app.startSubscriptions(ctx)
+	}()
+
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+}
+
+func publish(t *testing.T, app *App, value string) {
+	t.Helper()
+
+	require.NoError(t, app.container.GetPublisher().Publish(context.Background(), "orders", []byte(value)))
+}
+
+// unacknowledged returns the messages of the orders topic delivered to the subscription, but not committed.
+func unacknowledged(mocks *container.Mocks) int {
+	details := mocks.PubSub.Health().Details["topics"].(map[string]any)["orders"].(map[string]any)
+
+	return details["consumer_groups"].(map[string]any)["default"].(map[string]any)["unacknowledged"].(int)
+}
+
+func receive(t *testing.T, handled <-chan string) string {
+	t.Helper()
+
+	select {
+	case value := <-handled:
+		return value
+	case <-time.After(time.Second):
+		t.Fatal("no message handled")
+
+		return ""
+	}
+}
+
+func TestApp_PauseAndResumeSubscription(t *testing.T) {
+	app, mocks, handled, release := newControlledApp(t)
+	close(release)
+
+	require.NoError(t, app.PauseSubscription("orders"))
+
+	startSubscriptions(t, app)
+	publish(t, app, "1")
+
+	assert.Never(t, func() bool { return len(handled) > 0 }, 50*time.Millisecond, time.Millisecond,
+		"messages should not be handled while the subscription is paused")
+	assert.Equal(t, []SubscriptionInfo{{Topic: "orders", Status: SubscriptionPaused}}, app.Subscriptions())
+
+	require.NoError(t, app.ResumeSubscription("orders"))
+
+	assert.Equal(t, "1", receive(t, handled))
+	assert.Eventually(t, func() bool { return unacknowledged(mocks) == 0 }, time.Second, time.Millisecond)
+}
+
+func TestApp_PausedSubscriptionDoesNotCommit(t *testing.T) {
+	app, mocks, handled, release := newControlledApp(t)
+
+	startSubscriptions(t, app)
+	publish(t, app, "1")
+
+	assert.Equal(t, "1", receive(t, handled))
+
+	require.NoError(t, app.PauseSubscription("orders"))
+	close(release)
+
+	assert.Never(t, func() bool { return unacknowledged(mocks) == 0 }, 50*time.Millisecond, time.Millisecond,
+		"messages should not be committed while the subscription is paused")
+	assert.Equal(t, []SubscriptionInfo{{Topic: "orders", Status: SubscriptionPaused, InFlight: 1}}, app.Subscriptions())
+
+	require.NoError(t, app.ResumeSubscription("orders"))
+
+	assert.Eventually(t, func() bool { return unacknowledged(mocks) == 0 }, time.Second, time.Millisecond)
+}
+
+func TestApp_DrainSubscription(t *testing.T) {
+	app, mocks, handled, release := newControlledApp(t)
+
+	startSubscriptions(t, app)
+	publish(t, app, "1")
+
+	assert.Equal(t, "1", receive(t, handled))
+
+	drained := make(chan error)
+
+	go func() {
+		drained <- app.DrainSubscription(context.Background(), "orders")
+	}()
+
+	assert.Eventually(t, func() bool {
+		return app.Subscriptions()[0] == SubscriptionInfo{Topic: "orders", Status: SubscriptionDraining, InFlight: 1}
+	}, time.Second, time.Millisecond)
+
+	close(release)
+
+	require.NoError(t, <-drained)
+	assert.Equal(t, 0, unacknowledged(mocks), "the messages in flight should be committed when draining")
+	assert.Equal(t, []SubscriptionInfo{{Topic: "orders", Status: SubscriptionDrained}}, app.Subscriptions())
+
+	publish(t, app, "2")
+
+	assert.Never(t, func() bool { return len(handled) > 0 }, 50*time.Millisecond, time.Millisecond,
+		"messages should not be handled once the subscription is drained")
+
+	require.NoError(t, app.ResumeSubscription("orders"))
+
+	assert.Equal(t, "2", receive(t, handled))
+}
+
+func TestApp_DrainSubscriptionContextDone(t *testing.T) {
+	app, _, handled, release := newControlledApp(t)
+	defer close(release)
+
+	startSubscriptions(t, app)
+	publish(t, app, "1")
+	receive(t, handled)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	require.ErrorIs(t, app.DrainSubscription(ctx, "orders"), context.DeadlineExceeded)
+	assert.Equal(t, SubscriptionDraining, app.Subscriptions()[0].Status)
+}
+
+func TestApp_ControlUnknownSubscription(t *testing.T) {
+	app, _, _, _ := newControlledApp(t)
+
+	require.ErrorIs(t, app.PauseSubscription("payments"), ErrSubscriptionNotFound)
+	require.ErrorIs(t, app.ResumeSubscription("payments"), ErrSubscriptionNotFound)
+	require.ErrorIs(t, app.DrainSubscription(context.Background(), "payments"), ErrSubscriptionNotFound)
+}
+
+func TestApp_SubscriptionHandler(t *testing.T) {
+	app, _, _, _ := newControlledApp(t)
+
+	app.SubscribeBatch("payments", func(*Context, []*pubsub.Message) error { return nil }, 10, time.Second)
+
+	testCases := []struct {
+		desc     string
+		topic    string
+		control  func(c *Context, topic string) error
+		expected any
+		err      error
+	}{
+		{"pause subscription", "orders", func(_ *Context, topic string) error { return app.PauseSubscription(topic) },
+			SubscriptionInfo{Topic: "orders", Status: SubscriptionPaused}, nil},
+		{"drain batch subscription", "payments", func(c *Context, topic string) error { return app.DrainSubscription(c, topic) },
+			SubscriptionInfo{Topic: "payments", Status: SubscriptionDrained, Batch: true}, nil},
+		{"resume unknown subscription", "refunds", func(_ *Context, topic string) error { return app.ResumeSubscription(topic) },
+			nil, gofrHTTP.ErrorEntityNotFound{Name: "topic", Value: "refunds"}},
+	}
+
+	for i, tc := range testCases {
+		req := httptest.NewRequest(http.MethodPost, "/pubsub/subscriptions/"+tc.topic+"/pause", http.NoBody)
+		req = mux.SetURLVars(req, map[string]string{"topic": tc.topic})
+
+		ctx := newContext(gofrHTTP.NewResponder(httptest.NewRecorder(), http.MethodPost), gofrHTTP.NewRequest(req),
+			app.container)
+
+		resp, err := app.subscriptionHandler(tc.control)(ctx)
+
+		assert.Equal(t, tc.err, err, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.expected, resp, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+
+	assert.Equal(t, []SubscriptionInfo{
+		{Topic: "orders", Status: SubscriptionPaused},
+		{Topic: "payments", Status: SubscriptionDrained, Batch: true},
+	}, app.Subscriptions())
+}
+
+func TestApp_SubscriptionRoutesAreAuthenticated(t *testing.T) {
+	c, _ := container.NewMockContainer(t)
+
+	app := &App{
+		httpServer:          &httpServer{router: gofrHTTP.NewRouter()},
+		container:           c,
+		Config:              config.NewMockConfig(nil),
+		subscriptionManager: newSubscriptionManager(c),
+	}
+
+	app.addSubscriptionRoutes()
+	app.EnableBasicAuth("admin", "password")
+
+	server := httptest.NewServer(app.httpServer.router)
+	defer server.Close()
+
+	testCases := []struct {
+		method string
+		path   string
+		status int
+	}{
+		{http.MethodGet, "/.well-known/subscriptions", http.StatusOK},
+		{http.MethodPost, "/pubsub/subscriptions/orders/pause", http.StatusUnauthorized},
+		{http.MethodPost, "/pubsub/subscriptions/orders/resume", http.StatusUnauthorized},
+		{http.MethodPost, "/pubsub/subscriptions/orders/drain", http.StatusUnauthorized},
+	}
+
+	for i, tc := range testCases {
+		req, err := http.NewRequestWithContext(context.Background(), tc.method, server.URL+tc.path, http.NoBody)
+		require.NoError(t, err)
+
+		resp, err := server.Client().Do(req)
+		require.NoError(t, err)
+
+		resp.Body.Close()
+
+		assert.Equal(t, tc.status, resp.StatusCode, "TEST[%d], Failed.\n%s %s", i, tc.method, tc.path)
+	}
+}
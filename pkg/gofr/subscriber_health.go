@@ -0,0 +1,149 @@
+package gofr
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"gofr.dev/pkg/gofr/datasource"
+	"gofr.dev/pkg/gofr/datasource/pubsub"
+)
+
+// subscriptionHealth tracks the messages handled by the subscriptions, a subscription is down when its latest
+// message, or its latest read from the topic, has failed.
+type subscriptionHealth struct {
+	mu     sync.RWMutex
+	topics map[string]*subscriptionStatus
+}
+
+type subscriptionStatus struct {
+	handled             int
+	failed              int
+	consecutiveFailures int
+	lastMessageAt       time.Time
+	lastErrorAt         time.Time
+	lastError           string
+}
+
+func newSubscriptionHealth() *subscriptionHealth {
+	return &subscriptionHealth{topics: make(map[string]*subscriptionStatus)}
+}
+
+// add adds the subscription of the topic, so that it is reported before its first message.
+func (h *subscriptionHealth) add(topic string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.topics[topic] == nil {
+		h.topics[topic] = &subscriptionStatus{}
+	}
+}
+
+// messageHandled records the result of handling a message of the topic.
+func (h *subscriptionHealth) messageHandled(topic string, err error) {
+	h.update(topic, func(status *subscriptionStatus) {
+		if err == nil {
+			status.handled++
+			status.consecutiveFailures = 0
+			status.lastMessageAt = time.Now()
+
+			return
+		}
+
+		status.failed++
+		status.failure(err)
+	})
+}
+
+// readFailed records the failure to read a message from the topic.
+func (h *subscriptionHealth) readFailed(topic string, err error) {
+	h.update(topic, func(status *subscriptionStatus) {
+		status.failure(err)
+	})
+}
+
+func (h *subscriptionHealth) update(topic string, update func(status *subscriptionStatus)) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	status := h.topics[topic]
+	if status == nil {
+		status = &subscriptionStatus{}
+		h.topics[topic] = status
+	}
+
+	update(status)
+}
+
+func (s *subscriptionStatus) failure(err error) {
+	s.consecutiveFailures++
+	s.lastErrorAt = time.Now()
+	s.lastError = err.Error()
+}
+
+// SubscriptionHealth returns the health of the subscriptions by their topics, along with the time of their last
+// handled message and their last error.
+func (h *subscriptionHealth) SubscriptionHealth() map[string]datasource.Health {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	health := make(map[string]datasource.Health, len(h.topics))
+
+	for topic, status := range h.topics {
+		details := map[string]any{
+			"messages_handled":     status.handled,
+			"messages_failed":      status.failed,
+			"consecutive_failures": status.consecutiveFailures,
+		}
+
+		if !status.lastMessageAt.IsZero() {
+			details["last_message_at"] = status.lastMessageAt.UTC().Format(time.RFC3339)
+		}
+
+		if !status.lastErrorAt.IsZero() {
+			details["last_error_at"] = status.lastErrorAt.UTC().Format(time.RFC3339)
+			details["last_error"] = status.lastError
+		}
+
+		topicHealth := datasource.Health{Status: datasource.StatusUp, Details: details}
+		if status.consecutiveFailures > 0 {
+			topicHealth.Status = datasource.StatusDown
+		}
+
+		health[topic] = topicHealth
+	}
+
+	return health
+}
+
+// recordReceived records the time between publishing and receiving the message, for the backends setting the
+// timestamps of the messages.
+func (s *SubscriptionManager) recordReceived(topic string, msg *pubsub.Message) {
+	if s.container.Metrics() == nil || msg.Timestamp.IsZero() {
+		return
+	}
+
+	s.container.Metrics().SetGauge("app_pubsub_consumer_lag_seconds", time.Since(msg.Timestamp).Seconds(), "topic", topic)
+}
+
+// recordHandlerDuration records the time taken by a call of the handler of the topic.
+func (s *SubscriptionManager) recordHandlerDuration(ctx context.Context, topic string, start time.Time) {
+	if s.container.Metrics() == nil {
+		return
+	}
+
+	s.container.Metrics().RecordHistogram(ctx, "app_pubsub_subscriber_handler_duration", time.Since(start).Seconds(),
+		"topic", topic)
+}
+
+// recordHandled records the result of handling a message of the topic, once its attempts are exhausted, in the
+// metrics and the health of the subscription.
+func (s *SubscriptionManager) recordHandled(ctx context.Context, topic string, err error) {
+	s.health.messageHandled(topic, err)
+
+	if err == nil || s.container.Metrics() == nil {
+		return
+	}
+
+	s.container.Metrics().IncrementCounter(ctx, "app_pubsub_subscriber_error_count", "topic", topic)
+}
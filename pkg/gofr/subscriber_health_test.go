@@ -0,0 +1,73 @@
+package gofr
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gofr.dev/pkg/gofr/container"
+	"gofr.dev/pkg/gofr/datasource"
+	"gofr.dev/pkg/gofr/datasource/pubsub"
+	"gofr.dev/pkg/gofr/logging"
+)
+
+func TestSubscriptionHealth(t *testing.T) {
+	health := newSubscriptionHealth()
+
+	health.add("orders")
+	health.add("payments")
+	health.add("refunds")
+
+	health.messageHandled("orders", nil)
+	health.messageHandled("orders", errTest)
+	health.messageHandled("orders", nil)
+	health.messageHandled("payments", errTest)
+	health.readFailed("refunds", errTest)
+
+	subscriptions := health.SubscriptionHealth()
+	require.Len(t, subscriptions, 3)
+
+	testCases := []struct {
+		topic     string
+		status    string
+		handled   int
+		failed    int
+		lastError bool
+	}{
+		{"orders", datasource.StatusUp, 2, 1, true},
+		{"payments", datasource.StatusDown, 0, 1, true},
+		{"refunds", datasource.StatusDown, 0, 0, true},
+	}
+
+	for i, tc := range testCases {
+		subscription := subscriptions[tc.topic]
+
+		assert.Equal(t, tc.status, subscription.Status, "TEST[%d], Failed.\n%s", i, tc.topic)
+		assert.Equal(t, tc.handled, subscription.Details["messages_handled"], "TEST[%d], Failed.\n%s", i, tc.topic)
+		assert.Equal(t, tc.failed, subscription.Details["messages_failed"], "TEST[%d], Failed.\n%s", i, tc.topic)
+		assert.Equal(t, errTest.Error(), subscription.Details["last_error"], "TEST[%d], Failed.\n%s", i, tc.topic)
+	}
+
+	assert.Contains(t, subscriptions["orders"].Details, "last_message_at")
+	assert.NotContains(t, subscriptions["payments"].Details, "last_message_at")
+}
+
+func TestSubscriptionManager_HealthIsReported(t *testing.T) {
+	c := &container.Container{Logger: logging.NewMockLogger(logging.FATAL)}
+	c.PubSub = &mockSubscriber{}
+
+	s := newSubscriptionManager(c)
+
+	s.health.add("test-topic")
+	s.recordReceived("test-topic", &pubsub.Message{Timestamp: time.Now()})
+	s.recordHandled(context.Background(), "test-topic", nil)
+
+	health := c.Health(context.Background()).(map[string]any)
+	details := health["pubsub"].(datasource.Health).Details
+
+	require.Contains(t, details, "subscriptions")
+	assert.Equal(t, datasource.StatusUp, details["subscriptions"].(map[string]datasource.Health)["test-topic"].Status)
+}
@@ -2,6 +2,7 @@ package gofr
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
@@ -10,10 +11,17 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/mock/gomock"
 
 	"gofr.dev/pkg/gofr/container"
 	"gofr.dev/pkg/gofr/datasource"
 	"gofr.dev/pkg/gofr/datasource/pubsub"
+	"gofr.dev/pkg/gofr/datasource/pubsub/codec"
 	"gofr.dev/pkg/gofr/datasource/pubsub/kafka"
 	"gofr.dev/pkg/gofr/logging"
 	"gofr.dev/pkg/gofr/testutil"
@@ -50,6 +58,10 @@ func (mockSubscriber) Publish(_ context.Context, _ string, _ []byte) error {
 	return nil
 }
 
+func (mockSubscriber) PublishMessage(_ context.Context, _ string, _ *pubsub.PublishMessage) error {
+	return nil
+}
+
 func (mockSubscriber) Subscribe(ctx context.Context, topic string) (*pubsub.Message, error) {
 	msg := pubsub.NewMessage(ctx)
 	msg.Topic = topic
@@ -180,3 +192,302 @@ func TestSubscriptionManager_ShouldStopOnCtxDone(t *testing.T) {
 
 	require.NoError(t, err)
 }
+
+type testCommitter struct {
+	committed, nacked int
+}
+
+func (t *testCommitter) Commit() {
+	t.committed++
+}
+
+func (t *testCommitter) Nack() {
+	t.nacked++
+}
+
+// retrySubscriber returns a message with testCommitter for every subscription, and records the published messages.
+type retrySubscriber struct {
+	mockSubscriber
+
+	committer  *testCommitter
+	headers    map[string]string
+	published  map[string][]byte
+	publishErr error
+}
+
+func (r *retrySubscriber) Subscribe(ctx context.Context, topic string) (*pubsub.Message, error) {
+	msg := pubsub.NewMessage(ctx)
+	msg.Topic = topic
+	msg.Value = []byte(`{"orderId":"123"}`)
+	msg.Headers = r.headers
+	msg.Committer = r.committer
+
+	return msg, nil
+}
+
+func (r *retrySubscriber) Publish(_ context.Context, topic string, message []byte) error {
+	r.published[topic] = message
+
+	return r.publishErr
+}
+
+func TestSubscriptionManager_RetryAndDeadLetter(t *testing.T) {
+	errPublish := errors.New("publish failed")
+
+	testCases := []struct {
+		desc         string
+		options      []SubscribeOption
+		failures     int
+		publishErr   error
+		expAttempts  int
+		expCommitted int
+		expNacked    int
+		expDLQ       bool
+	}{
+		{"handled on first attempt", nil, 0, nil, 1, 1, 0, false},
+		// the failed message is not nacked, which would redeliver it right away over and over
+		{"failure is left uncommitted without retry or dead-letter", nil, 1, nil, 1, 0, 0, false},
+		{"handled on retry", []SubscribeOption{&RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}}, 2, nil,
+			3, 1, 0, false},
+		{"retries exhausted", []SubscribeOption{&RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond}}, 5, nil,
+			2, 0, 1, false},
+		{"moved to dead-letter topic", []SubscribeOption{&RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond},
+			&DeadLetter{Topic: "orders-dlq"}}, 5, nil, 2, 1, 0, true},
+		{"dead-letter publish fails", []SubscribeOption{&DeadLetter{Topic: "orders-dlq"}}, 5, errPublish, 1, 0, 1, true},
+	}
+
+	for i, tc := range testCases {
+		subscriber := &retrySubscriber{committer: &testCommitter{}, published: map[string][]byte{}, publishErr: tc.publishErr}
+
+		c := &container.Container{Logger: logging.NewMockLogger(logging.FATAL), PubSub: subscriber}
+		app := &App{container: c, subscriptionManager: newSubscriptionManager(c)}
+
+		attempts := 0
+
+		app.Subscribe("orders", func(*Context) error {
+			attempts++
+			if attempts <= tc.failures {
+				return handleError("order not processed")
+			}
+
+			return nil
+		}, tc.options...)
+
+		err := app.subscriptionManager.handleSubscription(context.Background(), "orders", app.subscriptionManager.subscriptions["orders"])
+		require.NoError(t, err, "TEST[%d], Failed.\n%s", i, tc.desc)
+
+		assert.Equal(t, tc.expAttempts, attempts, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.expCommitted, subscriber.committer.committed, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.expNacked, subscriber.committer.nacked, "TEST[%d], Failed.\n%s", i, tc.desc)
+
+		if !tc.expDLQ {
+			assert.Empty(t, subscriber.published, "TEST[%d], Failed.\n%s", i, tc.desc)
+
+			continue
+		}
+
+		var deadLetter DeadLetterMessage
+
+		require.NoError(t, json.Unmarshal(subscriber.published["orders-dlq"], &deadLetter), "TEST[%d], Failed.\n%s", i, tc.desc)
+
+		assert.Equal(t, "orders", deadLetter.Topic, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.JSONEq(t, `{"orderId":"123"}`, string(deadLetter.Value), "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, "error in subscribing: order not processed", deadLetter.Error, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.expAttempts, deadLetter.Attempts, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestSubscriptionManager_PanicIsRetried(t *testing.T) {
+	subscriber := &retrySubscriber{committer: &testCommitter{}, published: map[string][]byte{}}
+
+	s := newSubscriptionManager(&container.Container{Logger: logging.NewMockLogger(logging.FATAL), PubSub: subscriber})
+	s.options["orders"] = subscribeOptions{
+		retry:      &RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond},
+		deadLetter: &DeadLetter{Topic: "orders-dlq"},
+	}
+
+	err := s.handleSubscription(context.Background(), "orders", func(*Context) error {
+		panic("test panic")
+	})
+	require.NoError(t, err)
+
+	var deadLetter DeadLetterMessage
+
+	require.NoError(t, json.Unmarshal(subscriber.published["orders-dlq"], &deadLetter))
+
+	assert.Equal(t, "panic in handler: test panic", deadLetter.Error)
+	assert.Equal(t, 2, deadLetter.Attempts)
+	assert.Equal(t, 1, subscriber.committer.committed)
+}
+
+func TestSubscriptionManager_PropagatesTraceContext(t *testing.T) {
+	tracerProvider, propagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	defer func() {
+		otel.SetTracerProvider(tracerProvider)
+		otel.SetTextMapPropagator(propagator)
+	}()
+
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	publishCtx, publishSpan := otel.Tracer("test").Start(context.Background(), "publish")
+	publishSpan.End()
+
+	subscriber := &retrySubscriber{
+		committer: &testCommitter{},
+		headers:   pubsub.InjectTraceContext(publishCtx, nil),
+		published: map[string][]byte{},
+	}
+
+	s := newSubscriptionManager(&container.Container{Logger: logging.NewMockLogger(logging.FATAL), PubSub: subscriber})
+
+	var handlerSpan trace.SpanContext
+
+	err := s.handleSubscription(context.Background(), "orders", func(c *Context) error {
+		handlerSpan = trace.SpanContextFromContext(c)
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+
+	assert.Equal(t, "process orders", spans[1].Name())
+	assert.Equal(t, trace.SpanKindConsumer, spans[1].SpanKind())
+	assert.Equal(t, publishSpan.SpanContext().SpanID(), spans[1].Parent().SpanID())
+	assert.Equal(t, publishSpan.SpanContext().TraceID(), handlerSpan.TraceID())
+	assert.Equal(t, spans[1].SpanContext().SpanID(), handlerSpan.SpanID())
+}
+
+func TestSubscriptionManager_DeadLetterBinaryValue(t *testing.T) {
+	subscriber := &retrySubscriber{published: map[string][]byte{}}
+
+	s := newSubscriptionManager(&container.Container{Logger: logging.NewMockLogger(logging.FATAL), PubSub: subscriber})
+
+	// e.g. an Avro encoded message, which is not valid UTF-8
+	value := []byte{0x00, 0x00, 0x00, 0x00, 0x01, 0xff, 0xfe, 0x06}
+
+	msg := pubsub.NewMessage(context.Background())
+	msg.Topic = "orders"
+	msg.Value = value
+
+	require.NoError(t, s.publishDeadLetter(context.Background(), msg, "orders-dlq", 1, errHandler))
+
+	var deadLetter DeadLetterMessage
+
+	require.NoError(t, json.Unmarshal(subscriber.published["orders-dlq"], &deadLetter))
+
+	assert.Equal(t, value, deadLetter.Value)
+}
+
+func TestSubscriptionManager_MemoryPubSub(t *testing.T) {
+	c, mocks := container.NewMockContainer(t, container.WithMemoryPubSub())
+	c.Logger = logging.NewMockLogger(logging.FATAL)
+
+	mocks.Metrics.EXPECT().SetGauge("app_pubsub_consumer_lag_seconds", gomock.Any(), "topic", "orders").Times(3)
+	mocks.Metrics.EXPECT().RecordHistogram(gomock.Any(), "app_pubsub_subscriber_handler_duration", gomock.Any(),
+		"topic", "orders").Times(3)
+	mocks.Metrics.EXPECT().IncrementCounter(gomock.Any(), "app_pubsub_subscriber_error_count", "topic", "orders")
+
+	app := &App{container: c, subscriptionManager: newSubscriptionManager(c)}
+
+	var orders []string
+
+	app.Subscribe("orders", func(ctx *Context) error {
+		var order struct {
+			OrderID string `json:"orderId"`
+		}
+
+		if err := ctx.Bind(&order); err != nil {
+			return err
+		}
+
+		if order.OrderID == "2" {
+			return handleError("order not processed")
+		}
+
+		orders = append(orders, order.OrderID)
+
+		return nil
+	}, &DeadLetter{Topic: "orders-dlq"})
+
+	for _, order := range []string{`{"orderId":"1"}`, `{"orderId":"2"}`, `{"orderId":"3"}`} {
+		require.NoError(t, c.GetPublisher().Publish(context.Background(), "orders", []byte(order)))
+	}
+
+	for i := 0; i < 3; i++ {
+		err := app.subscriptionManager.handleSubscription(context.Background(), "orders", app.subscriptionManager.subscriptions["orders"])
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, []string{"1", "3"}, orders)
+	require.Len(t, mocks.PubSub.Messages("orders-dlq"), 1)
+
+	var deadLetter DeadLetterMessage
+
+	require.NoError(t, json.Unmarshal(mocks.PubSub.Messages("orders-dlq")[0], &deadLetter))
+	assert.JSONEq(t, `{"orderId":"2"}`, string(deadLetter.Value))
+
+	details := mocks.PubSub.Health().Details["topics"].(map[string]any)["orders"].(map[string]any)
+
+	assert.Equal(t, map[string]any{"default": map[string]any{"pending": 0, "unacknowledged": 0}}, details["consumer_groups"])
+}
+
+func TestSubscriptionManager_InvalidMessageIsNotRetried(t *testing.T) {
+	c, mocks := container.NewMockContainer(t, container.WithMemoryPubSub())
+	c.Logger = logging.NewMockLogger(logging.FATAL)
+
+	mocks.Metrics.EXPECT().SetGauge("app_pubsub_consumer_lag_seconds", gomock.Any(), "topic", "orders").Times(2)
+	mocks.Metrics.EXPECT().RecordHistogram(gomock.Any(), "app_pubsub_subscriber_handler_duration", gomock.Any(),
+		"topic", "orders").Times(2)
+	mocks.Metrics.EXPECT().IncrementCounter(gomock.Any(), "app_pubsub_subscriber_error_count", "topic", "orders")
+
+	app := &App{container: c, subscriptionManager: newSubscriptionManager(c)}
+
+	schema, err := codec.NewJSONSchema(`{"type":"object","required":["orderId"],"properties":{"orderId":{"type":"string"}}}`)
+	require.NoError(t, err)
+
+	app.UseCodec("orders", schema)
+
+	var attempts int
+
+	app.Subscribe("orders", func(ctx *Context) error {
+		attempts++
+
+		var order struct {
+			OrderID string `json:"orderId"`
+		}
+
+		return ctx.Bind(&order)
+	}, &RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}, &DeadLetter{Topic: "orders-dlq"})
+
+	value, err := c.EncodeMessage(context.Background(), "orders", map[string]string{"orderId": "1"})
+	require.NoError(t, err)
+
+	_, err = c.EncodeMessage(context.Background(), "orders", map[string]int{"orderId": 2})
+	require.ErrorIs(t, err, pubsub.ErrInvalidMessage)
+
+	require.ErrorIs(t, c.GetPublisher().Publish(context.Background(), "orders", []byte(`{"orderId":2}`)), pubsub.ErrInvalidMessage)
+
+	// the invalid message is published by a publisher without the codec
+	for _, order := range [][]byte{value, []byte(`{"orderId":2}`)} {
+		require.NoError(t, c.PubSub.Publish(context.Background(), "orders", order))
+	}
+
+	for i := 0; i < 2; i++ {
+		err = app.subscriptionManager.handleSubscription(context.Background(), "orders", app.subscriptionManager.subscriptions["orders"])
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, 2, attempts)
+	require.Len(t, mocks.PubSub.Messages("orders-dlq"), 1)
+
+	var deadLetter DeadLetterMessage
+
+	require.NoError(t, json.Unmarshal(mocks.PubSub.Messages("orders-dlq")[0], &deadLetter))
+	assert.JSONEq(t, `{"orderId":2}`, string(deadLetter.Value))
+	assert.Equal(t, 1, deadLetter.Attempts)
+	assert.Contains(t, deadLetter.Error, "at '/orderId': got number, want string")
+}
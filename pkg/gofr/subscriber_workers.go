@@ -0,0 +1,184 @@
+package gofr
+
+import (
+	"context"
+	"hash/fnv"
+	"sync"
+
+	"gofr.dev/pkg/gofr/datasource/pubsub"
+)
+
+type workerMessage struct {
+	msg   *pubsub.Message
+	entry *sequencedMessage
+}
+
+// startConcurrentSubscriber continuously subscribes to a topic and dispatches its messages to the workers of the
+// subscription, the queued messages are handled by the workers before it returns.
+func (s *SubscriptionManager) startConcurrentSubscriber(ctx context.Context, topic string, handler SubscribeFunc,
+	workers *Workers) error {
+	var (
+		wg        sync.WaitGroup
+		sequencer = newCommitSequencer(s.options[topic].nacksFailures())
+		queues    = make([]chan workerMessage, workers.Count)
+		metrics   = s.container.Metrics()
+		control   = s.controls.get(topic)
+	)
+
+	metrics.SetGauge("app_pubsub_subscriber_workers", float64(workers.Count), "topic", topic)
+
+	for i := range queues {
+		queues[i] = make(chan workerMessage, max(workers.QueueSize, 1))
+
+		wg.Add(1)
+
+		go func(queue <-chan workerMessage) {
+			defer wg.Done()
+
+			for m := range queue {
+				metrics.DeltaUpDownCounter(ctx, "app_pubsub_subscriber_queue_depth", -1, "topic", topic)
+
+				s.handleQueuedMessage(ctx, topic, m, handler, sequencer, control)
+			}
+		}(queues[i])
+	}
+
+	defer func() {
+		for _, queue := range queues {
+			close(queue)
+		}
+
+		wg.Wait()
+	}()
+
+	for next := 0; ; {
+		if !control.waitRunning(ctx) {
+			s.container.Logger.Infof("shutting down subscriber for topic %s", topic)
+			return nil
+		}
+
+		msg, err := s.container.GetSubscriber().Subscribe(ctx, topic)
+		if err != nil {
+			s.container.Logger.Errorf("error while reading from topic %v, err: %v", topic, err.Error())
+			s.health.readFailed(topic, err)
+
+			continue
+		}
+
+		if msg == nil || !control.acquire(ctx, 1) {
+			continue
+		}
+
+		worker := next
+		if msg.Key != "" || msg.Partition != "" {
+			worker = orderedWorker(msg.Partition, msg.Key, len(queues))
+		} else {
+			next = (next + 1) % len(queues)
+		}
+
+		metrics.DeltaUpDownCounter(ctx, "app_pubsub_subscriber_queue_depth", 1, "topic", topic)
+
+		queues[worker] <- workerMessage{msg: msg, entry: sequencer.add(msg)}
+	}
+}
+
+// handleQueuedMessage handles a message dispatched to a worker, once the subscription is not paused, and completes it
+// in the order of the messages of the subscription. The messages are neither handled nor committed while the
+// subscription is paused, and are left uncommitted when ctx is done meanwhile.
+func (s *SubscriptionManager) handleQueuedMessage(ctx context.Context, topic string, m workerMessage,
+	handler SubscribeFunc, sequencer *commitSequencer, control *subscriptionControl) {
+	defer control.release(1)
+
+	if !control.waitUnpaused(ctx) {
+		return
+	}
+
+	metrics := s.container.Metrics()
+
+	metrics.DeltaUpDownCounter(ctx, "app_pubsub_subscriber_busy_workers", 1, "topic", topic)
+
+	err := s.handleMessage(ctx, topic, m.msg, handler)
+
+	metrics.DeltaUpDownCounter(ctx, "app_pubsub_subscriber_busy_workers", -1, "topic", topic)
+
+	if control.waitUnpaused(ctx) {
+		sequencer.complete(m.entry, err)
+	}
+}
+
+// orderedWorker returns the worker for the messages of the key within the partition, so that they are handled in
+// the order of the partition. The messages of a partition without a key are all handled by the same worker.
+func orderedWorker(partition, key string, workers int) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(partition))
+	_, _ = h.Write([]byte{0})
+	_, _ = h.Write([]byte(key))
+
+	return int(h.Sum32() % uint32(workers))
+}
+
+// commitSequencer completes the messages of each partition of a subscription in the order they are received, so that
+// the commits of a partition only advance once all its earlier messages are handled, even though the messages are
+// handled concurrently. The messages of the backends without partitions are completed as soon as they are handled, as
+// these backends acknowledge each message on its own.
+type commitSequencer struct {
+	mu      sync.Mutex
+	pending map[string][]*sequencedMessage
+	nack    bool
+}
+
+type sequencedMessage struct {
+	msg  *pubsub.Message
+	done bool
+	err  error
+}
+
+func newCommitSequencer(nack bool) *commitSequencer {
+	return &commitSequencer{pending: make(map[string][]*sequencedMessage), nack: nack}
+}
+
+func (c *commitSequencer) add(msg *pubsub.Message) *sequencedMessage {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := &sequencedMessage{msg: msg}
+
+	if msg.Partition != "" {
+		c.pending[msg.Partition] = append(c.pending[msg.Partition], entry)
+	}
+
+	return entry
+}
+
+// complete marks the message as handled, completing it along with the later messages of its partition which are
+// already handled once all the earlier messages of the partition are completed. The messages without a partition are
+// completed right away.
+func (c *commitSequencer) complete(entry *sequencedMessage, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry.done, entry.err = true, err
+
+	partition := entry.msg.Partition
+	if partition == "" {
+		completeMessage(entry.msg, err, c.nack)
+
+		return
+	}
+	pending := c.pending[partition]
+
+	for len(pending) > 0 && pending[0].done {
+		completeMessage(pending[0].msg, pending[0].err, c.nack)
+
+		pending[0] = nil
+		pending = pending[1:]
+	}
+
+	if len(pending) == 0 {
+		delete(c.pending, partition)
+
+		return
+	}
+
+	c.pending[partition] = pending
+}
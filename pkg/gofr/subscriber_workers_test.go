@@ -0,0 +1,185 @@
+package gofr
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"gofr.dev/pkg/gofr/container"
+	"gofr.dev/pkg/gofr/datasource/pubsub"
+	"gofr.dev/pkg/gofr/logging"
+)
+
+// commitLog records the order in which the messages are committed.
+type commitLog struct {
+	mu        sync.Mutex
+	committed []string
+}
+
+type loggedCommitter struct {
+	value string
+	log   *commitLog
+}
+
+func (l *loggedCommitter) Commit() {
+	l.log.mu.Lock()
+	defer l.log.mu.Unlock()
+
+	l.log.committed = append(l.log.committed, l.value)
+}
+
+func (l *commitLog) values() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return append([]string(nil), l.committed...)
+}
+
+func newKeyedMessages(log *commitLog, keys ...string) chan *pubsub.Message {
+	messages := make(chan *pubsub.Message, len(keys))
+
+	for i, key := range keys {
+		msg := pubsub.NewMessage(context.Background())
+		msg.Topic = "orders"
+		msg.Key = key
+		msg.Value = []byte{byte('0' + i)}
+		msg.Committer = &loggedCommitter{value: string(msg.Value), log: log}
+
+		messages <- msg
+	}
+
+	return messages
+}
+
+func TestSubscriptionManager_ConcurrentWorkers(t *testing.T) {
+	c, mocks := container.NewMockContainer(t)
+	c.Logger = logging.NewMockLogger(logging.FATAL)
+
+	log := &commitLog{}
+	subscriber := newBatchSubscriber(0)
+	subscriber.messages = newKeyedMessages(log, "a", "b", "a", "b")
+	c.PubSub = subscriber
+
+	// the messages of a partition are committed in order, however they are handled
+	for range len(subscriber.messages) {
+		msg := <-subscriber.messages
+		msg.Partition = "0"
+		subscriber.messages <- msg
+	}
+
+	mocks.Metrics.EXPECT().SetGauge("app_pubsub_subscriber_workers", float64(2), "topic", "orders")
+	mocks.Metrics.EXPECT().DeltaUpDownCounter(gomock.Any(), "app_pubsub_subscriber_queue_depth", float64(1), "topic", "orders").Times(4)
+	mocks.Metrics.EXPECT().DeltaUpDownCounter(gomock.Any(), "app_pubsub_subscriber_queue_depth", float64(-1), "topic", "orders").Times(4)
+	mocks.Metrics.EXPECT().DeltaUpDownCounter(gomock.Any(), "app_pubsub_subscriber_busy_workers", float64(1), "topic", "orders").Times(4)
+	mocks.Metrics.EXPECT().DeltaUpDownCounter(gomock.Any(), "app_pubsub_subscriber_busy_workers", float64(-1), "topic", "orders").Times(4)
+	mocks.Metrics.EXPECT().RecordHistogram(gomock.Any(), "app_pubsub_subscriber_handler_duration", gomock.Any(),
+		"topic", "orders").Times(4)
+
+	s := newSubscriptionManager(c)
+	s.options["orders"] = subscribeOptions{workers: &Workers{Count: 2}}
+
+	var (
+		mu       sync.Mutex
+		handled  = make(map[string][]string)
+		firstOfB = make(chan struct{})
+	)
+
+	handler := func(ctx *Context) error {
+		msg := ctx.Request.(*pubsub.Message)
+
+		// the first message of key a waits for the first message of key b, which is only possible concurrently
+		switch string(msg.Value) {
+		case "0":
+			select {
+			case <-firstOfB:
+			case <-time.After(time.Second):
+				t.Error("messages of different keys are not handled concurrently")
+			}
+		case "1":
+			close(firstOfB)
+		}
+
+		mu.Lock()
+		handled[msg.Key] = append(handled[msg.Key], string(msg.Value))
+		mu.Unlock()
+
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+
+	go func() {
+		done <- s.startSubscriber(ctx, "orders", handler)
+	}()
+
+	require.Eventually(t, func() bool { return len(log.values()) == 4 }, time.Second, time.Millisecond)
+
+	cancel()
+	require.NoError(t, <-done)
+
+	assert.Equal(t, map[string][]string{"a": {"0", "2"}, "b": {"1", "3"}}, handled)
+	assert.Equal(t, []string{"0", "1", "2", "3"}, log.values(), "messages should be committed in the order they are received")
+}
+
+func TestCommitSequencer(t *testing.T) {
+	log := &commitLog{}
+	messages := newKeyedMessages(log, "a", "b", "c", "d")
+
+	var entries []*sequencedMessage
+
+	sequencer := newCommitSequencer(false)
+
+	for _, partition := range []string{"0", "1", "0", "1"} {
+		msg := <-messages
+		msg.Partition = partition
+
+		entries = append(entries, sequencer.add(msg))
+	}
+
+	// the partitions are committed independently of each other
+	sequencer.complete(entries[1], nil)
+	assert.Equal(t, []string{"1"}, log.values())
+
+	sequencer.complete(entries[2], nil)
+	assert.Equal(t, []string{"1"}, log.values())
+
+	sequencer.complete(entries[0], nil)
+	assert.Equal(t, []string{"1", "0", "2"}, log.values())
+
+	sequencer.complete(entries[3], nil)
+	assert.Equal(t, []string{"1", "0", "2", "3"}, log.values())
+	assert.Empty(t, sequencer.pending)
+}
+
+func TestCommitSequencer_MessagesWithoutPartition(t *testing.T) {
+	log := &commitLog{}
+	messages := newKeyedMessages(log, "a", "b")
+
+	sequencer := newCommitSequencer(false)
+
+	first, second := sequencer.add(<-messages), sequencer.add(<-messages)
+
+	// the messages of the backends without partitions are committed once handled, whatever their order
+	sequencer.complete(second, nil)
+	assert.Equal(t, []string{"1"}, log.values())
+
+	sequencer.complete(first, nil)
+	assert.Equal(t, []string{"1", "0"}, log.values())
+	assert.Empty(t, sequencer.pending)
+}
+
+func TestOrderedWorker(t *testing.T) {
+	assert.Equal(t, orderedWorker("0", "order-1", 8), orderedWorker("0", "order-1", 8))
+	assert.NotEqual(t, orderedWorker("", "a", 2), orderedWorker("", "b", 2))
+	assert.NotEqual(t, orderedWorker("0", "", 2), orderedWorker("1", "", 2))
+
+	for _, key := range []string{"a", "b", "order-1", "order-2"} {
+		assert.Less(t, orderedWorker("0", key, 3), 3)
+	}
+}